@@ -0,0 +1,326 @@
+// Package bcs encodes and decodes Binary Canonical Serialization, the format
+// Sui signs transactions and personal messages in.
+//
+// BCS is not self-describing: a value is written as the sequence of its
+// fields, so types describe their layout by calling the Encoder and Decoder
+// methods in field order.
+//
+//	func (p Point) MarshalBCS(e *bcs.Encoder) {
+//		e.U64(p.X)
+//		e.U64(p.Y)
+//	}
+//
+//	func (p *Point) UnmarshalBCS(d *bcs.Decoder) {
+//		p.X = d.U64()
+//		p.Y = d.U64()
+//	}
+//
+// Integers are little endian; vector lengths and enum variants are ULEB128
+// encoded; options are an enum of None and Some.
+package bcs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// MaxSequenceLength is the largest vector length BCS allows.
+const MaxSequenceLength = 1<<31 - 1
+
+// Marshaler is a type that writes itself as BCS.
+type Marshaler interface {
+	MarshalBCS(e *Encoder)
+}
+
+// Unmarshaler is a type that reads itself from BCS. Errors are recorded on
+// the Decoder.
+type Unmarshaler interface {
+	UnmarshalBCS(d *Decoder)
+}
+
+// Marshal returns the BCS encoding of v.
+func Marshal(v Marshaler) []byte {
+	var e Encoder
+	v.MarshalBCS(&e)
+	return e.Bytes()
+}
+
+// Unmarshal reads v from data, which must hold exactly one value.
+func Unmarshal(data []byte, v Unmarshaler) error {
+	d := NewDecoder(data)
+	v.UnmarshalBCS(d)
+	return d.Finish()
+}
+
+// Encoder appends BCS values to a buffer. The zero value is ready to use.
+type Encoder struct {
+	buf []byte
+}
+
+// Bytes returns the values written so far.
+func (e *Encoder) Bytes() []byte {
+	return e.buf
+}
+
+// ULEB128 writes v in 7-bit groups, least significant first, with the high
+// bit set on every byte but the last.
+func (e *Encoder) ULEB128(v uint32) {
+	for v >= 0x80 {
+		e.buf = append(e.buf, byte(v)|0x80)
+		v >>= 7
+	}
+	e.buf = append(e.buf, byte(v))
+}
+
+func (e *Encoder) Bool(v bool) {
+	if v {
+		e.U8(1)
+	} else {
+		e.U8(0)
+	}
+}
+
+func (e *Encoder) U8(v uint8) {
+	e.buf = append(e.buf, v)
+}
+
+func (e *Encoder) U16(v uint16) {
+	e.buf = binary.LittleEndian.AppendUint16(e.buf, v)
+}
+
+func (e *Encoder) U32(v uint32) {
+	e.buf = binary.LittleEndian.AppendUint32(e.buf, v)
+}
+
+func (e *Encoder) U64(v uint64) {
+	e.buf = binary.LittleEndian.AppendUint64(e.buf, v)
+}
+
+// Length writes the length of a vector; its n elements follow. It panics
+// when n is beyond MaxSequenceLength.
+func (e *Encoder) Length(n int) {
+	if n < 0 || n > MaxSequenceLength {
+		panic(fmt.Sprintf("bcs: sequence length %d out of range", n))
+	}
+	e.ULEB128(uint32(n))
+}
+
+// ByteVector writes a vector<u8>: the length of v followed by its bytes.
+func (e *Encoder) ByteVector(v []byte) {
+	e.Length(len(v))
+	e.buf = append(e.buf, v...)
+}
+
+// FixedBytes writes v without a length, as for fixed-size arrays.
+func (e *Encoder) FixedBytes(v []byte) {
+	e.buf = append(e.buf, v...)
+}
+
+// UTF8String writes s as a vector of its UTF-8 bytes.
+func (e *Encoder) UTF8String(s string) {
+	e.ByteVector([]byte(s))
+}
+
+// Option writes None, or Some followed by the value written by some.
+func (e *Encoder) Option(present bool, some func(e *Encoder)) {
+	e.Bool(present)
+	if present {
+		some(e)
+	}
+}
+
+// Variant writes the index of an enum variant; its fields follow.
+func (e *Encoder) Variant(index uint32) {
+	e.ULEB128(index)
+}
+
+// Encode writes v.
+func (e *Encoder) Encode(v Marshaler) {
+	v.MarshalBCS(e)
+}
+
+var (
+	// ErrUnexpectedEOF is recorded when a value runs past the input.
+	ErrUnexpectedEOF = errors.New("bcs: unexpected end of input")
+	// ErrNonCanonical is recorded for encodings BCS forbids, such as
+	// ULEB128 values with redundant bytes or booleans other than 0 and 1.
+	ErrNonCanonical = errors.New("bcs: non-canonical encoding")
+)
+
+// Decoder reads BCS values from a byte slice. The first error stops it: the
+// following reads return zero values and Err reports the error.
+type Decoder struct {
+	data []byte
+	off  int
+	err  error
+}
+
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{data: data}
+}
+
+// Err returns the first error met while decoding.
+func (d *Decoder) Err() error {
+	return d.err
+}
+
+// Fail records err unless an error was already recorded, for Unmarshalers
+// that find invalid values.
+func (d *Decoder) Fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+// Remaining returns how many bytes are left to read.
+func (d *Decoder) Remaining() int {
+	return len(d.data) - d.off
+}
+
+// Finish returns the first error, or an error when input is left over.
+func (d *Decoder) Finish() error {
+	if d.err == nil && d.Remaining() > 0 {
+		d.err = fmt.Errorf("bcs: %d bytes left after the value", d.Remaining())
+	}
+	return d.err
+}
+
+func (d *Decoder) read(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n > d.Remaining() {
+		d.err = ErrUnexpectedEOF
+		return nil
+	}
+	b := d.data[d.off : d.off+n]
+	d.off += n
+	return b
+}
+
+// ULEB128 reads a ULEB128 value of at most 32 bits in its shortest form.
+func (d *Decoder) ULEB128() uint32 {
+	var v uint64
+	for shift := 0; shift < 35; shift += 7 {
+		b := d.read(1)
+		if b == nil {
+			return 0
+		}
+		v |= uint64(b[0]&0x7f) << shift
+		if b[0]&0x80 == 0 {
+			if shift > 0 && b[0] == 0 {
+				d.Fail(fmt.Errorf("%w: ULEB128 with trailing zero byte", ErrNonCanonical))
+				return 0
+			}
+			if v > 1<<32-1 {
+				d.Fail(fmt.Errorf("%w: ULEB128 value overflows 32 bits", ErrNonCanonical))
+				return 0
+			}
+			return uint32(v)
+		}
+	}
+	d.Fail(fmt.Errorf("%w: ULEB128 longer than 5 bytes", ErrNonCanonical))
+	return 0
+}
+
+func (d *Decoder) Bool() bool {
+	b := d.read(1)
+	if b == nil {
+		return false
+	}
+	if b[0] > 1 {
+		d.Fail(fmt.Errorf("%w: boolean %d", ErrNonCanonical, b[0]))
+		return false
+	}
+	return b[0] == 1
+}
+
+func (d *Decoder) U8() uint8 {
+	b := d.read(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (d *Decoder) U16() uint16 {
+	b := d.read(2)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(b)
+}
+
+func (d *Decoder) U32() uint32 {
+	b := d.read(4)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
+func (d *Decoder) U64() uint64 {
+	b := d.read(8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+// Length reads the length of a vector. It fails when the length is beyond
+// MaxSequenceLength or the bytes left, as every element takes at least one.
+func (d *Decoder) Length() int {
+	n := d.ULEB128()
+	if d.err != nil {
+		return 0
+	}
+	if n > MaxSequenceLength {
+		d.Fail(fmt.Errorf("bcs: sequence length %d out of range", n))
+		return 0
+	}
+	if int(n) > d.Remaining() {
+		d.Fail(ErrUnexpectedEOF)
+		return 0
+	}
+	return int(n)
+}
+
+// ByteVector reads a vector<u8>. The result aliases the input.
+func (d *Decoder) ByteVector() []byte {
+	n := d.Length()
+	return d.read(n)
+}
+
+// FixedBytes reads n bytes without a length. The result aliases the input.
+func (d *Decoder) FixedBytes(n int) []byte {
+	return d.read(n)
+}
+
+// UTF8String reads a vector of UTF-8 bytes.
+func (d *Decoder) UTF8String() string {
+	b := d.ByteVector()
+	if b != nil && !utf8.Valid(b) {
+		d.Fail(errors.New("bcs: string is not valid UTF-8"))
+		return ""
+	}
+	return string(b)
+}
+
+// Option reads whether an option is Some; its value follows when it is.
+func (d *Decoder) Option() bool {
+	return d.Bool()
+}
+
+// Variant reads the index of an enum variant; its fields follow.
+func (d *Decoder) Variant() uint32 {
+	return d.ULEB128()
+}
+
+// Decode reads v.
+func (d *Decoder) Decode(v Unmarshaler) {
+	if d.err == nil {
+		v.UnmarshalBCS(d)
+	}
+}
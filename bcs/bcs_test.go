@@ -0,0 +1,192 @@
+package bcs_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Gateway-DAO/gateway-go-sdk/bcs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The golden vectors below are the examples of the BCS specification and of
+// the Sui TypeScript SDK's bcs package documentation.
+
+func TestULEB128(t *testing.T) {
+	vectors := []struct {
+		value   uint32
+		encoded []byte
+	}{
+		{0, []byte{0x00}},
+		{1, []byte{0x01}},
+		{127, []byte{0x7f}},
+		{128, []byte{0x80, 0x01}},
+		{255, []byte{0xff, 0x01}},
+		{300, []byte{0xac, 0x02}},
+		{16383, []byte{0xff, 0x7f}},
+		{16384, []byte{0x80, 0x80, 0x01}},
+		{2097152, []byte{0x80, 0x80, 0x80, 0x01}},
+		{268435456, []byte{0x80, 0x80, 0x80, 0x80, 0x01}},
+		{4294967295, []byte{0xff, 0xff, 0xff, 0xff, 0x0f}},
+	}
+
+	for _, v := range vectors {
+		var e bcs.Encoder
+		e.ULEB128(v.value)
+		assert.Equal(t, v.encoded, e.Bytes(), "encode %d", v.value)
+
+		d := bcs.NewDecoder(v.encoded)
+		assert.Equal(t, v.value, d.ULEB128(), "decode %x", v.encoded)
+		assert.NoError(t, d.Finish())
+	}
+}
+
+func TestULEB128_NonCanonical(t *testing.T) {
+	for _, encoded := range [][]byte{
+		{0x80, 0x00},
+		{0x81, 0x80, 0x00},
+		{0xff, 0xff, 0xff, 0xff, 0x1f},
+		{0x80, 0x80, 0x80, 0x80, 0x80, 0x01},
+	} {
+		d := bcs.NewDecoder(encoded)
+		d.ULEB128()
+		assert.ErrorIs(t, d.Err(), bcs.ErrNonCanonical, "%x", encoded)
+	}
+
+	d := bcs.NewDecoder([]byte{0x80})
+	d.ULEB128()
+	assert.ErrorIs(t, d.Err(), bcs.ErrUnexpectedEOF)
+}
+
+func TestIntegers(t *testing.T) {
+	var e bcs.Encoder
+	e.U8(1)
+	e.U16(4660)
+	e.U32(305419896)
+	e.U64(1311768467750121216)
+	e.Bool(true)
+	e.Bool(false)
+
+	golden := []byte{
+		0x01,
+		0x34, 0x12,
+		0x78, 0x56, 0x34, 0x12,
+		0x00, 0xef, 0xcd, 0xab, 0x78, 0x56, 0x34, 0x12,
+		0x01,
+		0x00,
+	}
+	require.Equal(t, golden, e.Bytes())
+
+	d := bcs.NewDecoder(golden)
+	assert.EqualValues(t, 1, d.U8())
+	assert.EqualValues(t, 4660, d.U16())
+	assert.EqualValues(t, 305419896, d.U32())
+	assert.EqualValues(t, uint64(1311768467750121216), d.U64())
+	assert.True(t, d.Bool())
+	assert.False(t, d.Bool())
+	assert.NoError(t, d.Finish())
+
+	d = bcs.NewDecoder([]byte{0x02})
+	d.Bool()
+	assert.ErrorIs(t, d.Err(), bcs.ErrNonCanonical)
+}
+
+func TestVectorsAndStrings(t *testing.T) {
+	var e bcs.Encoder
+	e.ByteVector([]byte{1, 2, 3})
+	e.UTF8String("çå∞≠¢õß∂ƒ∫")
+	golden := []byte{
+		0x03, 0x01, 0x02, 0x03,
+		24, 0xc3, 0xa7, 0xc3, 0xa5, 0xe2, 0x88, 0x9e, 0xe2, 0x89, 0xa0, 0xc2,
+		0xa2, 0xc3, 0xb5, 0xc3, 0x9f, 0xe2, 0x88, 0x82, 0xc6, 0x92, 0xe2, 0x88, 0xab,
+	}
+	require.Equal(t, golden, e.Bytes())
+
+	d := bcs.NewDecoder(golden)
+	assert.Equal(t, []byte{1, 2, 3}, d.ByteVector())
+	assert.Equal(t, "çå∞≠¢õß∂ƒ∫", d.UTF8String())
+	assert.NoError(t, d.Finish())
+
+	// A vector of 300 bytes needs a two byte length.
+	var long bcs.Encoder
+	long.ByteVector([]byte(strings.Repeat("a", 300)))
+	assert.Equal(t, []byte{0xac, 0x02, 'a'}, long.Bytes()[:3])
+	assert.Len(t, long.Bytes(), 302)
+
+	d = bcs.NewDecoder([]byte{0x05, 0x01, 0x02})
+	d.ByteVector()
+	assert.ErrorIs(t, d.Err(), bcs.ErrUnexpectedEOF)
+
+	d = bcs.NewDecoder([]byte{0x01, 0xff})
+	d.UTF8String()
+	assert.ErrorContains(t, d.Err(), "UTF-8")
+}
+
+type coin struct {
+	Value    uint64
+	Owner    string
+	IsLocked bool
+}
+
+func (c coin) MarshalBCS(e *bcs.Encoder) {
+	e.U64(c.Value)
+	e.UTF8String(c.Owner)
+	e.Bool(c.IsLocked)
+}
+
+func (c *coin) UnmarshalBCS(d *bcs.Decoder) {
+	c.Value = d.U64()
+	c.Owner = d.UTF8String()
+	c.IsLocked = d.Bool()
+}
+
+func TestStruct(t *testing.T) {
+	value := coin{Value: 412412400000, Owner: "Big Wallet Guy"}
+	golden := []byte{
+		128, 209, 177, 5, 96, 0, 0, 0,
+		14, 66, 105, 103, 32, 87, 97, 108, 108, 101, 116, 32, 71, 117, 121,
+		0,
+	}
+	assert.Equal(t, golden, bcs.Marshal(value))
+
+	var decoded coin
+	require.NoError(t, bcs.Unmarshal(golden, &decoded))
+	assert.Equal(t, value, decoded)
+
+	assert.ErrorContains(t, bcs.Unmarshal(append(golden, 0), &decoded), "1 bytes left")
+}
+
+// shape is an enum: Circle(u32 radius) or Rectangle(u16 width, u16 height).
+type shape struct {
+	Circle        *uint32
+	Width, Height uint16
+}
+
+func (s shape) MarshalBCS(e *bcs.Encoder) {
+	if s.Circle != nil {
+		e.Variant(0)
+		e.U32(*s.Circle)
+		return
+	}
+	e.Variant(1)
+	e.U16(s.Width)
+	e.U16(s.Height)
+}
+
+func TestEnumsAndOptions(t *testing.T) {
+	radius := uint32(7)
+	assert.Equal(t, []byte{0, 7, 0, 0, 0}, bcs.Marshal(shape{Circle: &radius}))
+	assert.Equal(t, []byte{1, 2, 0, 3, 0}, bcs.Marshal(shape{Width: 2, Height: 3}))
+
+	var e bcs.Encoder
+	e.Option(true, func(e *bcs.Encoder) { e.U8(8) })
+	e.Option(false, nil)
+	assert.Equal(t, []byte{1, 8, 0}, e.Bytes())
+
+	d := bcs.NewDecoder(e.Bytes())
+	require.True(t, d.Option())
+	assert.EqualValues(t, 8, d.U8())
+	assert.False(t, d.Option())
+	assert.NoError(t, d.Finish())
+}
@@ -1,13 +1,17 @@
 package client
 
 import (
-	"errors"
+	"context"
 )
 
 type Accounts interface {
 	Create(accountDetails AccountCreateRequest) (string, error)
+	CreateCtx(ctx context.Context, accountDetails AccountCreateRequest) (string, error)
 	GetMe() (MyAccountResponse, error)
+	GetMeCtx(ctx context.Context) (MyAccountResponse, error)
 	UpdateMe(updateDetails AccountUpdateRequest) (MyAccountResponse, error)
+	UpdateMeCtx(ctx context.Context, updateDetails AccountUpdateRequest) (MyAccountResponse, error)
+	Wallets() WalletInterface
 }
 
 type AccountsImpl struct {
@@ -23,35 +27,48 @@ func NewAccountsImpl(config Config) *AccountsImpl {
 	}
 }
 
+// Wallets returns the wallet management of the authenticated account.
+func (u *AccountsImpl) Wallets() WalletInterface {
+	return u.Wallet
+}
+
 func (u *AccountsImpl) Create(accountDetails AccountCreateRequest) (string, error) {
+	return u.CreateCtx(context.Background(), accountDetails)
+}
+
+func (u *AccountsImpl) CreateCtx(ctx context.Context, accountDetails AccountCreateRequest) (string, error) {
 	var jwtTokenResponse TokenResponse
 	var error Error
 
-	res, err := u.Config.Client.R().SetBody(&accountDetails).SetResult(&jwtTokenResponse).SetError(&error).Post(CreateAccount)
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "Accounts.Create")).SetBody(&accountDetails).SetResult(&jwtTokenResponse).SetError(&error).Post(CreateAccount)
 
 	if err != nil {
 		return jwtTokenResponse.Token, err
 	}
 
 	if res.IsError() {
-		return jwtTokenResponse.Token, errors.New(error.Error)
+		return jwtTokenResponse.Token, newAPIError(res, CreateAccount, error)
 	}
 
 	return jwtTokenResponse.Token, nil
 }
 
 func (u *AccountsImpl) GetMe() (MyAccountResponse, error) {
+	return u.GetMeCtx(context.Background())
+}
+
+func (u *AccountsImpl) GetMeCtx(ctx context.Context) (MyAccountResponse, error) {
 	var myAccountResponse MyAccountResponse
 	var error Error
 
-	res, err := u.Config.Client.R().SetResult(&myAccountResponse).SetError(&error).Get(GetMyAccount)
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "Accounts.GetMe")).SetResult(&myAccountResponse).SetError(&error).Get(GetMyAccount)
 
 	if err != nil {
 		return myAccountResponse, err
 	}
 
 	if res.IsError() {
-		return myAccountResponse, errors.New(error.Error)
+		return myAccountResponse, newAPIError(res, GetMyAccount, error)
 	}
 
 	return myAccountResponse, nil
@@ -59,17 +76,21 @@ func (u *AccountsImpl) GetMe() (MyAccountResponse, error) {
 }
 
 func (u *AccountsImpl) UpdateMe(updateDetails AccountUpdateRequest) (MyAccountResponse, error) {
+	return u.UpdateMeCtx(context.Background(), updateDetails)
+}
+
+func (u *AccountsImpl) UpdateMeCtx(ctx context.Context, updateDetails AccountUpdateRequest) (MyAccountResponse, error) {
 	var myAccountResponse MyAccountResponse
 	var error Error
 
-	res, err := u.Config.Client.R().SetBody(&updateDetails).SetResult(&myAccountResponse).SetError(&error).Patch(GetMyAccount)
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "Accounts.UpdateMe")).SetBody(&updateDetails).SetResult(&myAccountResponse).SetError(&error).Patch(GetMyAccount)
 
 	if err != nil {
 		return myAccountResponse, err
 	}
 
 	if res.IsError() {
-		return myAccountResponse, errors.New(error.Error)
+		return myAccountResponse, newAPIError(res, GetMyAccount, error)
 	}
 
 	return myAccountResponse, nil
@@ -1,13 +1,17 @@
 package client
 
 import (
-	"errors"
+	"context"
+	"fmt"
 )
 
 type ACL interface {
 	Add(id int64, aclList []ACLRequest) (PublicACL, error)
+	AddCtx(ctx context.Context, id int64, aclList []ACLRequest) (PublicACL, error)
 	Update(id int64, aclList []ACLRequest) (PublicACL, error)
+	UpdateCtx(ctx context.Context, id int64, aclList []ACLRequest) (PublicACL, error)
 	Delete(id int64, aclList []ACLRequest) (string, error)
+	DeleteCtx(ctx context.Context, id int64, aclList []ACLRequest) (string, error)
 }
 
 type ACLImpl struct {
@@ -21,17 +25,21 @@ func NewACLImpl(config Config) *ACLImpl {
 }
 
 func (u *ACLImpl) Add(id int64, aclList []ACLRequest) (PublicACL, error) {
+	return u.AddCtx(context.Background(), id, aclList)
+}
+
+func (u *ACLImpl) AddCtx(ctx context.Context, id int64, aclList []ACLRequest) (PublicACL, error) {
 	var publicACL PublicACL
 	var error Error
 
-	res, err := u.Config.Client.R().SetBody(&aclList).SetResult(&publicACL).SetError(&error).Post(AssignACLItemsToDataAsset)
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "ACL.Add")).SetPathParam("id", fmt.Sprintf("%d", id)).SetBody(&aclList).SetResult(&publicACL).SetError(&error).Post(AssignACLItemsToDataAsset)
 
 	if err != nil {
 		return publicACL, err
 	}
 
 	if res.IsError() {
-		return publicACL, errors.New(error.Error)
+		return publicACL, newAPIError(res, AssignACLItemsToDataAsset, error)
 	}
 
 	return publicACL, nil
@@ -39,34 +47,42 @@ func (u *ACLImpl) Add(id int64, aclList []ACLRequest) (PublicACL, error) {
 }
 
 func (u *ACLImpl) Update(id int64, aclList []ACLRequest) (PublicACL, error) {
+	return u.UpdateCtx(context.Background(), id, aclList)
+}
+
+func (u *ACLImpl) UpdateCtx(ctx context.Context, id int64, aclList []ACLRequest) (PublicACL, error) {
 	var publicACL PublicACL
 	var error Error
 
-	res, err := u.Config.Client.R().SetBody(&aclList).SetResult(&publicACL).SetError(&error).Put(UpdateACLItemsToDataAsset)
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "ACL.Update")).SetPathParam("id", fmt.Sprintf("%d", id)).SetBody(&aclList).SetResult(&publicACL).SetError(&error).Put(UpdateACLItemsToDataAsset)
 
 	if err != nil {
 		return publicACL, err
 	}
 
 	if res.IsError() {
-		return publicACL, errors.New(error.Error)
+		return publicACL, newAPIError(res, UpdateACLItemsToDataAsset, error)
 	}
 
 	return publicACL, nil
 }
 
 func (u *ACLImpl) Delete(id int64, aclList []ACLRequest) (string, error) {
+	return u.DeleteCtx(context.Background(), id, aclList)
+}
+
+func (u *ACLImpl) DeleteCtx(ctx context.Context, id int64, aclList []ACLRequest) (string, error) {
 	var response MessageResponse
 	var error Error
 
-	res, err := u.Config.Client.R().SetBody(&aclList).SetResult(&response).SetError(&error).Delete(DeleteAssignedRoleByACL)
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "ACL.Delete")).SetPathParam("id", fmt.Sprintf("%d", id)).SetBody(&aclList).SetResult(&response).SetError(&error).Delete(DeleteAssignedRoleByACL)
 
 	if err != nil {
 		return response.Message, err
 	}
 
 	if res.IsError() {
-		return response.Message, errors.New(error.Error)
+		return response.Message, newAPIError(res, DeleteAssignedRoleByACL, error)
 	}
 
 	return response.Message, nil
@@ -31,7 +31,7 @@ func TestACLSuite(t *testing.T) {
 			resp.Header.Set("Content-Type", "application/json")
 			return resp, nil
 		}
-		httpmock.RegisterResponder("POST", gateway.AssignACLItemsToDataAsset, responder)
+		httpmock.RegisterResponder("POST", "/data-assets/1/acl", responder)
 
 		aclList := []gateway.ACLRequest{
 			{Address: "test", Roles: []gateway.TypesAccessLevel{
@@ -48,7 +48,7 @@ func TestACLSuite(t *testing.T) {
 		httpmock.Reset()
 
 		responder := httpmock.NewStringResponder(400, `{"error": "Invalid ACL request"}`)
-		httpmock.RegisterResponder("POST", gateway.AssignACLItemsToDataAsset, responder)
+		httpmock.RegisterResponder("POST", "/data-assets/1/acl", responder)
 
 		aclList := []gateway.ACLRequest{
 			{Address: "test", Roles: []gateway.TypesAccessLevel{
@@ -63,7 +63,7 @@ func TestACLSuite(t *testing.T) {
 	t.Run("TestAddACLHttpRequestError", func(t *testing.T) {
 		httpmock.Reset()
 
-		httpmock.RegisterResponder("POST", gateway.AssignACLItemsToDataAsset, httpmock.NewErrorResponder(errors.New("http request error")))
+		httpmock.RegisterResponder("POST", "/data-assets/1/acl", httpmock.NewErrorResponder(errors.New("http request error")))
 
 		aclList := []gateway.ACLRequest{
 			{Address: "test", Roles: []gateway.TypesAccessLevel{
@@ -85,7 +85,7 @@ func TestACLSuite(t *testing.T) {
 			resp.Header.Set("Content-Type", "application/json")
 			return resp, nil
 		}
-		httpmock.RegisterResponder("PUT", gateway.UpdateACLItemsToDataAsset, responder)
+		httpmock.RegisterResponder("PUT", "/data-assets/1/acl", responder)
 
 		aclList := []gateway.ACLRequest{
 			{Address: "test", Roles: []gateway.TypesAccessLevel{
@@ -102,7 +102,7 @@ func TestACLSuite(t *testing.T) {
 		httpmock.Reset()
 
 		responder := httpmock.NewStringResponder(400, `{"error": "Invalid ACL update"}`)
-		httpmock.RegisterResponder("PUT", gateway.UpdateACLItemsToDataAsset, responder)
+		httpmock.RegisterResponder("PUT", "/data-assets/1/acl", responder)
 
 		aclList := []gateway.ACLRequest{
 			{Address: "test", Roles: []gateway.TypesAccessLevel{
@@ -117,7 +117,7 @@ func TestACLSuite(t *testing.T) {
 	t.Run("TestUpdateACLHttpRequestError", func(t *testing.T) {
 		httpmock.Reset()
 
-		httpmock.RegisterResponder("PUT", gateway.UpdateACLItemsToDataAsset, httpmock.NewErrorResponder(errors.New("http request error")))
+		httpmock.RegisterResponder("PUT", "/data-assets/1/acl", httpmock.NewErrorResponder(errors.New("http request error")))
 
 		// Test
 		aclList := []gateway.ACLRequest{
@@ -140,7 +140,7 @@ func TestACLSuite(t *testing.T) {
 			resp.Header.Set("Content-Type", "application/json")
 			return resp, nil
 		}
-		httpmock.RegisterResponder("DELETE", gateway.DeleteAssignedRoleByACL, responder)
+		httpmock.RegisterResponder("DELETE", "/data-assets/1/acl/delete", responder)
 
 		aclList := []gateway.ACLRequest{
 			{Address: "test", Roles: []gateway.TypesAccessLevel{
@@ -157,7 +157,7 @@ func TestACLSuite(t *testing.T) {
 		httpmock.Reset()
 
 		responder := httpmock.NewStringResponder(400, `{"error": "Failed to delete ACL"}`)
-		httpmock.RegisterResponder("DELETE", gateway.DeleteAssignedRoleByACL, responder)
+		httpmock.RegisterResponder("DELETE", "/data-assets/1/acl/delete", responder)
 
 		aclList := []gateway.ACLRequest{
 			{Address: "test", Roles: []gateway.TypesAccessLevel{
@@ -173,7 +173,7 @@ func TestACLSuite(t *testing.T) {
 	t.Run("TestDeleteACLHttpRequestError", func(t *testing.T) {
 		httpmock.Reset()
 
-		httpmock.RegisterResponder("DELETE", gateway.DeleteAssignedRoleByACL, httpmock.NewErrorResponder(errors.New("http request error")))
+		httpmock.RegisterResponder("DELETE", "/data-assets/1/acl/delete", httpmock.NewErrorResponder(errors.New("http request error")))
 
 		aclList := []gateway.ACLRequest{
 			{Address: "test", Roles: []gateway.TypesAccessLevel{
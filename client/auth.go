@@ -1,14 +1,19 @@
 package client
 
 import (
-	"errors"
+	"context"
 	"fmt"
 )
 
 type Auth interface {
 	Login(message string, signature string, wallet_address string) (string, error)
+	LoginCtx(ctx context.Context, message string, signature string, wallet_address string) (string, error)
+	LoginWithChain(chain WalletTypeEnum, message string, signature string, wallet_address string) (string, error)
+	LoginWithChainCtx(ctx context.Context, chain WalletTypeEnum, message string, signature string, wallet_address string) (string, error)
 	GetMessage() (string, error)
+	GetMessageCtx(ctx context.Context) (string, error)
 	GetRefreshToken() (string, error)
+	GetRefreshTokenCtx(ctx context.Context) (string, error)
 }
 
 type AuthImpl struct {
@@ -22,82 +27,131 @@ func NewAuthImpl(config Config) *AuthImpl {
 }
 
 func (u *AuthImpl) Login(message string, signature string, wallet_address string) (string, error) {
-	var isValid bool
-	var err error
-	if ValidateEtherumWallet(wallet_address) {
-		isValid, err = VerifyEtherumMessage(signature, message, wallet_address)
-		if err != nil {
-			return "", fmt.Errorf("ethereum signature verification failed: %v", err)
-		}
-		if !isValid {
-			return "", errors.New("invalid Ethereum signature")
-		}
-	} else if ValidateSuiWallet(wallet_address) {
-		isValid, err = VerifySuiMessage(message, signature, wallet_address)
-		if err != nil {
-			return "", fmt.Errorf("sui signature verification failed: %v", err)
-		}
-		if !isValid {
-			return "", errors.New("invalid sui signature")
-		}
+	return u.LoginCtx(context.Background(), message, signature, wallet_address)
+}
 
-	} else if ValidateSolanaWallet(wallet_address) {
-		isValid, err = VerifySolanaMessage(message, signature, wallet_address)
-		if err != nil {
-			return "", fmt.Errorf("solana signature verification failed: %v", err)
-		}
-		if !isValid {
-			return "", errors.New("invalid Solana signature")
+// LoginCtx verifies signature with the Verifier of the chain the wallet
+// address belongs to, then signs in. Addresses no Verifier validates are
+// left to the API unless Config.StrictVerification is set.
+func (u *AuthImpl) LoginCtx(ctx context.Context, message string, signature string, wallet_address string) (string, error) {
+	chain, verifier, ok := u.Config.verifiers().Detect(wallet_address)
+	if ok {
+		if err := u.verifySignature(chain, verifier, message, signature, wallet_address); err != nil {
+			return "", err
 		}
+	} else if u.Config.StrictVerification {
+		return "", u.signatureFailed(fmt.Errorf("%w address %q", ErrNoVerifier, wallet_address))
+	}
+
+	return u.login(ctx, message, signature, wallet_address)
+}
+
+// LoginWithChain signs in like Login with the Verifier of chain, instead of
+// guessing the chain from the wallet address.
+func (u *AuthImpl) LoginWithChain(chain WalletTypeEnum, message string, signature string, wallet_address string) (string, error) {
+	return u.LoginWithChainCtx(context.Background(), chain, message, signature, wallet_address)
+}
+
+func (u *AuthImpl) LoginWithChainCtx(ctx context.Context, chain WalletTypeEnum, message string, signature string, wallet_address string) (string, error) {
+	verifier, ok := u.Config.verifiers().Lookup(chain)
+	if !ok {
+		return "", u.signatureFailed(fmt.Errorf("%w chain %q", ErrNoVerifier, chain))
+	}
+	if err := u.verifySignature(chain, verifier, message, signature, wallet_address); err != nil {
+		return "", err
+	}
+
+	return u.login(ctx, message, signature, wallet_address)
+}
+
+func (u *AuthImpl) verifySignature(chain WalletTypeEnum, verifier Verifier, message string, signature string, wallet_address string) error {
+	isValid, err := verifier.VerifyMessage(signature, message, wallet_address)
+	if err != nil {
+		return u.signatureFailed(fmt.Errorf("%s signature verification failed: %v", chain, err))
+	}
+	if !isValid {
+		return u.signatureFailed(fmt.Errorf("invalid %s signature", chain))
 	}
+	return nil
+}
 
+func (u *AuthImpl) login(ctx context.Context, message string, signature string, wallet_address string) (string, error) {
 	var jwtTokenResponse TokenResponse
 	var error Error
 
-	res, err := u.Config.Client.R().SetBody(&AuthRequest{Message: message, Signature: signature, WalletAddress: wallet_address}).SetResult(&jwtTokenResponse).SetError(&error).Post(AuthenticateAccount)
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "Auth.Login")).SetBody(&AuthRequest{Message: message, Signature: signature, WalletAddress: wallet_address}).SetResult(&jwtTokenResponse).SetError(&error).Post(AuthenticateAccount)
 
 	if err != nil {
 		return jwtTokenResponse.Token, err
 	}
 
 	if res.IsError() {
-		return jwtTokenResponse.Token, errors.New(error.Error)
+		return jwtTokenResponse.Token, newAPIError(res, AuthenticateAccount, error)
 	}
 
 	return jwtTokenResponse.Token, nil
 }
 
+// signatureFailed reports a login signature that did not verify and
+// returns err.
+func (u *AuthImpl) signatureFailed(err error) error {
+	u.Config.metrics().SignatureFailed()
+	u.Config.logger().Warn("login signature did not verify", "error", err)
+	return err
+}
+
 func (u *AuthImpl) GetMessage() (string, error) {
+	return u.GetMessageCtx(context.Background())
+}
+
+func (u *AuthImpl) GetMessageCtx(ctx context.Context) (string, error) {
 
 	var messageResponse MessageResponse
 	var error Error
 
-	res, err := u.Config.Client.R().SetResult(&messageResponse).SetError(&error).Get(GenerateSignMessage)
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "Auth.GetMessage")).SetResult(&messageResponse).SetError(&error).Get(GenerateSignMessage)
 	if err != nil {
 		return messageResponse.Message, err
 	}
 
 	if res.IsError() {
-		return messageResponse.Message, errors.New(error.Error)
+		return messageResponse.Message, newAPIError(res, GenerateSignMessage, error)
 	}
 
 	return messageResponse.Message, nil
 }
 
 func (u *AuthImpl) GetRefreshToken() (string, error) {
+	return u.GetRefreshTokenCtx(context.Background())
+}
+
+func (u *AuthImpl) GetRefreshTokenCtx(ctx context.Context) (string, error) {
+	return u.refreshToken(ctx, nil, "")
+}
 
+// refreshToken exchanges accessToken, which holder issued, for a new one. A
+// nil holder leaves the Authorization header to the client configuration.
+func (u *AuthImpl) refreshToken(ctx context.Context, holder *tokenHolder, accessToken string) (string, error) {
 	var jwtTokenResponse TokenResponse
 	var error Error
 
-	res, err := u.Config.Client.R().SetResult(&jwtTokenResponse).SetError(&error).Get(RefreshToken)
+	req := u.Config.Client.R().SetContext(withOperation(ctx, "Auth.GetRefreshToken"))
+	if holder != nil {
+		req = holder.authorize(req, accessToken)
+	}
+
+	res, err := req.SetResult(&jwtTokenResponse).SetError(&error).Get(RefreshToken)
 
 	if err != nil {
 		return jwtTokenResponse.Token, err
 	}
 
 	if res.IsError() {
-		return jwtTokenResponse.Token, errors.New(error.Error)
+		return jwtTokenResponse.Token, newAPIError(res, RefreshToken, error)
 	}
 
+	u.Config.metrics().TokenRefreshed()
+	u.Config.logger().DebugContext(ctx, "refreshed token")
+
 	return jwtTokenResponse.Token, nil
 }
@@ -0,0 +1,266 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	DefaultBreakerFailureThreshold = 5
+	DefaultBreakerOpenTimeout      = 30 * time.Second
+	DefaultBreakerHalfOpenRequests = 1
+)
+
+// ErrCircuitOpen matches the *CircuitOpenError of requests the circuit
+// breaker did not send.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitOpenError is returned for requests refused while the circuit is
+// open, or half-open with every trial request taken.
+type CircuitOpenError struct {
+	// Until is when the circuit lets trial requests through again; it is
+	// zero while the half-open trials are running.
+	Until time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	if e.Until.IsZero() {
+		return ErrCircuitOpen.Error() + ": trial requests in progress"
+	}
+	return fmt.Sprintf("%s until %s", ErrCircuitOpen, e.Until.Format(time.RFC3339))
+}
+
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+type CircuitState int
+
+const (
+	// CircuitClosed sends every request.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen refuses every request until the open timeout passes.
+	CircuitOpen
+	// CircuitHalfOpen sends a few trial requests to decide whether to close
+	// or open the circuit again.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(s))
+}
+
+type CircuitBreakerConfig struct {
+	// FailureThreshold is how many failures in a row open the circuit.
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before trial requests
+	// are let through.
+	OpenTimeout time.Duration
+	// HalfOpenRequests is how many trial requests run while half-open; as
+	// many successes close the circuit and one failure opens it again.
+	HalfOpenRequests int
+	// IsFailure decides whether the outcome of a request counts against the
+	// API. The default counts 5xx responses, timeouts and connection
+	// errors; 4xx responses count as successes and canceled requests are
+	// ignored.
+	IsFailure func(res *http.Response, err error) bool
+	// OnStateChange is called after every transition, outside the lock.
+	OnStateChange func(from CircuitState, to CircuitState)
+}
+
+func (c CircuitBreakerConfig) withDefaults() CircuitBreakerConfig {
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = DefaultBreakerFailureThreshold
+	}
+	if c.OpenTimeout <= 0 {
+		c.OpenTimeout = DefaultBreakerOpenTimeout
+	}
+	if c.HalfOpenRequests <= 0 {
+		c.HalfOpenRequests = DefaultBreakerHalfOpenRequests
+	}
+	if c.IsFailure == nil {
+		c.IsFailure = isAPIFailure
+	}
+	return c
+}
+
+// isAPIFailure counts 5xx responses and requests that got no response,
+// except those canceled by the caller.
+func isAPIFailure(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return res.StatusCode >= http.StatusInternalServerError
+}
+
+// CircuitBreaker stops sending requests to the API after repeated failures
+// and probes it with trial requests before resuming. It is safe for
+// concurrent use and can be shared by several SDKs.
+type CircuitBreaker struct {
+	config CircuitBreakerConfig
+
+	mu        sync.Mutex
+	state     CircuitState
+	failures  int
+	openUntil time.Time
+	trials    int
+	successes int
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker configured by config.
+func NewCircuitBreaker(config CircuitBreakerConfig) *CircuitBreaker {
+	return &CircuitBreaker{config: config.withDefaults()}
+}
+
+// State returns the current state of the circuit.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen && !time.Now().Before(b.openUntil) {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// allow reserves the right to send a request, or reports why the circuit
+// refuses it. trial is set for the requests sent while half-open.
+func (b *CircuitBreaker) allow() (trial bool, err error) {
+	b.mu.Lock()
+	var changed func()
+	defer func() {
+		b.mu.Unlock()
+		if changed != nil {
+			changed()
+		}
+	}()
+
+	if b.state == CircuitOpen {
+		if time.Now().Before(b.openUntil) {
+			return false, &CircuitOpenError{Until: b.openUntil}
+		}
+		changed = b.transition(CircuitHalfOpen)
+	}
+
+	if b.state == CircuitHalfOpen {
+		if b.trials >= b.config.HalfOpenRequests {
+			return false, &CircuitOpenError{}
+		}
+		b.trials++
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// record counts the outcome of a request allowed by allow.
+func (b *CircuitBreaker) record(trial bool, res *http.Response, err error) {
+	ignored := errors.Is(err, context.Canceled)
+	failed := !ignored && b.config.IsFailure(res, err)
+
+	b.mu.Lock()
+	var changed func()
+	defer func() {
+		b.mu.Unlock()
+		if changed != nil {
+			changed()
+		}
+	}()
+
+	if trial {
+		// A trial that ended after the circuit moved on no longer counts.
+		if b.state != CircuitHalfOpen {
+			return
+		}
+		b.trials--
+		switch {
+		case ignored:
+		case failed:
+			changed = b.transition(CircuitOpen)
+		default:
+			b.successes++
+			if b.successes >= b.config.HalfOpenRequests {
+				changed = b.transition(CircuitClosed)
+			}
+		}
+		return
+	}
+
+	if b.state != CircuitClosed || ignored {
+		return
+	}
+	if !failed {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.config.FailureThreshold {
+		changed = b.transition(CircuitOpen)
+	}
+}
+
+// transition moves the circuit to state and returns the callback to run
+// once the lock is released. b.mu must be held.
+func (b *CircuitBreaker) transition(state CircuitState) func() {
+	from := b.state
+	b.state = state
+	b.failures = 0
+	b.trials = 0
+	b.successes = 0
+	if state == CircuitOpen {
+		b.openUntil = time.Now().Add(b.config.OpenTimeout)
+	}
+
+	if b.config.OnStateChange == nil || from == state {
+		return nil
+	}
+	return func() { b.config.OnStateChange(from, state) }
+}
+
+// breakerTransport sends requests through a CircuitBreaker.
+type breakerTransport struct {
+	next    http.RoundTripper
+	breaker *CircuitBreaker
+}
+
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	trial, err := t.breaker.allow()
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	res, err := t.next.RoundTrip(req)
+	t.breaker.record(trial, res, err)
+	return res, err
+}
+
+// installCircuitBreaker sends the requests of client through breaker.
+// newSDK installs it after failover and tracing and before the rate limiter,
+// so it records the outcome of a request once failover has tried every
+// endpoint, and never counts a request that failed waiting for the limiter.
+func installCircuitBreaker(client *resty.Client, breaker *CircuitBreaker) {
+	httpClient := client.GetClient()
+
+	next := httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	httpClient.Transport = &breakerTransport{next: next, breaker: breaker}
+}
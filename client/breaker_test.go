@@ -0,0 +1,162 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stateChanges struct {
+	mu      sync.Mutex
+	changes []string
+}
+
+func (s *stateChanges) record(from gateway.CircuitState, to gateway.CircuitState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changes = append(s.changes, from.String()+"->"+to.String())
+}
+
+func (s *stateChanges) get() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.changes...)
+}
+
+func TestCircuitBreaker_OpensAfterServerErrors(t *testing.T) {
+	var calls, status int32 = 0, http.StatusInternalServerError
+	server := newJSONServer(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+	})
+	defer server.Close()
+
+	var changes stateChanges
+	breaker := gateway.NewCircuitBreaker(gateway.CircuitBreakerConfig{
+		FailureThreshold: 3,
+		OpenTimeout:      50 * time.Millisecond,
+		OnStateChange:    changes.record,
+	})
+	sdk, err := gateway.New(gateway.WithAPIKey("test-key"), gateway.WithBaseURL(server.URL), gateway.WithSharedCircuitBreaker(breaker))
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = sdk.Account.GetMe()
+		assert.ErrorIs(t, err, gateway.ErrServerError)
+	}
+	assert.Equal(t, gateway.CircuitOpen, breaker.State())
+
+	_, err = sdk.Account.GetMe()
+	assert.ErrorIs(t, err, gateway.ErrCircuitOpen)
+	var openErr *gateway.CircuitOpenError
+	require.True(t, errors.As(err, &openErr))
+	assert.False(t, openErr.Until.IsZero())
+	assert.EqualValues(t, 3, atomic.LoadInt32(&calls), "open circuit does not send requests")
+
+	// The trial request fails and opens the circuit again.
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, gateway.CircuitHalfOpen, breaker.State())
+	_, err = sdk.Account.GetMe()
+	assert.ErrorIs(t, err, gateway.ErrServerError)
+	assert.Equal(t, gateway.CircuitOpen, breaker.State())
+
+	// Once the API recovers the trial request closes it.
+	atomic.StoreInt32(&status, http.StatusOK)
+	time.Sleep(60 * time.Millisecond)
+	_, err = sdk.Account.GetMe()
+	require.NoError(t, err)
+	assert.Equal(t, gateway.CircuitClosed, breaker.State())
+
+	assert.Equal(t, []string{
+		"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed",
+	}, changes.get())
+}
+
+func TestCircuitBreaker_ClientErrorsDoNotCount(t *testing.T) {
+	var calls int32
+	server := newJSONServer(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) % 3 {
+		case 0:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	})
+	defer server.Close()
+
+	sdk, err := gateway.New(
+		gateway.WithAPIKey("test-key"),
+		gateway.WithBaseURL(server.URL),
+		gateway.WithCircuitBreaker(gateway.CircuitBreakerConfig{FailureThreshold: 3}),
+	)
+	require.NoError(t, err)
+
+	// Every third request is a 404, which resets the run of failures.
+	for i := 0; i < 9; i++ {
+		_, err = sdk.Account.GetMe()
+		assert.NotErrorIs(t, err, gateway.ErrCircuitOpen)
+	}
+	assert.EqualValues(t, 9, atomic.LoadInt32(&calls))
+}
+
+func TestCircuitBreaker_TimeoutsCountCancellationsDoNot(t *testing.T) {
+	server := newJSONServer(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	defer server.Close()
+
+	breaker := gateway.NewCircuitBreaker(gateway.CircuitBreakerConfig{FailureThreshold: 2})
+	sdk, err := gateway.New(gateway.WithAPIKey("test-key"), gateway.WithBaseURL(server.URL), gateway.WithSharedCircuitBreaker(breaker))
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+		_, err = sdk.Account.GetMeCtx(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+	assert.Equal(t, gateway.CircuitClosed, breaker.State())
+
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		_, err = sdk.Account.GetMeCtx(ctx)
+		cancel()
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+	assert.Equal(t, gateway.CircuitOpen, breaker.State())
+}
+
+func TestCircuitBreaker_IsNotRetried(t *testing.T) {
+	var calls int32
+	server := newJSONServer(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer server.Close()
+
+	sdk, err := gateway.New(
+		gateway.WithAPIKey("test-key"),
+		gateway.WithBaseURL(server.URL),
+		gateway.WithRetry(gateway.RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond}),
+		gateway.WithCircuitBreaker(gateway.CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute}),
+	)
+	require.NoError(t, err)
+
+	_, err = sdk.Account.GetMe()
+	assert.ErrorIs(t, err, gateway.ErrCircuitOpen)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&calls))
+}
+
+func TestWithSharedCircuitBreaker_Nil(t *testing.T) {
+	_, err := gateway.New(gateway.WithAPIKey("test-key"), gateway.WithSharedCircuitBreaker(nil))
+	assert.Error(t, err)
+}
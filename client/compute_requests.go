@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+type ComputeRequest interface {
+	Create(computeRequestInput ComputeRequestCreateRequest) (ComputeRequestResponse, error)
+	CreateCtx(ctx context.Context, computeRequestInput ComputeRequestCreateRequest) (ComputeRequestResponse, error)
+	Get(id int64) (ComputeRequestResponse, error)
+	GetCtx(ctx context.Context, id int64) (ComputeRequestResponse, error)
+	GetMy(page int, page_size int) (HelperPaginatedResponse[[]ComputeRequestResponse], error)
+	GetMyCtx(ctx context.Context, page int, page_size int) (HelperPaginatedResponse[[]ComputeRequestResponse], error)
+	GetReceived(page int, page_size int) (HelperPaginatedResponse[[]ComputeRequestReceivedResponse], error)
+	GetReceivedCtx(ctx context.Context, page int, page_size int) (HelperPaginatedResponse[[]ComputeRequestReceivedResponse], error)
+	AllMy(pageSize int) *PageIterator[ComputeRequestResponse]
+	AllMyCtx(ctx context.Context, pageSize int) *PageIterator[ComputeRequestResponse]
+	AllReceived(pageSize int) *PageIterator[ComputeRequestReceivedResponse]
+	AllReceivedCtx(ctx context.Context, pageSize int) *PageIterator[ComputeRequestReceivedResponse]
+	Accept(id int64, dataAssetId int) (ComputeRequestResponse, error)
+	AcceptCtx(ctx context.Context, id int64, dataAssetId int) (ComputeRequestResponse, error)
+	StartComputingProcess(id int64) (ComputingProcessResponse, error)
+	StartComputingProcessCtx(ctx context.Context, id int64) (ComputingProcessResponse, error)
+}
+
+type ComputeRequestImpl struct {
+	Config Config
+}
+
+func NewComputeRequestImpl(config Config) *ComputeRequestImpl {
+	return &ComputeRequestImpl{
+		Config: config,
+	}
+}
+
+func (u *ComputeRequestImpl) Create(computeRequestInput ComputeRequestCreateRequest) (ComputeRequestResponse, error) {
+	return u.CreateCtx(context.Background(), computeRequestInput)
+}
+
+func (u *ComputeRequestImpl) CreateCtx(ctx context.Context, computeRequestInput ComputeRequestCreateRequest) (ComputeRequestResponse, error) {
+	var computeRequest ComputeRequestResponse
+	var error Error
+
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "ComputeRequest.Create")).SetBody(&computeRequestInput).SetResult(&computeRequest).SetError(&error).Post(CreateComputeRequest)
+
+	if err != nil {
+		return computeRequest, err
+	}
+
+	if res.IsError() {
+		return computeRequest, newAPIError(res, CreateComputeRequest, error)
+	}
+
+	return computeRequest, nil
+}
+
+func (u *ComputeRequestImpl) Get(id int64) (ComputeRequestResponse, error) {
+	return u.GetCtx(context.Background(), id)
+}
+
+func (u *ComputeRequestImpl) GetCtx(ctx context.Context, id int64) (ComputeRequestResponse, error) {
+	var computeRequest ComputeRequestResponse
+	var error Error
+
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "ComputeRequest.Get")).SetPathParam("id", fmt.Sprintf("%d", id)).SetResult(&computeRequest).SetError(&error).Get(GetComputeRequest)
+
+	if err != nil {
+		return computeRequest, err
+	}
+
+	if res.IsError() {
+		return computeRequest, newAPIError(res, GetComputeRequest, error)
+	}
+
+	return computeRequest, nil
+}
+
+func (u *ComputeRequestImpl) GetMy(page int, page_size int) (HelperPaginatedResponse[[]ComputeRequestResponse], error) {
+	return u.GetMyCtx(context.Background(), page, page_size)
+}
+
+func (u *ComputeRequestImpl) GetMyCtx(ctx context.Context, page int, page_size int) (HelperPaginatedResponse[[]ComputeRequestResponse], error) {
+	var computeRequests HelperPaginatedResponse[[]ComputeRequestResponse]
+	var error Error
+
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "ComputeRequest.GetMy")).SetQueryParams(map[string]string{
+		"page":      fmt.Sprintf("%d", page),
+		"page_size": fmt.Sprintf("%d", page_size),
+	}).SetResult(&computeRequests).SetError(&error).Get(GetComputeRequests)
+
+	if err != nil {
+		return computeRequests, err
+	}
+
+	if res.IsError() {
+		return computeRequests, newAPIError(res, GetComputeRequests, error)
+	}
+
+	return computeRequests, nil
+}
+
+func (u *ComputeRequestImpl) GetReceived(page int, page_size int) (HelperPaginatedResponse[[]ComputeRequestReceivedResponse], error) {
+	return u.GetReceivedCtx(context.Background(), page, page_size)
+}
+
+func (u *ComputeRequestImpl) GetReceivedCtx(ctx context.Context, page int, page_size int) (HelperPaginatedResponse[[]ComputeRequestReceivedResponse], error) {
+	var computeRequests HelperPaginatedResponse[[]ComputeRequestReceivedResponse]
+	var error Error
+
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "ComputeRequest.GetReceived")).SetQueryParams(map[string]string{
+		"page":      fmt.Sprintf("%d", page),
+		"page_size": fmt.Sprintf("%d", page_size),
+	}).SetResult(&computeRequests).SetError(&error).Get(GetComputeRequestsReceived)
+
+	if err != nil {
+		return computeRequests, err
+	}
+
+	if res.IsError() {
+		return computeRequests, newAPIError(res, GetComputeRequestsReceived, error)
+	}
+
+	return computeRequests, nil
+}
+
+func (u *ComputeRequestImpl) Accept(id int64, dataAssetId int) (ComputeRequestResponse, error) {
+	return u.AcceptCtx(context.Background(), id, dataAssetId)
+}
+
+func (u *ComputeRequestImpl) AcceptCtx(ctx context.Context, id int64, dataAssetId int) (ComputeRequestResponse, error) {
+	var computeRequest ComputeRequestResponse
+	var error Error
+
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "ComputeRequest.Accept")).SetPathParam("id", fmt.Sprintf("%d", id)).SetBody(&ComputeRequestAcceptRequest{DataAssetId: dataAssetId}).SetResult(&computeRequest).SetError(&error).Post(AcceptComputeRequest)
+
+	if err != nil {
+		return computeRequest, err
+	}
+
+	if res.IsError() {
+		return computeRequest, newAPIError(res, AcceptComputeRequest, error)
+	}
+
+	return computeRequest, nil
+}
+
+func (u *ComputeRequestImpl) StartComputingProcess(id int64) (ComputingProcessResponse, error) {
+	return u.StartComputingProcessCtx(context.Background(), id)
+}
+
+func (u *ComputeRequestImpl) StartComputingProcessCtx(ctx context.Context, id int64) (ComputingProcessResponse, error) {
+	var computingProcess ComputingProcessResponse
+	var error Error
+
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "ComputeRequest.StartComputingProcess")).SetPathParam("id", fmt.Sprintf("%d", id)).SetResult(&computingProcess).SetError(&error).Post(CreateComputingProcess)
+
+	if err != nil {
+		return computingProcess, err
+	}
+
+	if res.IsError() {
+		return computingProcess, newAPIError(res, CreateComputingProcess, error)
+	}
+
+	return computingProcess, nil
+}
+
+func (u *ComputeRequestImpl) AllMy(pageSize int) *PageIterator[ComputeRequestResponse] {
+	return u.AllMyCtx(context.Background(), pageSize)
+}
+
+// AllMyCtx iterates over every compute request created by the authenticated
+// user, pageSize requests per call.
+func (u *ComputeRequestImpl) AllMyCtx(ctx context.Context, pageSize int) *PageIterator[ComputeRequestResponse] {
+	return NewPageIterator(ctx, pageSize, u.GetMyCtx)
+}
+
+func (u *ComputeRequestImpl) AllReceived(pageSize int) *PageIterator[ComputeRequestReceivedResponse] {
+	return u.AllReceivedCtx(context.Background(), pageSize)
+}
+
+// AllReceivedCtx iterates over every compute request received by the
+// authenticated user, pageSize requests per call.
+func (u *ComputeRequestImpl) AllReceivedCtx(ctx context.Context, pageSize int) *PageIterator[ComputeRequestReceivedResponse] {
+	return NewPageIterator(ctx, pageSize, u.GetReceivedCtx)
+}
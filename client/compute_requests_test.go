@@ -0,0 +1,209 @@
+package client_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeRequestSuite(t *testing.T) {
+	client := resty.New()
+	httpmock.ActivateNonDefault(client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	config := gateway.Config{
+		Client: client,
+	}
+
+	computeRequestImpl := gateway.NewComputeRequestImpl(config)
+
+	jsonResponder := func(status int, fixture string) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(status, fixture)
+			resp.Header.Set("Content-Type", "application/json")
+			return resp, nil
+		}
+	}
+
+	t.Run("TestCreate", func(t *testing.T) {
+		httpmock.Reset()
+
+		httpmock.RegisterResponder("POST", gateway.CreateComputeRequest,
+			jsonResponder(200, `{"id": 1, "title": "Sum of ages", "compute_operation": "sum"}`))
+
+		result, err := computeRequestImpl.Create(gateway.ComputeRequestCreateRequest{
+			Title:            "Sum of ages",
+			ComputeFieldName: "age",
+			ComputeOperation: gateway.ComputeOperationSum,
+			DataModelId:      10,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, *result.Id)
+		assert.Equal(t, "sum", *result.ComputeOperation)
+	})
+
+	t.Run("TestCreateError", func(t *testing.T) {
+		httpmock.Reset()
+
+		httpmock.RegisterResponder("POST", gateway.CreateComputeRequest,
+			jsonResponder(400, `{"error": "invalid compute request"}`))
+
+		result, err := computeRequestImpl.Create(gateway.ComputeRequestCreateRequest{})
+
+		assert.EqualError(t, err, "invalid compute request")
+		assert.Empty(t, result)
+	})
+
+	t.Run("TestCreateHttpRequestError", func(t *testing.T) {
+		httpmock.Reset()
+
+		httpmock.RegisterResponder("POST", gateway.CreateComputeRequest,
+			httpmock.NewErrorResponder(errors.New("http request error")))
+
+		result, err := computeRequestImpl.Create(gateway.ComputeRequestCreateRequest{})
+
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("TestGet", func(t *testing.T) {
+		httpmock.Reset()
+
+		httpmock.RegisterResponder("GET", "/compute-requests/1",
+			jsonResponder(200, `{"id": 1, "accepted_data_assets": [{"data_asset_id": 7}]}`))
+
+		result, err := computeRequestImpl.Get(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, *result.Id)
+		assert.Len(t, *result.AcceptedDataAssets, 1)
+	})
+
+	t.Run("TestGetError", func(t *testing.T) {
+		httpmock.Reset()
+
+		httpmock.RegisterResponder("GET", "/compute-requests/1",
+			jsonResponder(404, `{"error": "compute request not found"}`))
+
+		result, err := computeRequestImpl.Get(1)
+
+		assert.EqualError(t, err, "compute request not found")
+		assert.Empty(t, result)
+	})
+
+	t.Run("TestGetMy", func(t *testing.T) {
+		httpmock.Reset()
+
+		httpmock.RegisterResponder("GET", gateway.GetComputeRequests, func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "2", req.URL.Query().Get("page"))
+			assert.Equal(t, "5", req.URL.Query().Get("page_size"))
+			return jsonResponder(200, `{"data": [{"id": 1}, {"id": 2}], "meta": {"total_items": 2}}`)(req)
+		})
+
+		result, err := computeRequestImpl.GetMy(2, 5)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Data, 2)
+		assert.Equal(t, 2, result.Meta.TotalItems)
+	})
+
+	t.Run("TestGetMyHttpRequestError", func(t *testing.T) {
+		httpmock.Reset()
+
+		httpmock.RegisterResponder("GET", gateway.GetComputeRequests,
+			httpmock.NewErrorResponder(errors.New("http request error")))
+
+		result, err := computeRequestImpl.GetMy(1, 10)
+
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("TestGetReceived", func(t *testing.T) {
+		httpmock.Reset()
+
+		httpmock.RegisterResponder("GET", gateway.GetComputeRequestsReceived,
+			jsonResponder(200, `{"data": [{"id": 3, "data_assets_ids": [4, 5]}]}`))
+
+		result, err := computeRequestImpl.GetReceived(1, 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Data, 1)
+		assert.Equal(t, []int{4, 5}, *result.Data[0].DataAssetsIds)
+	})
+
+	t.Run("TestGetReceivedError", func(t *testing.T) {
+		httpmock.Reset()
+
+		httpmock.RegisterResponder("GET", gateway.GetComputeRequestsReceived,
+			jsonResponder(500, `{"error": "internal server error"}`))
+
+		result, err := computeRequestImpl.GetReceived(1, 10)
+
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("TestAccept", func(t *testing.T) {
+		httpmock.Reset()
+
+		httpmock.RegisterResponder("POST", "/compute-requests/1/accept", func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			var accept gateway.ComputeRequestAcceptRequest
+			assert.NoError(t, json.Unmarshal(body, &accept))
+			assert.Equal(t, 7, accept.DataAssetId)
+			return jsonResponder(200, `{"id": 1, "accepted_data_assets": [{"data_asset_id": 7}]}`)(req)
+		})
+
+		result, err := computeRequestImpl.Accept(1, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 7, *(*result.AcceptedDataAssets)[0].DataAssetId)
+	})
+
+	t.Run("TestAcceptError", func(t *testing.T) {
+		httpmock.Reset()
+
+		httpmock.RegisterResponder("POST", "/compute-requests/1/accept",
+			jsonResponder(403, `{"error": "forbidden"}`))
+
+		result, err := computeRequestImpl.Accept(1, 7)
+
+		assert.EqualError(t, err, "forbidden")
+		assert.Empty(t, result)
+	})
+
+	t.Run("TestStartComputingProcess", func(t *testing.T) {
+		httpmock.Reset()
+
+		httpmock.RegisterResponder("POST", "/compute-requests/1/start",
+			jsonResponder(200, `{"id": 9, "compute_request": 1, "compute_status": "pending"}`))
+
+		result, err := computeRequestImpl.StartComputingProcess(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 9, *result.Id)
+		assert.Equal(t, "pending", *result.ComputeStatus)
+	})
+
+	t.Run("TestStartComputingProcessHttpRequestError", func(t *testing.T) {
+		httpmock.Reset()
+
+		httpmock.RegisterResponder("POST", "/compute-requests/1/start",
+			httpmock.NewErrorResponder(errors.New("http request error")))
+
+		result, err := computeRequestImpl.StartComputingProcess(1)
+
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
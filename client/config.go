@@ -0,0 +1,25 @@
+package client
+
+import (
+	"log/slog"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Config is shared by the services of an SDK. It is kept out of types.go,
+// which scripts/generate.go regenerates from the API schema.
+type Config struct {
+	// Client sends the requests. Uploads go through a copy of it with its
+	// own pre-request hook, so a hook set with SetPreRequestHook does not run
+	// for them; OnBeforeRequest middlewares do.
+	Client *resty.Client
+	// Metrics receives the measurements of the services; nil drops them.
+	Metrics Metrics
+	// Logger receives the auth lifecycle events; nil drops them.
+	Logger *slog.Logger
+	// Verifiers check login signatures; nil uses DefaultVerifierRegistry.
+	Verifiers *VerifierRegistry
+	// StrictVerification rejects logins from wallets no Verifier handles
+	// instead of leaving them to the API.
+	StrictVerification bool
+}
@@ -1,8 +1,10 @@
 package client_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -52,6 +54,24 @@ func TestDataAssetSuite(t *testing.T) {
 		assert.Empty(t, asset)
 	})
 
+	t.Run("TestGetDataAssetCtx", func(t *testing.T) {
+		httpmock.Reset()
+
+		type ctxKey struct{}
+		httpmock.RegisterResponder("GET", "/data-assets/1", func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "request-scoped", req.Context().Value(ctxKey{}))
+			resp := httpmock.NewStringResponse(200, `{"id": 1}`)
+			resp.Header.Set("Content-Type", "application/json")
+			return resp, nil
+		})
+
+		ctx := context.WithValue(context.Background(), ctxKey{}, "request-scoped")
+		result, err := dataAssetImpl.GetCtx(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Id)
+	})
+
 	t.Run("TestUploadDataAsset", func(t *testing.T) {
 		httpmock.Reset()
 
@@ -251,12 +271,13 @@ func TestDataAssetSuite(t *testing.T) {
 	t.Run("TestDownload", func(t *testing.T) {
 		httpmock.Reset()
 
-		httpmock.RegisterResponder("GET", gateway.DownloadDataAssetByID, httpmock.NewStringResponder(200, ""))
+		httpmock.RegisterResponder("GET", "/data-assets/1/download", httpmock.NewStringResponder(200, ""))
 
 		fixture := "File content goes here"
-		httpmock.RegisterResponder("GET", gateway.DownloadDataAssetByID, func(req *http.Request) (*http.Response, error) {
+		httpmock.RegisterResponder("GET", "/data-assets/1/download", func(req *http.Request) (*http.Response, error) {
 			resp := httpmock.NewStringResponse(200, fixture)
 			resp.Header.Set("Content-Disposition", `attachment; filename="testfile.txt"`)
+			resp.Header.Set("Content-Type", "text/plain; charset=utf-8")
 			return resp, nil
 		})
 
@@ -264,6 +285,8 @@ func TestDataAssetSuite(t *testing.T) {
 
 		assert.NoError(t, err)
 		assert.Equal(t, "testfile.txt", result.FileName)
+		assert.Equal(t, "text/plain", result.FileType)
+		assert.Equal(t, fixture, string(result.FileContent))
 	})
 
 	t.Run("TestShare", func(t *testing.T) {
@@ -420,3 +443,22 @@ func TestDataAssetSuite(t *testing.T) {
 		assert.Empty(t, result.Id)
 	})
 }
+
+func TestDataAssetCtxDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	dataAssetImpl := gateway.NewDataAssetImpl(gateway.Config{
+		Client: resty.New().SetBaseURL(server.URL),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	result, err := dataAssetImpl.DownloadCtx(ctx, 1)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, result)
+}
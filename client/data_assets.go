@@ -2,11 +2,9 @@ package client
 
 import (
 	"bytes"
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
-	"mime"
-	"os"
+	"io"
 	"path/filepath"
 	"time"
 )
@@ -19,15 +17,41 @@ type FileResponse struct {
 
 type DataAsset interface {
 	Upload(dataAssetInput CreateDataAssetRequest) (DataAssetIDRequestAndResponse, error)
+	UploadCtx(ctx context.Context, dataAssetInput CreateDataAssetRequest) (DataAssetIDRequestAndResponse, error)
 	UploadFile(fileName string, fileContent []byte, aclList *[]ACLRequest, expirationDate *time.Time) (DataAssetIDRequestAndResponse, error)
+	UploadFileCtx(ctx context.Context, fileName string, fileContent []byte, aclList *[]ACLRequest, expirationDate *time.Time) (DataAssetIDRequestAndResponse, error)
 	GetCreatedByMe(page int, page_size int) (HelperPaginatedResponse[[]PublicDataAsset], error)
+	GetCreatedByMeCtx(ctx context.Context, page int, page_size int) (HelperPaginatedResponse[[]PublicDataAsset], error)
 	GetReceivedByMe(page int, page_size int) (HelperPaginatedResponse[[]PublicDataAsset], error)
+	GetReceivedByMeCtx(ctx context.Context, page int, page_size int) (HelperPaginatedResponse[[]PublicDataAsset], error)
+	AllCreatedByMe(pageSize int) *PageIterator[PublicDataAsset]
+	AllCreatedByMeCtx(ctx context.Context, pageSize int) *PageIterator[PublicDataAsset]
+	AllReceivedByMe(pageSize int) *PageIterator[PublicDataAsset]
+	AllReceivedByMeCtx(ctx context.Context, pageSize int) *PageIterator[PublicDataAsset]
 	Get(id int64) (PublicDataAsset, error)
+	GetCtx(ctx context.Context, id int64) (PublicDataAsset, error)
 	UpdateAsset(id string, dataAssetInput UpdateDataAssetRequest) (PublicDataAsset, error)
+	UpdateAssetCtx(ctx context.Context, id string, dataAssetInput UpdateDataAssetRequest) (PublicDataAsset, error)
 	UpdateFile(id string, fileName string, fileContent []byte, aclList *[]ACLRequest, expirationDate *time.Time) (PublicDataAsset, error)
+	UpdateFileCtx(ctx context.Context, id string, fileName string, fileContent []byte, aclList *[]ACLRequest, expirationDate *time.Time) (PublicDataAsset, error)
+	UploadFileReader(fileName string, content io.Reader, options UploadOptions) (DataAssetIDRequestAndResponse, error)
+	UploadFileReaderCtx(ctx context.Context, fileName string, content io.Reader, options UploadOptions) (DataAssetIDRequestAndResponse, error)
+	UploadFilePath(path string, options UploadOptions) (DataAssetIDRequestAndResponse, error)
+	UploadFilePathCtx(ctx context.Context, path string, options UploadOptions) (DataAssetIDRequestAndResponse, error)
+	UpdateFileReader(id string, fileName string, content io.Reader, options UploadOptions) (PublicDataAsset, error)
+	UpdateFileReaderCtx(ctx context.Context, id string, fileName string, content io.Reader, options UploadOptions) (PublicDataAsset, error)
+	UpdateFilePath(id string, path string, options UploadOptions) (PublicDataAsset, error)
+	UpdateFilePathCtx(ctx context.Context, id string, path string, options UploadOptions) (PublicDataAsset, error)
 	DeleteAsset(id int64) (MessageResponse, error)
+	DeleteAssetCtx(ctx context.Context, id int64) (MessageResponse, error)
 	Download(id int64) (*FileResponse, error)
+	DownloadCtx(ctx context.Context, id int64) (*FileResponse, error)
+	DownloadStream(id int64) (io.ReadCloser, DownloadMetadata, error)
+	DownloadStreamCtx(ctx context.Context, id int64) (io.ReadCloser, DownloadMetadata, error)
+	DownloadTo(id int64, w io.Writer) (DownloadMetadata, error)
+	DownloadToCtx(ctx context.Context, id int64, w io.Writer) (DownloadMetadata, error)
 	Share(id int64, shareDetails []ShareDataAssetRequest) ([]PublicACL, error)
+	ShareCtx(ctx context.Context, id int64, shareDetails []ShareDataAssetRequest) ([]PublicACL, error)
 }
 
 type DataAssetImpl struct {
@@ -41,28 +65,36 @@ func NewDataAssetImpl(config Config) *DataAssetImpl {
 }
 
 func (u *DataAssetImpl) Get(id int64) (PublicDataAsset, error) {
+	return u.GetCtx(context.Background(), id)
+}
+
+func (u *DataAssetImpl) GetCtx(ctx context.Context, id int64) (PublicDataAsset, error) {
 	var asset PublicDataAsset
 	var error Error
 
-	res, err := u.Config.Client.R().SetPathParam("id", fmt.Sprintf("%d", id)).SetResult(&asset).SetError(&error).Get(GetDataAssetByID)
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "DataAsset.Get")).SetPathParam("id", fmt.Sprintf("%d", id)).SetResult(&asset).SetError(&error).Get(GetDataAssetByID)
 
 	if err != nil {
 		return asset, err
 	}
 
 	if res.IsError() {
-		return asset, errors.New(error.Error)
+		return asset, newAPIError(res, GetDataAssetByID, error)
 	}
 
 	return asset, nil
 }
 
 func (u *DataAssetImpl) GetCreatedByMe(page int, page_size int) (HelperPaginatedResponse[[]PublicDataAsset], error) {
+	return u.GetCreatedByMeCtx(context.Background(), page, page_size)
+}
+
+func (u *DataAssetImpl) GetCreatedByMeCtx(ctx context.Context, page int, page_size int) (HelperPaginatedResponse[[]PublicDataAsset], error) {
 
 	var assets HelperPaginatedResponse[[]PublicDataAsset]
 	var error Error
 
-	res, err := u.Config.Client.R().SetQueryParams(map[string]string{
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "DataAsset.GetCreatedByMe")).SetQueryParams(map[string]string{
 		"page":      fmt.Sprintf("%d", page),
 		"page_size": fmt.Sprintf("%d", page_size),
 	}).SetResult(&assets).SetError(&error).Get(GetCreatedDataAssets)
@@ -72,17 +104,21 @@ func (u *DataAssetImpl) GetCreatedByMe(page int, page_size int) (HelperPaginated
 	}
 
 	if res.IsError() {
-		return assets, errors.New(error.Error)
+		return assets, newAPIError(res, GetCreatedDataAssets, error)
 	}
 
 	return assets, nil
 }
 
 func (u *DataAssetImpl) GetReceivedByMe(page int, page_size int) (HelperPaginatedResponse[[]PublicDataAsset], error) {
+	return u.GetReceivedByMeCtx(context.Background(), page, page_size)
+}
+
+func (u *DataAssetImpl) GetReceivedByMeCtx(ctx context.Context, page int, page_size int) (HelperPaginatedResponse[[]PublicDataAsset], error) {
 	var assets HelperPaginatedResponse[[]PublicDataAsset]
 	var error Error
 
-	res, err := u.Config.Client.R().SetQueryParams(map[string]string{
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "DataAsset.GetReceivedByMe")).SetQueryParams(map[string]string{
 		"page":      fmt.Sprintf("%d", page),
 		"page_size": fmt.Sprintf("%d", page_size),
 	}).SetResult(&assets).SetError(&error).Get(GetReceivedDataAssets)
@@ -92,24 +128,28 @@ func (u *DataAssetImpl) GetReceivedByMe(page int, page_size int) (HelperPaginate
 	}
 
 	if res.IsError() {
-		return assets, errors.New(error.Error)
+		return assets, newAPIError(res, GetReceivedDataAssets, error)
 	}
 
 	return assets, nil
 }
 
 func (u *DataAssetImpl) Upload(dataAssetInput CreateDataAssetRequest) (DataAssetIDRequestAndResponse, error) {
+	return u.UploadCtx(context.Background(), dataAssetInput)
+}
+
+func (u *DataAssetImpl) UploadCtx(ctx context.Context, dataAssetInput CreateDataAssetRequest) (DataAssetIDRequestAndResponse, error) {
 	var id DataAssetIDRequestAndResponse
 	var error Error
 
-	res, err := u.Config.Client.R().SetBody(&dataAssetInput).SetResult(&id).SetError(&error).Post(CreateANewDataAsset)
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "DataAsset.Upload")).SetBody(&dataAssetInput).SetResult(&id).SetError(&error).Post(CreateANewDataAsset)
 
 	if err != nil {
 		return id, err
 	}
 
 	if res.IsError() {
-		return id, errors.New(error.Error)
+		return id, newAPIError(res, CreateANewDataAsset, error)
 	}
 
 	return id, nil
@@ -120,24 +160,19 @@ func toRFC3339(t time.Time) string {
 }
 
 func (u *DataAssetImpl) UploadFile(fileName string, fileContent []byte, aclList *[]ACLRequest, expirationDate *time.Time) (DataAssetIDRequestAndResponse, error) {
+	return u.UploadFileCtx(context.Background(), fileName, fileContent, aclList, expirationDate)
+}
+
+func (u *DataAssetImpl) UploadFileCtx(ctx context.Context, fileName string, fileContent []byte, aclList *[]ACLRequest, expirationDate *time.Time) (DataAssetIDRequestAndResponse, error) {
 	var id DataAssetIDRequestAndResponse
 	var error Error
 
-	formData := make(map[string]string)
-
-	if aclList != nil {
-		aclJSON, err := json.Marshal(aclList)
-		if err != nil {
-			return id, err
-		}
-		formData["acl"] = string(aclJSON)
-	}
-
-	if expirationDate != nil {
-		formData["expiration_date"] = toRFC3339(*expirationDate)
+	formData, err := uploadFormData(aclList, expirationDate)
+	if err != nil {
+		return id, err
 	}
 
-	req := u.Config.Client.R().SetFileReader("data", fileName, bytes.NewReader(fileContent))
+	req := u.Config.Client.R().SetContext(withOperation(ctx, "DataAsset.UploadFile")).SetFileReader("data", fileName, bytes.NewReader(fileContent))
 
 	if len(formData) > 0 {
 		req = req.SetFormData(formData)
@@ -150,53 +185,145 @@ func (u *DataAssetImpl) UploadFile(fileName string, fileContent []byte, aclList
 	}
 
 	if res.IsError() {
-		return id, errors.New(error.Error)
+		return id, newAPIError(res, CreateANewDataAsset, error)
 	}
 
+	u.Config.metrics().BytesUploaded("DataAsset.UploadFile", int64(len(fileContent)))
+
 	return id, nil
 }
 
 func (u *DataAssetImpl) UpdateAsset(id string, dataAssetInput UpdateDataAssetRequest) (PublicDataAsset, error) {
+	return u.UpdateAssetCtx(context.Background(), id, dataAssetInput)
+}
+
+func (u *DataAssetImpl) UpdateAssetCtx(ctx context.Context, id string, dataAssetInput UpdateDataAssetRequest) (PublicDataAsset, error) {
 	var asset PublicDataAsset
 	var error Error
 
-	res, err := u.Config.Client.R().SetPathParam("id", id).SetBody(&dataAssetInput).SetResult(&asset).SetError(&error).Put(UpdateDataAssetByID)
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "DataAsset.UpdateAsset")).SetPathParam("id", id).SetBody(&dataAssetInput).SetResult(&asset).SetError(&error).Put(UpdateDataAssetByID)
 
 	if err != nil {
 		return asset, err
 	}
 
 	if res.IsError() {
-		return asset, errors.New(error.Error)
+		return asset, newAPIError(res, UpdateDataAssetByID, error)
 	}
 
 	return asset, nil
 }
 
 func (u *DataAssetImpl) UpdateFile(id string, fileName string, fileContent []byte, aclList *[]ACLRequest, expirationDate *time.Time) (PublicDataAsset, error) {
+	return u.UpdateFileCtx(context.Background(), id, fileName, fileContent, aclList, expirationDate)
+}
+
+func (u *DataAssetImpl) UpdateFileCtx(ctx context.Context, id string, fileName string, fileContent []byte, aclList *[]ACLRequest, expirationDate *time.Time) (PublicDataAsset, error) {
 	var asset PublicDataAsset
 	var error Error
 
-	formData := make(map[string]string)
+	formData, err := uploadFormData(aclList, expirationDate)
+	if err != nil {
+		return asset, err
+	}
+
+	req := u.Config.Client.R().SetContext(withOperation(ctx, "DataAsset.UpdateFile")).SetFileReader("data", fileName, bytes.NewReader(fileContent))
+
+	if len(formData) > 0 {
+		req = req.SetFormData(formData)
+	}
+
+	res, err := req.SetPathParam("id", id).SetResult(&asset).SetError(&error).Put(UpdateDataAssetByID)
+
+	if err != nil {
+		return asset, err
+	}
+
+	if res.IsError() {
+		return asset, newAPIError(res, UpdateDataAssetByID, error)
+	}
+
+	u.Config.metrics().BytesUploaded("DataAsset.UpdateFile", int64(len(fileContent)))
+
+	return asset, nil
+
+}
+
+func (u *DataAssetImpl) UploadFileReader(fileName string, content io.Reader, options UploadOptions) (DataAssetIDRequestAndResponse, error) {
+	return u.UploadFileReaderCtx(context.Background(), fileName, content, options)
+}
+
+// UploadFileReaderCtx streams content as the file of a new data asset
+// without loading it into memory.
+func (u *DataAssetImpl) UploadFileReaderCtx(ctx context.Context, fileName string, content io.Reader, options UploadOptions) (DataAssetIDRequestAndResponse, error) {
+	return u.uploadFileReader(withOperation(ctx, "DataAsset.UploadFileReader"), fileName, content, options)
+}
+
+// uploadFileReader streams content as the file of a new data asset on
+// behalf of the operation ctx is tagged with.
+func (u *DataAssetImpl) uploadFileReader(ctx context.Context, fileName string, content io.Reader, options UploadOptions) (DataAssetIDRequestAndResponse, error) {
+	var id DataAssetIDRequestAndResponse
+	var error Error
+
+	stream, err := newUploadStream(fileName, content, options)
+	if err != nil {
+		return id, err
+	}
+
+	req := uploadRequest(u.Config.Client, ctx, stream)
+
+	res, err := req.SetResult(&id).SetError(&error).Post(CreateANewDataAsset)
 
-	if aclList != nil {
-		aclJSON, err := json.Marshal(aclList)
-		if err != nil {
-			return asset, err
-		}
-		formData["acl"] = string(aclJSON)
+	if err != nil {
+		return id, err
 	}
 
-	if expirationDate != nil {
-		formData["expiration_date"] = toRFC3339(*expirationDate)
+	if res.IsError() {
+		return id, newAPIError(res, CreateANewDataAsset, error)
 	}
 
-	req := u.Config.Client.R().SetFileReader("data", fileName, bytes.NewReader(fileContent))
+	u.Config.metrics().BytesUploaded(operationFrom(ctx), stream.sent.Load())
 
-	if len(formData) > 0 {
-		req = req.SetFormData(formData)
+	return id, nil
+}
+
+func (u *DataAssetImpl) UploadFilePath(path string, options UploadOptions) (DataAssetIDRequestAndResponse, error) {
+	return u.UploadFilePathCtx(context.Background(), path, options)
+}
+
+func (u *DataAssetImpl) UploadFilePathCtx(ctx context.Context, path string, options UploadOptions) (DataAssetIDRequestAndResponse, error) {
+	file, options, err := openUploadFile(path, options)
+	if err != nil {
+		return DataAssetIDRequestAndResponse{}, err
+	}
+	defer file.Close()
+
+	return u.uploadFileReader(withOperation(ctx, "DataAsset.UploadFilePath"), filepath.Base(path), file, options)
+}
+
+func (u *DataAssetImpl) UpdateFileReader(id string, fileName string, content io.Reader, options UploadOptions) (PublicDataAsset, error) {
+	return u.UpdateFileReaderCtx(context.Background(), id, fileName, content, options)
+}
+
+// UpdateFileReaderCtx streams content as the new file of a data asset
+// without loading it into memory.
+func (u *DataAssetImpl) UpdateFileReaderCtx(ctx context.Context, id string, fileName string, content io.Reader, options UploadOptions) (PublicDataAsset, error) {
+	return u.updateFileReader(withOperation(ctx, "DataAsset.UpdateFileReader"), id, fileName, content, options)
+}
+
+// updateFileReader streams content as the new file of a data asset on
+// behalf of the operation ctx is tagged with.
+func (u *DataAssetImpl) updateFileReader(ctx context.Context, id string, fileName string, content io.Reader, options UploadOptions) (PublicDataAsset, error) {
+	var asset PublicDataAsset
+	var error Error
+
+	stream, err := newUploadStream(fileName, content, options)
+	if err != nil {
+		return asset, err
 	}
 
+	req := uploadRequest(u.Config.Client, ctx, stream)
+
 	res, err := req.SetPathParam("id", id).SetResult(&asset).SetError(&error).Put(UpdateDataAssetByID)
 
 	if err != nil {
@@ -204,79 +331,172 @@ func (u *DataAssetImpl) UpdateFile(id string, fileName string, fileContent []byt
 	}
 
 	if res.IsError() {
-		return asset, errors.New(error.Error)
+		return asset, newAPIError(res, UpdateDataAssetByID, error)
 	}
 
+	u.Config.metrics().BytesUploaded(operationFrom(ctx), stream.sent.Load())
+
 	return asset, nil
+}
+
+func (u *DataAssetImpl) UpdateFilePath(id string, path string, options UploadOptions) (PublicDataAsset, error) {
+	return u.UpdateFilePathCtx(context.Background(), id, path, options)
+}
+
+func (u *DataAssetImpl) UpdateFilePathCtx(ctx context.Context, id string, path string, options UploadOptions) (PublicDataAsset, error) {
+	file, options, err := openUploadFile(path, options)
+	if err != nil {
+		return PublicDataAsset{}, err
+	}
+	defer file.Close()
 
+	return u.updateFileReader(withOperation(ctx, "DataAsset.UpdateFilePath"), id, filepath.Base(path), file, options)
 }
 
 func (u *DataAssetImpl) DeleteAsset(id int64) (MessageResponse, error) {
+	return u.DeleteAssetCtx(context.Background(), id)
+}
+
+func (u *DataAssetImpl) DeleteAssetCtx(ctx context.Context, id int64) (MessageResponse, error) {
 	var message MessageResponse
 	var error Error
 
-	res, err := u.Config.Client.R().SetPathParam("id", fmt.Sprintf("%v", id)).SetResult(&message).SetError(&error).Delete(DeleteDataAssetByID)
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "DataAsset.DeleteAsset")).SetPathParam("id", fmt.Sprintf("%v", id)).SetResult(&message).SetError(&error).Delete(DeleteDataAssetByID)
 
 	if err != nil {
 		return message, err
 	}
 
 	if res.IsError() {
-		return message, errors.New(error.Error)
+		return message, newAPIError(res, DeleteDataAssetByID, error)
 	}
 
 	return message, nil
 }
 
 func (u *DataAssetImpl) Share(id int64, shareDetails []ShareDataAssetRequest) ([]PublicACL, error) {
+	return u.ShareCtx(context.Background(), id, shareDetails)
+}
+
+func (u *DataAssetImpl) ShareCtx(ctx context.Context, id int64, shareDetails []ShareDataAssetRequest) ([]PublicACL, error) {
 
 	var acl []PublicACL
 	var error Error
 
-	res, err := u.Config.Client.R().SetPathParam("id", fmt.Sprintf("%v", id)).SetBody(&shareDetails).SetResult(&acl).SetError(&error).Post(ShareDataAssetByID)
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "DataAsset.Share")).SetPathParam("id", fmt.Sprintf("%v", id)).SetBody(&shareDetails).SetResult(&acl).SetError(&error).Post(ShareDataAssetByID)
 
 	if err != nil {
 		return acl, err
 	}
 
 	if res.IsError() {
-		return acl, errors.New(error.Error)
+		return acl, newAPIError(res, ShareDataAssetByID, error)
 	}
 
 	return acl, nil
 }
 
 func (u *DataAssetImpl) Download(id int64) (*FileResponse, error) {
+	return u.DownloadCtx(context.Background(), id)
+}
+
+func (u *DataAssetImpl) DownloadCtx(ctx context.Context, id int64) (*FileResponse, error) {
+	body, metadata, err := u.downloadStream(withOperation(ctx, "DataAsset.Download"), id)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
 
-	dataAsset, _ := u.Get(id)
+	fileContent, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file content: %w", err)
+	}
+
+	return &FileResponse{
+		FileName:    metadata.FileName,
+		FileContent: fileContent,
+		FileType:    metadata.FileType,
+	}, nil
+}
 
-	resp, err := u.Config.Client.R().SetPathParam("id", fmt.Sprintf("%v", id)).
-		SetOutput("temporary-file").
+func (u *DataAssetImpl) DownloadStream(id int64) (io.ReadCloser, DownloadMetadata, error) {
+	return u.DownloadStreamCtx(context.Background(), id)
+}
+
+// DownloadStreamCtx starts the download of a data asset and returns its
+// content as it arrives. The caller must close the returned body.
+func (u *DataAssetImpl) DownloadStreamCtx(ctx context.Context, id int64) (io.ReadCloser, DownloadMetadata, error) {
+	return u.downloadStream(withOperation(ctx, "DataAsset.DownloadStream"), id)
+}
+
+// downloadStream starts the download of a data asset on behalf of the
+// operation ctx is tagged with.
+func (u *DataAssetImpl) downloadStream(ctx context.Context, id int64) (io.ReadCloser, DownloadMetadata, error) {
+	resp, err := u.Config.Client.R().SetContext(ctx).SetPathParam("id", fmt.Sprintf("%v", id)).
+		SetDoNotParseResponse(true).
 		Get(DownloadDataAssetByID)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to download file: %w", err)
+		return nil, DownloadMetadata{}, fmt.Errorf("failed to download file: %w", err)
 	}
 
-	var fileName = dataAsset.Name
+	if resp.IsError() {
+		return nil, DownloadMetadata{}, downloadError(resp, DownloadDataAssetByID)
+	}
+
+	metadata, incomplete := downloadMetadata(resp)
+	if incomplete {
+		dataAsset, _ := u.GetCtx(ctx, id)
+		metadata = fillDownloadMetadata(metadata, dataAsset)
+	}
 
-	mediaType, _, err := mime.ParseMediaType(dataAsset.Type)
+	if metadata.FileName == "" {
+		metadata.FileName = filepath.Base(resp.Request.URL)
+	}
 
-	if fileName == "" {
-		fileName = filepath.Base(resp.Request.URL)
+	body := resp.RawBody()
+	if u.Config.Metrics != nil {
+		body = &countingReader{ReadCloser: body, operation: operationFrom(ctx), metrics: u.Config.Metrics}
 	}
 
-	fileContent, err := os.ReadFile("temporary-file")
+	return body, metadata, nil
+}
+
+func (u *DataAssetImpl) DownloadTo(id int64, w io.Writer) (DownloadMetadata, error) {
+	return u.DownloadToCtx(context.Background(), id, w)
+}
+
+// DownloadToCtx copies the content of a data asset into w as it arrives.
+func (u *DataAssetImpl) DownloadToCtx(ctx context.Context, id int64, w io.Writer) (DownloadMetadata, error) {
+	body, metadata, err := u.downloadStream(withOperation(ctx, "DataAsset.DownloadTo"), id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file content: %w", err)
+		return metadata, err
 	}
+	defer body.Close()
 
-	defer os.Remove("temporary-file")
+	if _, err := io.Copy(w, body); err != nil {
+		return metadata, fmt.Errorf("failed to download file: %w", err)
+	}
 
-	return &FileResponse{
-		FileName:    fileName,
-		FileContent: fileContent,
-		FileType:    mediaType,
-	}, nil
+	return metadata, nil
+}
+
+func (u *DataAssetImpl) AllCreatedByMe(pageSize int) *PageIterator[PublicDataAsset] {
+	return u.AllCreatedByMeCtx(context.Background(), pageSize)
+}
+
+// AllCreatedByMeCtx iterates over every data asset created by the
+// authenticated user, pageSize assets per request.
+func (u *DataAssetImpl) AllCreatedByMeCtx(ctx context.Context, pageSize int) *PageIterator[PublicDataAsset] {
+	return NewPageIterator(ctx, pageSize, u.GetCreatedByMeCtx)
+}
+
+func (u *DataAssetImpl) AllReceivedByMe(pageSize int) *PageIterator[PublicDataAsset] {
+	return u.AllReceivedByMeCtx(context.Background(), pageSize)
+}
 
+// AllReceivedByMeCtx iterates over every data asset shared with the
+// authenticated user, pageSize assets per request.
+func (u *DataAssetImpl) AllReceivedByMeCtx(ctx context.Context, pageSize int) *PageIterator[PublicDataAsset] {
+	return NewPageIterator(ctx, pageSize, u.GetReceivedByMeCtx)
 }
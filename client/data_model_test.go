@@ -309,7 +309,7 @@ func TestDataModelSuite(t *testing.T) {
 			resp.Header.Set("Content-Type", "application/json")
 			return resp, nil
 		}
-		httpmock.RegisterResponder("PUT", gateway.UpdateDataModel, responder)
+		httpmock.RegisterResponder("PUT", "/data-models/2", responder)
 
 		id := int64(2)
 		var title = "UpdatedModel"
@@ -326,7 +326,7 @@ func TestDataModelSuite(t *testing.T) {
 		httpmock.Reset()
 
 		responder := httpmock.NewStringResponder(400, `{"error": "Failed to delete ACL"}`)
-		httpmock.RegisterResponder("PUT", gateway.UpdateDataModel, responder)
+		httpmock.RegisterResponder("PUT", "/data-models/2", responder)
 
 		id := int64(2)
 		var title = "UpdatedModel"
@@ -343,7 +343,7 @@ func TestDataModelSuite(t *testing.T) {
 	t.Run("TestUpdateHttpRequestError", func(t *testing.T) {
 		httpmock.Reset()
 
-		httpmock.RegisterResponder("PUT", gateway.UpdateDataModel, func(req *http.Request) (*http.Response, error) {
+		httpmock.RegisterResponder("PUT", "/data-models/2", func(req *http.Request) (*http.Response, error) {
 			return nil, errors.New("client-side error")
 		})
 
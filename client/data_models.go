@@ -1,16 +1,25 @@
 package client
 
 import (
-	"errors"
+	"context"
 	"fmt"
 )
 
 type DataModel interface {
 	GetAll(page int, page_size int) (HelperPaginatedResponse[[]DataModelResponse], error)
+	GetAllCtx(ctx context.Context, page int, page_size int) (HelperPaginatedResponse[[]DataModelResponse], error)
 	Create(dataModelInput DataModelCreateRequest) (DataModelResponse, error)
+	CreateCtx(ctx context.Context, dataModelInput DataModelCreateRequest) (DataModelResponse, error)
 	GetMy(page int, page_size int) (HelperPaginatedResponse[[]DataModelResponse], error)
+	GetMyCtx(ctx context.Context, page int, page_size int) (HelperPaginatedResponse[[]DataModelResponse], error)
+	All(pageSize int) *PageIterator[DataModelResponse]
+	AllCtx(ctx context.Context, pageSize int) *PageIterator[DataModelResponse]
+	AllMy(pageSize int) *PageIterator[DataModelResponse]
+	AllMyCtx(ctx context.Context, pageSize int) *PageIterator[DataModelResponse]
 	GetById(id int64) (DataModelResponse, error)
+	GetByIdCtx(ctx context.Context, id int64) (DataModelResponse, error)
 	Update(id int64, dataModelInput DataModelUpdateRequest) (DataModelResponse, error)
+	UpdateCtx(ctx context.Context, id int64, dataModelInput DataModelUpdateRequest) (DataModelResponse, error)
 }
 
 type DataModelImpl struct {
@@ -24,11 +33,15 @@ func NewDataModelImpl(config Config) *DataModelImpl {
 }
 
 func (u *DataModelImpl) GetAll(page int, page_size int) (HelperPaginatedResponse[[]DataModelResponse], error) {
+	return u.GetAllCtx(context.Background(), page, page_size)
+}
+
+func (u *DataModelImpl) GetAllCtx(ctx context.Context, page int, page_size int) (HelperPaginatedResponse[[]DataModelResponse], error) {
 
 	var dataModels HelperPaginatedResponse[[]DataModelResponse]
 	var error Error
 
-	res, err := u.Config.Client.R().SetQueryParams(map[string]string{
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "DataModel.GetAll")).SetQueryParams(map[string]string{
 		"page":      fmt.Sprintf("%d", page),
 		"page_size": fmt.Sprintf("%d", page_size),
 	}).SetResult(&dataModels).SetError(&error).Get(GetDataModels)
@@ -38,17 +51,21 @@ func (u *DataModelImpl) GetAll(page int, page_size int) (HelperPaginatedResponse
 	}
 
 	if res.IsError() {
-		return dataModels, errors.New(error.Error)
+		return dataModels, newAPIError(res, GetDataModels, error)
 	}
 
 	return dataModels, nil
 }
 
 func (u *DataModelImpl) GetMy(page int, page_size int) (HelperPaginatedResponse[[]DataModelResponse], error) {
+	return u.GetMyCtx(context.Background(), page, page_size)
+}
+
+func (u *DataModelImpl) GetMyCtx(ctx context.Context, page int, page_size int) (HelperPaginatedResponse[[]DataModelResponse], error) {
 	var dataModels HelperPaginatedResponse[[]DataModelResponse]
 	var error Error
 
-	res, err := u.Config.Client.R().SetQueryParams(map[string]string{
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "DataModel.GetMy")).SetQueryParams(map[string]string{
 		"page":      fmt.Sprintf("%d", page),
 		"page_size": fmt.Sprintf("%d", page_size),
 	}).SetResult(&dataModels).SetError(&error).Get(GetDataModelsByUser)
@@ -58,25 +75,29 @@ func (u *DataModelImpl) GetMy(page int, page_size int) (HelperPaginatedResponse[
 	}
 
 	if res.IsError() {
-		return dataModels, errors.New(error.Error)
+		return dataModels, newAPIError(res, GetDataModelsByUser, error)
 	}
 
 	return dataModels, nil
 }
 
 func (u *DataModelImpl) GetById(id int64) (DataModelResponse, error) {
+	return u.GetByIdCtx(context.Background(), id)
+}
+
+func (u *DataModelImpl) GetByIdCtx(ctx context.Context, id int64) (DataModelResponse, error) {
 
 	var dataModel DataModelResponse
 	var error Error
 
-	res, err := u.Config.Client.R().SetPathParam("id", fmt.Sprintf("%d", id)).SetResult(&dataModel).SetError(&error).Get(GetDataModelByID)
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "DataModel.GetById")).SetPathParam("id", fmt.Sprintf("%d", id)).SetResult(&dataModel).SetError(&error).Get(GetDataModelByID)
 
 	if err != nil {
 		return dataModel, err
 	}
 
 	if res.IsError() {
-		return dataModel, errors.New(error.Error)
+		return dataModel, newAPIError(res, GetDataModelByID, error)
 	}
 
 	return dataModel, nil
@@ -84,36 +105,63 @@ func (u *DataModelImpl) GetById(id int64) (DataModelResponse, error) {
 }
 
 func (u *DataModelImpl) Create(dataModelInput DataModelCreateRequest) (DataModelResponse, error) {
+	return u.CreateCtx(context.Background(), dataModelInput)
+}
+
+func (u *DataModelImpl) CreateCtx(ctx context.Context, dataModelInput DataModelCreateRequest) (DataModelResponse, error) {
 	var dataModelCreated DataModelResponse
 	var error Error
 
-	res, err := u.Config.Client.R().SetBody(&dataModelInput).SetResult(&dataModelCreated).SetError(&error).Post(CreateDataModel)
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "DataModel.Create")).SetBody(&dataModelInput).SetResult(&dataModelCreated).SetError(&error).Post(CreateDataModel)
 
 	if err != nil {
 		return dataModelCreated, err
 	}
 
 	if res.IsError() {
-		return dataModelCreated, errors.New(error.Error)
+		return dataModelCreated, newAPIError(res, CreateDataModel, error)
 	}
 
 	return dataModelCreated, nil
 }
 
 func (u *DataModelImpl) Update(id int64, dataModelInput DataModelUpdateRequest) (DataModelResponse, error) {
+	return u.UpdateCtx(context.Background(), id, dataModelInput)
+}
+
+func (u *DataModelImpl) UpdateCtx(ctx context.Context, id int64, dataModelInput DataModelUpdateRequest) (DataModelResponse, error) {
 	var dataModelUpdated DataModelResponse
 	var error Error
 
-	res, err := u.Config.Client.R().SetBody(&dataModelInput).SetResult(&dataModelUpdated).SetError(&error).Put(UpdateDataModel)
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "DataModel.Update")).SetPathParam("id", fmt.Sprintf("%d", id)).SetBody(&dataModelInput).SetResult(&dataModelUpdated).SetError(&error).Put(UpdateDataModel)
 
 	if err != nil {
 		return dataModelUpdated, err
 	}
 
 	if res.IsError() {
-		return dataModelUpdated, errors.New(error.Error)
+		return dataModelUpdated, newAPIError(res, UpdateDataModel, error)
 	}
 
 	return dataModelUpdated, nil
 
 }
+
+func (u *DataModelImpl) All(pageSize int) *PageIterator[DataModelResponse] {
+	return u.AllCtx(context.Background(), pageSize)
+}
+
+// AllCtx iterates over every data model, pageSize models per request.
+func (u *DataModelImpl) AllCtx(ctx context.Context, pageSize int) *PageIterator[DataModelResponse] {
+	return NewPageIterator(ctx, pageSize, u.GetAllCtx)
+}
+
+func (u *DataModelImpl) AllMy(pageSize int) *PageIterator[DataModelResponse] {
+	return u.AllMyCtx(context.Background(), pageSize)
+}
+
+// AllMyCtx iterates over every data model created by the authenticated
+// user, pageSize models per request.
+func (u *DataModelImpl) AllMyCtx(ctx context.Context, pageSize int) *PageIterator[DataModelResponse] {
+	return NewPageIterator(ctx, pageSize, u.GetMyCtx)
+}
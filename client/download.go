@@ -0,0 +1,71 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"path/filepath"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// DownloadMetadata describes a file returned by DownloadStream and DownloadTo.
+type DownloadMetadata struct {
+	FileName string
+	FileType string
+	// Size is the length of the file content in bytes, or -1 when unknown.
+	Size int64
+}
+
+// downloadMetadata reads the file name and media type from the download
+// response headers. It reports whether either of them is missing.
+func downloadMetadata(res *resty.Response) (DownloadMetadata, bool) {
+	metadata := DownloadMetadata{Size: res.RawResponse.ContentLength}
+
+	if _, params, err := mime.ParseMediaType(res.Header().Get("Content-Disposition")); err == nil {
+		metadata.FileName = filepath.Base(params["filename"])
+		if metadata.FileName == "." || metadata.FileName == string(filepath.Separator) {
+			metadata.FileName = ""
+		}
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(res.Header().Get("Content-Type")); err == nil {
+		metadata.FileType = mediaType
+	}
+
+	return metadata, metadata.FileName == "" || metadata.FileType == ""
+}
+
+// fillDownloadMetadata completes metadata with the asset details for
+// whatever the download response headers left out.
+func fillDownloadMetadata(metadata DownloadMetadata, asset PublicDataAsset) DownloadMetadata {
+	if metadata.FileName == "" {
+		metadata.FileName = asset.Name
+	}
+
+	if metadata.FileType == "" {
+		if mediaType, _, err := mime.ParseMediaType(asset.Type); err == nil {
+			metadata.FileType = mediaType
+		}
+	}
+
+	return metadata
+}
+
+// downloadError turns an unparsed error response into an APIError, reading
+// the body that resty left untouched.
+func downloadError(res *resty.Response, route string) error {
+	body := res.RawBody()
+	defer body.Close()
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	res.SetBody(content)
+
+	var error Error
+	json.Unmarshal(content, &error)
+
+	return newAPIError(res, route, error)
+}
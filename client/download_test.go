@@ -0,0 +1,78 @@
+package client_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDownloadImpl(t *testing.T) *gateway.DataAssetImpl {
+	client := resty.New()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+
+	return gateway.NewDataAssetImpl(gateway.Config{Client: client})
+}
+
+func TestDownloadTo(t *testing.T) {
+	dataAssetImpl := newTestDownloadImpl(t)
+
+	httpmock.RegisterResponder("GET", "/data-assets/1/download", func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, "name,age\nalice,30\n")
+		resp.Header.Set("Content-Disposition", `attachment; filename="report.csv"`)
+		resp.Header.Set("Content-Type", "text/csv")
+		resp.ContentLength = 18
+		return resp, nil
+	})
+
+	var buf bytes.Buffer
+	metadata, err := dataAssetImpl.DownloadTo(1, &buf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, gateway.DownloadMetadata{FileName: "report.csv", FileType: "text/csv", Size: 18}, metadata)
+	assert.Equal(t, "name,age\nalice,30\n", buf.String())
+	assert.Equal(t, 0, httpmock.GetCallCountInfo()["GET /data-assets/1"])
+}
+
+func TestDownloadStreamFallsBackToAssetMetadata(t *testing.T) {
+	dataAssetImpl := newTestDownloadImpl(t)
+
+	httpmock.RegisterResponder("GET", "/data-assets/1/download", httpmock.NewStringResponder(200, "content"))
+	httpmock.RegisterResponder("GET", "/data-assets/1",
+		httpmock.NewJsonResponderOrPanic(200, gateway.PublicDataAsset{Id: 1, Name: "notes.txt", Type: "text/plain; charset=utf-8"}))
+
+	body, metadata, err := dataAssetImpl.DownloadStream(1)
+	assert.NoError(t, err)
+	defer body.Close()
+
+	content, err := io.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+	assert.Equal(t, "notes.txt", metadata.FileName)
+	assert.Equal(t, "text/plain", metadata.FileType)
+}
+
+func TestDownloadStreamError(t *testing.T) {
+	dataAssetImpl := newTestDownloadImpl(t)
+
+	httpmock.RegisterResponder("GET", "/data-assets/1/download",
+		httpmock.NewJsonResponderOrPanic(404, gateway.Error{Error: "data asset not found"}))
+
+	body, _, err := dataAssetImpl.DownloadStream(1)
+
+	assert.Nil(t, body)
+	assert.ErrorIs(t, err, gateway.ErrNotFound)
+
+	var apiError *gateway.APIError
+	assert.True(t, errors.As(err, &apiError))
+	assert.Equal(t, "data asset not found", apiError.Message)
+	assert.Equal(t, gateway.DownloadDataAssetByID, apiError.Route)
+}
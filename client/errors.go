@@ -0,0 +1,76 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrServerError  = errors.New("server error")
+)
+
+// APIError is returned whenever the Gateway API answers with a non-2xx status.
+// It matches the sentinel errors above through errors.Is, so callers can
+// branch on the status class without inspecting StatusCode directly.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Body       []byte
+	Header     http.Header
+	RequestID  string
+	Route      string
+	Method     string
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s %s: %d %s", e.Method, e.Route, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+func newAPIError(res *resty.Response, route string, body Error) *APIError {
+	apiError := &APIError{
+		StatusCode: res.StatusCode(),
+		Message:    body.Error,
+		Body:       res.Body(),
+		Header:     res.Header(),
+		RequestID:  res.Header().Get("X-Request-Id"),
+		Route:      route,
+	}
+
+	if res.Request != nil {
+		apiError.Method = res.Request.Method
+	}
+
+	return apiError
+}
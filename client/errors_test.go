@@ -0,0 +1,76 @@
+package client_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		sentinel   error
+	}{
+		{http.StatusBadRequest, gateway.ErrBadRequest},
+		{http.StatusUnauthorized, gateway.ErrUnauthorized},
+		{http.StatusForbidden, gateway.ErrForbidden},
+		{http.StatusNotFound, gateway.ErrNotFound},
+		{http.StatusConflict, gateway.ErrConflict},
+		{http.StatusTooManyRequests, gateway.ErrRateLimited},
+		{http.StatusInternalServerError, gateway.ErrServerError},
+		{http.StatusBadGateway, gateway.ErrServerError},
+	}
+
+	for _, tt := range tests {
+		err := error(&gateway.APIError{StatusCode: tt.statusCode})
+
+		assert.ErrorIs(t, err, tt.sentinel, "status %d", tt.statusCode)
+	}
+
+	err := error(&gateway.APIError{StatusCode: http.StatusNotFound})
+	assert.NotErrorIs(t, err, gateway.ErrForbidden)
+	assert.NotErrorIs(t, err, gateway.ErrServerError)
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	err := &gateway.APIError{StatusCode: http.StatusNotFound, Message: "data asset not found"}
+	assert.EqualError(t, err, "data asset not found")
+
+	err = &gateway.APIError{StatusCode: http.StatusBadGateway, Method: "GET", Route: gateway.GetDataAssetByID}
+	assert.EqualError(t, err, "GET /data-assets/{id}: 502 Bad Gateway")
+}
+
+func TestAPIErrorFromResponse(t *testing.T) {
+	client := resty.New()
+	httpmock.ActivateNonDefault(client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	dataAssetImpl := gateway.NewDataAssetImpl(gateway.Config{Client: client})
+
+	fixture := `{"error": "data asset not found"}`
+	httpmock.RegisterResponder("DELETE", "/data-assets/42", func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(404, fixture)
+		resp.Header.Set("Content-Type", "application/json")
+		resp.Header.Set("X-Request-Id", "req-123")
+		return resp, nil
+	})
+
+	_, err := dataAssetImpl.DeleteAsset(42)
+
+	assert.ErrorIs(t, err, gateway.ErrNotFound)
+
+	var apiError *gateway.APIError
+	assert.True(t, errors.As(err, &apiError))
+	assert.Equal(t, http.StatusNotFound, apiError.StatusCode)
+	assert.Equal(t, "data asset not found", apiError.Message)
+	assert.Equal(t, fixture, string(apiError.Body))
+	assert.Equal(t, "req-123", apiError.RequestID)
+	assert.Equal(t, gateway.DeleteDataAssetByID, apiError.Route)
+	assert.Equal(t, http.MethodDelete, apiError.Method)
+}
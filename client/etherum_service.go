@@ -2,8 +2,8 @@ package client
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
-	"log"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	common "github.com/ethereum/go-ethereum/common"
@@ -16,25 +16,32 @@ type EtherumService struct {
 	WalletAddress    string
 }
 
+// NewEtherumService panics when walletPrivateKey is not a valid key; use
+// ParseEtherumService to get the error instead.
 func NewEtherumService(walletPrivateKey string) *EtherumService {
-	privateKey, err := crypto.HexToECDSA(walletPrivateKey)
+	service, err := ParseEtherumService(walletPrivateKey)
 	if err != nil {
-		log.Printf("Failed to load private key: %v", err)
 		panic(err)
 	}
+	return service
+}
 
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		log.Fatal("Failed to get the public key from private key")
+// ParseEtherumService loads a hex encoded secp256k1 private key.
+func ParseEtherumService(walletPrivateKey string) (*EtherumService, error) {
+	privateKey, err := crypto.HexToECDSA(walletPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ethereum private key: %w", err)
 	}
 
-	walletAddress := crypto.PubkeyToAddress(*publicKeyECDSA).Hex()
+	publicKeyECDSA, ok := privateKey.Public().(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("invalid ethereum private key: failed to derive the public key")
+	}
 
 	return &EtherumService{
 		WalletPrivateKey: privateKey,
-		WalletAddress:    walletAddress,
-	}
+		WalletAddress:    crypto.PubkeyToAddress(*publicKeyECDSA).Hex(),
+	}, nil
 }
 
 func (es *EtherumService) SignMessage(message string) (WalletSignMessageType, error) {
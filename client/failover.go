@@ -0,0 +1,310 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Environment names a Gateway API deployment.
+type Environment string
+
+const (
+	EnvDev        Environment = "dev"
+	EnvStaging    Environment = "staging"
+	EnvProduction Environment = "production"
+)
+
+var environmentURLs = map[Environment]string{
+	EnvDev:        "https://dev.api.gateway.tech",
+	EnvStaging:    "https://staging.api.gateway.tech",
+	EnvProduction: "https://api.gateway.tech",
+}
+
+// BaseURL returns the URL of the environment's API, or "" when the
+// environment is unknown.
+func (e Environment) BaseURL() string {
+	return environmentURLs[e]
+}
+
+const (
+	DefaultFailoverProbePath     = "/"
+	DefaultFailoverProbeInterval = 30 * time.Second
+	DefaultFailoverProbeTimeout  = 5 * time.Second
+)
+
+// FailoverConfig tunes how an SDK with several base URLs moves between them.
+// Requests go to the first healthy URL in order. A URL that fails with a
+// connection error or a 5xx response is marked down and the request moves
+// to the next one; URLs marked down are probed in the background and the
+// SDK moves back to an earlier URL, ultimately the primary, once it answers
+// again.
+type FailoverConfig struct {
+	// ProbePath is requested with GET to check a URL; any response below
+	// 500 counts as healthy.
+	ProbePath string
+	// ProbeInterval is the least time between two probes of the same URL.
+	ProbeInterval time.Duration
+	ProbeTimeout  time.Duration
+	// OnFailover is called whenever requests move from one base URL to
+	// another, including the move back to the primary.
+	OnFailover func(from string, to string)
+}
+
+func (c FailoverConfig) withDefaults() FailoverConfig {
+	if c.ProbePath == "" {
+		c.ProbePath = DefaultFailoverProbePath
+	}
+	if c.ProbeInterval <= 0 {
+		c.ProbeInterval = DefaultFailoverProbeInterval
+	}
+	if c.ProbeTimeout <= 0 {
+		c.ProbeTimeout = DefaultFailoverProbeTimeout
+	}
+	return c
+}
+
+type endpoint struct {
+	url       *url.URL
+	down      bool
+	probing   bool
+	lastProbe time.Time
+}
+
+// failoverTransport sends the requests built against the primary base URL
+// to the active one, and moves to another base URL when it fails.
+type failoverTransport struct {
+	next   http.RoundTripper
+	config FailoverConfig
+	logger *slog.Logger
+
+	mu        sync.Mutex
+	endpoints []*endpoint
+	active    int
+}
+
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	primary := t.endpoints[0].url
+	if req.URL.Host != primary.Host || req.URL.Scheme != primary.Scheme {
+		return t.next.RoundTrip(req)
+	}
+	t.probeDown()
+
+	attempt := req
+	tried := map[int]bool{}
+	for {
+		i := t.current()
+		tried[i] = true
+
+		res, err := t.next.RoundTrip(t.rewrite(attempt, i))
+		if !endpointFailed(res, err) || req.Context().Err() != nil {
+			return res, err
+		}
+
+		t.markDown(i)
+		next := t.current()
+		if tried[next] || !canReplay(req, res) {
+			return res, err
+		}
+
+		replay, replayErr := replayRequest(req)
+		if replayErr != nil {
+			return res, err
+		}
+		if res != nil {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		attempt = replay
+	}
+}
+
+// endpointFailed reports whether res and err show that the base URL itself
+// is failing rather than the request.
+func endpointFailed(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return res.StatusCode >= http.StatusInternalServerError
+}
+
+// canReplay reports whether req can be sent again to another base URL after
+// failing with res: connection failures can always be replayed, 5xx
+// responses only for requests that are safe to repeat.
+func canReplay(req *http.Request, res *http.Response) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if res == nil || isIdempotent(req.Method) {
+		return true
+	}
+	allowed, _ := req.Context().Value(allowRetryKey{}).(bool)
+	return allowed
+}
+
+func replayRequest(req *http.Request) (*http.Request, error) {
+	replay := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		replay.Body = body
+	}
+	return replay, nil
+}
+
+// rewrite points req, built against the primary base URL, at the base URL
+// of endpoint i.
+func (t *failoverTransport) rewrite(req *http.Request, i int) *http.Request {
+	if i == 0 {
+		return req
+	}
+
+	primary, target := t.endpoints[0].url, t.endpoints[i].url
+	out := req.Clone(req.Context())
+	out.Host = ""
+	out.URL.Scheme = target.Scheme
+	out.URL.Host = target.Host
+	out.URL.Path = strings.TrimSuffix(target.Path, "/") + strings.TrimPrefix(req.URL.Path, strings.TrimSuffix(primary.Path, "/"))
+	out.URL.RawPath = ""
+	return out
+}
+
+func (t *failoverTransport) current() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.active
+}
+
+// markDown records that endpoint i failed and, when it was active, moves to
+// the first endpoint not marked down, or else to the one after i.
+func (t *failoverTransport) markDown(i int) {
+	t.mu.Lock()
+	ep := t.endpoints[i]
+	ep.down = true
+	ep.lastProbe = time.Now()
+
+	if t.active != i {
+		t.mu.Unlock()
+		return
+	}
+
+	next := (i + 1) % len(t.endpoints)
+	for j, candidate := range t.endpoints {
+		if !candidate.down {
+			next = j
+			break
+		}
+	}
+	t.active = next
+	t.mu.Unlock()
+
+	t.switched(i, next)
+}
+
+// probeDown starts a probe of every endpoint marked down whose last probe
+// is older than the probe interval.
+func (t *failoverTransport) probeDown() {
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, ep := range t.endpoints {
+		if !ep.down || ep.probing || now.Sub(ep.lastProbe) < t.config.ProbeInterval {
+			continue
+		}
+		ep.probing = true
+		ep.lastProbe = now
+		go t.probe(i)
+	}
+}
+
+// probe checks endpoint i and, when it answers, clears its down mark and
+// moves back to it if it comes before the active endpoint.
+func (t *failoverTransport) probe(i int) {
+	ctx, cancel := context.WithTimeout(context.Background(), t.config.ProbeTimeout)
+	defer cancel()
+
+	healthy := false
+	probeURL := strings.TrimSuffix(t.endpoints[i].url.String(), "/") + "/" + strings.TrimPrefix(t.config.ProbePath, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probeURL, nil)
+	if err == nil {
+		res, err := t.next.RoundTrip(req)
+		if err == nil {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+			healthy = res.StatusCode < http.StatusInternalServerError
+		}
+	}
+
+	t.mu.Lock()
+	ep := t.endpoints[i]
+	ep.probing = false
+	if !healthy {
+		t.mu.Unlock()
+		return
+	}
+	ep.down = false
+	from := t.active
+	if i < from || t.endpoints[from].down {
+		t.active = i
+	}
+	to := t.active
+	t.mu.Unlock()
+
+	t.switched(from, to)
+}
+
+func (t *failoverTransport) switched(from int, to int) {
+	if from == to {
+		return
+	}
+
+	fromURL, toURL := t.endpoints[from].url.String(), t.endpoints[to].url.String()
+	if to < from {
+		t.logger.Info("gateway base URL recovered", "from", fromURL, "to", toURL)
+	} else {
+		t.logger.Warn("gateway base URL failed over", "from", fromURL, "to", toURL)
+	}
+	if t.config.OnFailover != nil {
+		t.config.OnFailover(fromURL, toURL)
+	}
+}
+
+// installFailover sends the requests of client to the first healthy URL of
+// baseURLs, the first of which must be the client's base URL. It must run
+// before the other transports are installed so that they see one request
+// however many base URLs it tried.
+func installFailover(client *resty.Client, baseURLs []string, config FailoverConfig, logger *slog.Logger) error {
+	endpoints := make([]*endpoint, len(baseURLs))
+	for i, baseURL := range baseURLs {
+		parsed, err := url.Parse(baseURL)
+		if err != nil {
+			return fmt.Errorf("invalid base URL: %w", err)
+		}
+		endpoints[i] = &endpoint{url: parsed}
+	}
+
+	httpClient := client.GetClient()
+	next := httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	httpClient.Transport = &failoverTransport{
+		next:      next,
+		config:    config.withDefaults(),
+		logger:    logger,
+		endpoints: endpoints,
+	}
+	return nil
+}
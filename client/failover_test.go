@@ -0,0 +1,172 @@
+package client_test
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEndpoint is an API server that can be taken down, counting the API
+// requests it receives apart from the health probes.
+type testEndpoint struct {
+	*httptest.Server
+	down  atomic.Bool
+	calls atomic.Int32
+}
+
+func newTestEndpoint(path string) *testEndpoint {
+	e := &testEndpoint{}
+	e.Server = newJSONServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			e.calls.Add(1)
+		}
+		if e.down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	return e
+}
+
+type failovers struct {
+	mu    sync.Mutex
+	moves [][2]string
+}
+
+func (f *failovers) record(from string, to string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.moves = append(f.moves, [2]string{from, to})
+}
+
+func (f *failovers) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.moves)
+}
+
+func TestFailover_MovesAndRecoversPrimary(t *testing.T) {
+	primary, secondary := newTestEndpoint("/health"), newTestEndpoint("/health")
+	defer primary.Close()
+	defer secondary.Close()
+	primary.down.Store(true)
+
+	var moves failovers
+	sdk, err := gateway.New(
+		gateway.WithAPIKey("test-key"),
+		gateway.WithBaseURLs(primary.URL, secondary.URL),
+		gateway.WithFailover(gateway.FailoverConfig{
+			ProbePath:     "/health",
+			ProbeInterval: 50 * time.Millisecond,
+			OnFailover:    moves.record,
+		}),
+	)
+	require.NoError(t, err)
+
+	_, err = sdk.Account.GetMe()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, primary.calls.Load())
+	assert.EqualValues(t, 1, secondary.calls.Load())
+
+	// The secondary stays active until a probe finds the primary healthy.
+	primary.down.Store(false)
+	_, err = sdk.Account.GetMe()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, primary.calls.Load())
+	assert.EqualValues(t, 2, secondary.calls.Load())
+
+	time.Sleep(60 * time.Millisecond)
+	_, err = sdk.Account.GetMe()
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return moves.count() == 2 }, time.Second, 5*time.Millisecond)
+
+	_, err = sdk.Account.GetMe()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, primary.calls.Load())
+	assert.Equal(t, [][2]string{{primary.URL, secondary.URL}, {secondary.URL, primary.URL}}, moves.moves)
+}
+
+func TestFailover_ConnectionErrors(t *testing.T) {
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	secondary := newTestEndpoint("/")
+	defer secondary.Close()
+
+	sdk, err := gateway.New(gateway.WithAPIKey("test-key"), gateway.WithBaseURLs(closed.URL, secondary.URL))
+	require.NoError(t, err)
+
+	_, err = sdk.DataModel.Create(gateway.DataModelCreateRequest{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, secondary.calls.Load())
+}
+
+func TestFailover_ServerErrorsOfWritesAreNotReplayed(t *testing.T) {
+	primary, secondary := newTestEndpoint("/"), newTestEndpoint("/")
+	defer primary.Close()
+	defer secondary.Close()
+	primary.down.Store(true)
+
+	sdk, err := gateway.New(gateway.WithAPIKey("test-key"), gateway.WithBaseURLs(primary.URL, secondary.URL))
+	require.NoError(t, err)
+
+	_, err = sdk.DataModel.Create(gateway.DataModelCreateRequest{})
+	assert.ErrorIs(t, err, gateway.ErrServerError)
+	assert.EqualValues(t, 0, secondary.calls.Load())
+
+	_, err = sdk.DataModel.Create(gateway.DataModelCreateRequest{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, primary.calls.Load())
+	assert.EqualValues(t, 1, secondary.calls.Load())
+}
+
+func TestFailover_AllDown(t *testing.T) {
+	primary, secondary := newTestEndpoint("/"), newTestEndpoint("/")
+	defer primary.Close()
+	defer secondary.Close()
+	primary.down.Store(true)
+	secondary.down.Store(true)
+
+	sdk, err := gateway.New(gateway.WithAPIKey("test-key"), gateway.WithBaseURLs(primary.URL, secondary.URL))
+	require.NoError(t, err)
+
+	_, err = sdk.Account.GetMe()
+	assert.ErrorIs(t, err, gateway.ErrServerError)
+	assert.EqualValues(t, 1, primary.calls.Load())
+	assert.EqualValues(t, 1, secondary.calls.Load())
+}
+
+func TestEnvironment(t *testing.T) {
+	sdk, err := gateway.New(gateway.WithAPIKey("test-key"), gateway.WithEnvironment(gateway.EnvProduction))
+	require.NoError(t, err)
+	assert.Equal(t, gateway.EnvProduction.BaseURL(), sdk.Account.(*gateway.AccountsImpl).Config.Client.BaseURL)
+	assert.Equal(t, gateway.DefaultBaseURL, gateway.EnvDev.BaseURL())
+
+	_, err = gateway.New(gateway.WithAPIKey("test-key"), gateway.WithEnvironment("qa"))
+	assert.ErrorContains(t, err, `unknown environment "qa"`)
+
+	_, err = gateway.New(gateway.WithAPIKey("test-key"), gateway.WithEnvironment(gateway.EnvStaging), gateway.WithBaseURL("https://example.com"))
+	assert.ErrorContains(t, err, "mutually exclusive")
+
+	_, err = gateway.New(gateway.WithAPIKey("test-key"), gateway.WithBaseURLs("https://example.com", "example.org"))
+	assert.ErrorContains(t, err, `invalid base URL "example.org"`)
+}
+
+func TestEnvironment_DevDefaultWarns(t *testing.T) {
+	logger, buf := newTestLogger(slog.LevelWarn)
+	_, err := gateway.New(gateway.WithAPIKey("test-key"), gateway.WithLogger(logger))
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "using the dev Gateway API")
+
+	buf.Reset()
+	_, err = gateway.New(gateway.WithAPIKey("test-key"), gateway.WithLogger(logger), gateway.WithEnvironment(gateway.EnvDev))
+	require.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
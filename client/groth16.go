@@ -0,0 +1,149 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// Groth16VerifyingKey checks Groth16 proofs over BN254, such as the zkLogin
+// proofs of Sui.
+type Groth16VerifyingKey struct {
+	alpha bn254.G1Affine
+	beta  bn254.G2Affine
+	gamma bn254.G2Affine
+	delta bn254.G2Affine
+	ic    []bn254.G1Affine
+}
+
+// snarkjsVerifyingKey is the verification_key.json layout of snarkjs, with
+// points as projective coordinates in decimal.
+type snarkjsVerifyingKey struct {
+	Protocol string     `json:"protocol"`
+	Curve    string     `json:"curve"`
+	Alpha    []string   `json:"vk_alpha_1"`
+	Beta     [][]string `json:"vk_beta_2"`
+	Gamma    [][]string `json:"vk_gamma_2"`
+	Delta    [][]string `json:"vk_delta_2"`
+	IC       [][]string `json:"IC"`
+}
+
+// ParseGroth16VerifyingKey reads a verifying key in the verification_key.json
+// format of snarkjs.
+func ParseGroth16VerifyingKey(data []byte) (*Groth16VerifyingKey, error) {
+	var raw snarkjsVerifyingKey
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid verifying key: %w", err)
+	}
+	if raw.Protocol != "" && raw.Protocol != "groth16" {
+		return nil, fmt.Errorf("invalid verifying key: unsupported protocol %q", raw.Protocol)
+	}
+	if raw.Curve != "" && raw.Curve != "bn128" && raw.Curve != "bn254" {
+		return nil, fmt.Errorf("invalid verifying key: unsupported curve %q", raw.Curve)
+	}
+	if len(raw.IC) < 1 {
+		return nil, errors.New("invalid verifying key: no IC points")
+	}
+
+	var vk Groth16VerifyingKey
+	var err error
+	if vk.alpha, err = parseG1(raw.Alpha); err != nil {
+		return nil, fmt.Errorf("invalid verifying key alpha: %w", err)
+	}
+	if vk.beta, err = parseG2(raw.Beta); err != nil {
+		return nil, fmt.Errorf("invalid verifying key beta: %w", err)
+	}
+	if vk.gamma, err = parseG2(raw.Gamma); err != nil {
+		return nil, fmt.Errorf("invalid verifying key gamma: %w", err)
+	}
+	if vk.delta, err = parseG2(raw.Delta); err != nil {
+		return nil, fmt.Errorf("invalid verifying key delta: %w", err)
+	}
+	vk.ic = make([]bn254.G1Affine, len(raw.IC))
+	for i, point := range raw.IC {
+		if vk.ic[i], err = parseG1(point); err != nil {
+			return nil, fmt.Errorf("invalid verifying key IC %d: %w", i, err)
+		}
+	}
+	return &vk, nil
+}
+
+// verify checks the proof of points a, b and c for the public inputs.
+func (vk *Groth16VerifyingKey) verify(a bn254.G1Affine, b bn254.G2Affine, c bn254.G1Affine, inputs []*big.Int) (bool, error) {
+	if len(inputs) != len(vk.ic)-1 {
+		return false, fmt.Errorf("expected %d public inputs, got %d", len(vk.ic)-1, len(inputs))
+	}
+
+	var vkX bn254.G1Jac
+	vkX.FromAffine(&vk.ic[0])
+	for i, input := range inputs {
+		if input.Sign() < 0 || input.Cmp(fr.Modulus()) >= 0 {
+			return false, errors.New("public input is not a field element")
+		}
+		var term bn254.G1Jac
+		term.FromAffine(&vk.ic[i+1])
+		term.ScalarMultiplication(&term, input)
+		vkX.AddAssign(&term)
+	}
+	var vkXAffine, negA bn254.G1Affine
+	vkXAffine.FromJacobian(&vkX)
+	negA.Neg(&a)
+
+	// e(A, B) = e(alpha, beta) * e(vk_x, gamma) * e(C, delta)
+	return bn254.PairingCheck(
+		[]bn254.G1Affine{negA, vk.alpha, vkXAffine, c},
+		[]bn254.G2Affine{b, vk.beta, vk.gamma, vk.delta},
+	)
+}
+
+// parseG1 reads a G1 point given as decimal [x, y] or projective [x, y, 1].
+func parseG1(coordinates []string) (bn254.G1Affine, error) {
+	var p bn254.G1Affine
+	if len(coordinates) != 2 && !(len(coordinates) == 3 && coordinates[2] == "1") {
+		return p, errors.New("expected affine coordinates")
+	}
+	if _, err := p.X.SetString(coordinates[0]); err != nil {
+		return p, err
+	}
+	if _, err := p.Y.SetString(coordinates[1]); err != nil {
+		return p, err
+	}
+	if !p.IsOnCurve() || !p.IsInSubGroup() {
+		return p, errors.New("point is not on the curve")
+	}
+	return p, nil
+}
+
+// parseG2 reads a G2 point given as decimal [[x0, x1], [y0, y1]] or with a
+// third coordinate of [1, 0], where x = x0 + x1*u.
+func parseG2(coordinates [][]string) (bn254.G2Affine, error) {
+	var p bn254.G2Affine
+	if len(coordinates) != 2 && !(len(coordinates) == 3 && len(coordinates[2]) == 2 && coordinates[2][0] == "1" && coordinates[2][1] == "0") {
+		return p, errors.New("expected affine coordinates")
+	}
+	for _, c := range coordinates[:2] {
+		if len(c) != 2 {
+			return p, errors.New("expected two coordinates per element")
+		}
+	}
+	if _, err := p.X.A0.SetString(coordinates[0][0]); err != nil {
+		return p, err
+	}
+	if _, err := p.X.A1.SetString(coordinates[0][1]); err != nil {
+		return p, err
+	}
+	if _, err := p.Y.A0.SetString(coordinates[1][0]); err != nil {
+		return p, err
+	}
+	if _, err := p.Y.A1.SetString(coordinates[1][1]); err != nil {
+		return p, err
+	}
+	if !p.IsOnCurve() || !p.IsInSubGroup() {
+		return p, errors.New("point is not on the curve")
+	}
+	return p, nil
+}
@@ -1,14 +1,29 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const DefaultTokenLeeway = 30 * time.Second
+
+// tokenIssueTimeout bounds a shared token issue, which outlives the caller
+// that started it.
+const tokenIssueTimeout = time.Minute
+
 func CheckJWTTokenExpiration(tokenString string) (bool, error) {
+	return CheckJWTTokenExpirationWithLeeway(tokenString, 0)
+}
+
+// CheckJWTTokenExpirationWithLeeway reports a token as expired once it is
+// within leeway of its exp claim, so it is never sent moments before expiry.
+func CheckJWTTokenExpirationWithLeeway(tokenString string, leeway time.Duration) (bool, error) {
 	claims := &jwt.RegisteredClaims{}
 
 	_, _, err := jwt.NewParser(jwt.WithoutClaimsValidation()).ParseUnverified(tokenString, claims)
@@ -17,64 +32,244 @@ func CheckJWTTokenExpiration(tokenString string) (bool, error) {
 		return false, err
 	}
 
-	if claims.ExpiresAt != nil && claims.ExpiresAt.Time.Before(time.Now()) {
+	if claims.ExpiresAt != nil && claims.ExpiresAt.Time.Before(time.Now().Add(leeway)) {
 		return false, nil
 	}
 
 	return true, nil
 }
 
+// jwtExpiration returns the exp claim of a token, if it has one.
+func jwtExpiration(tokenString string) (time.Time, bool) {
+	claims := &jwt.RegisteredClaims{}
+
+	_, _, err := jwt.NewParser(jwt.WithoutClaimsValidation()).ParseUnverified(tokenString, claims)
+	if err != nil || claims.ExpiresAt == nil {
+		return time.Time{}, false
+	}
+
+	return claims.ExpiresAt.Time, true
+}
+
 func IssueJWT(client resty.Client, wallet Wallet) (string, error) {
-	auth := NewAuthImpl(Config{Client: &client})
+	return IssueJWTCtx(context.Background(), client, wallet)
+}
+
+func IssueJWTCtx(ctx context.Context, client resty.Client, wallet Wallet) (string, error) {
+	return issueJWT(ctx, Config{Client: &client}, wallet)
+}
+
+// issueJWT signs in with wallet through the client of config.
+func issueJWT(ctx context.Context, config Config, wallet Wallet) (jwt string, err error) {
+	ctx, span := startSpan(ctx, "Auth.IssueJWT")
+	defer func() { endSpan(span, err) }()
+
+	logger := config.logger()
+	logger.DebugContext(ctx, "signing in with wallet")
+	defer func() {
+		if err != nil {
+			logger.WarnContext(ctx, "wallet sign-in failed", "error", err)
+		}
+	}()
 
-	message, messageErr := auth.GetMessage()
+	auth := NewAuthImpl(config)
+
+	message, messageErr := auth.GetMessageCtx(ctx)
 	if messageErr != nil {
 		return "", messageErr
 	}
 
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	_, signSpan := startSpan(ctx, "Wallet.SignMessage")
 	signatureDetails, signingErr := wallet.SignMessage(message)
+	endSpan(signSpan, signingErr)
 	if signingErr != nil {
+		config.metrics().SignatureFailed()
 		return "", signingErr
 	}
 
-	jwt, authErr := auth.Login(message, string(signatureDetails.Signature), signatureDetails.SigningKey)
+	// Wallets of a known chain skip guessing it from the address.
+	var token string
+	var authErr error
+	if service, ok := wallet.(*WalletService); ok {
+		token, authErr = auth.LoginWithChainCtx(ctx, service.WalletType, message, signatureDetails.Signature, signatureDetails.SigningKey)
+	} else {
+		token, authErr = auth.LoginCtx(ctx, message, string(signatureDetails.Signature), signatureDetails.SigningKey)
+	}
 	if authErr != nil {
 		return "", authErr
 	}
-	return jwt, nil
+	config.metrics().JWTIssued()
+	logger.DebugContext(ctx, "issued token")
+	return token, nil
 }
 
 var UNPROTECTED_ROUTES = []string{GenerateSignMessage,
 	RefreshToken, AuthenticateAccount}
 
+type tokenCall struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+// tokenHolder caches the JWT issued for a wallet and collapses concurrent
+// re-authentications into a single issue call.
+type tokenHolder struct {
+	mu     sync.Mutex
+	token  string
+	call   *tokenCall
+	leeway time.Duration
+	issue  func(ctx context.Context) (string, error)
+	// bearer sends the tokens with the Bearer scheme. API keys and the
+	// tokens renewed from them are bearer tokens; wallet JWTs are sent bare.
+	bearer bool
+}
+
+func newTokenHolder(leeway time.Duration, issue func(ctx context.Context) (string, error)) *tokenHolder {
+	if leeway == 0 {
+		leeway = DefaultTokenLeeway
+	}
+	return &tokenHolder{
+		leeway: leeway,
+		issue:  issue,
+	}
+}
+
+var errNoTokenIssuer = errors.New("token expired and no wallet is configured to issue a new one")
+
+func (h *tokenHolder) Token(ctx context.Context) (string, error) {
+	return h.get(ctx, false)
+}
+
+// Reissue issues a new token even if the cached one is still valid.
+func (h *tokenHolder) Reissue(ctx context.Context) (string, error) {
+	return h.get(ctx, true)
+}
+
+func (h *tokenHolder) get(ctx context.Context, force bool) (string, error) {
+	h.mu.Lock()
+	if h.token != "" && !force {
+		if isValid, _ := CheckJWTTokenExpirationWithLeeway(h.token, h.leeway); isValid {
+			token := h.token
+			h.mu.Unlock()
+			return token, nil
+		}
+	}
+
+	call := h.call
+	if call == nil {
+		if h.issue == nil {
+			h.mu.Unlock()
+			return "", errNoTokenIssuer
+		}
+		if err := ctx.Err(); err != nil {
+			h.mu.Unlock()
+			return "", err
+		}
+
+		call = &tokenCall{done: make(chan struct{})}
+		h.call = call
+		go h.issueShared(ctx, call)
+	}
+	h.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.token, call.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// issueShared issues the token every caller of get waits for. It keeps the
+// values of the ctx that started it but not its cancellation, so the caller
+// giving up does not fail the others.
+func (h *tokenHolder) issueShared(ctx context.Context, call *tokenCall) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), tokenIssueTimeout)
+	defer cancel()
+
+	call.token, call.err = h.issue(ctx)
+
+	h.mu.Lock()
+	if call.err == nil {
+		h.token = call.token
+	}
+	h.call = nil
+	h.mu.Unlock()
+	close(call.done)
+}
+
+func (h *tokenHolder) Current() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.token
+}
+
+func (h *tokenHolder) Set(token string) {
+	h.mu.Lock()
+	h.token = token
+	h.mu.Unlock()
+}
+
+func (h *tokenHolder) Invalidate() {
+	h.Set("")
+}
+
+func newWalletTokenHolder(params MiddlewareParams) *tokenHolder {
+	return newTokenHolder(params.TokenLeeway, func(ctx context.Context) (string, error) {
+		client := *params.Client
+		return issueJWT(ctx, Config{
+			Client:             &client,
+			Metrics:            params.Metrics,
+			Logger:             params.Logger,
+			Verifiers:          params.Verifiers,
+			StrictVerification: params.StrictVerification,
+		}, &params.Wallet)
+	})
+}
+
 func AuthMiddleware(params MiddlewareParams) resty.RequestMiddleware {
+	return authMiddleware(newWalletTokenHolder(params))
+}
+
+func authMiddleware(holder *tokenHolder) resty.RequestMiddleware {
 	return func(c *resty.Client, r *resty.Request) error {
 		for _, route := range UNPROTECTED_ROUTES {
 			if route == r.URL {
 				return nil
 			}
 		}
+
 		accessToken := r.Header.Get("Authorization")
-		if accessToken == "" {
-			newToken, err := IssueJWT(*params.Client, &params.Wallet)
-			if err != nil {
-				return fmt.Errorf("failed to issue new token: %v", err)
-			}
-			accessToken = newToken
-		} else {
-			isValid, _ := CheckJWTTokenExpiration(accessToken)
-
-			if !isValid {
-				newToken, err := IssueJWT(*params.Client, &params.Wallet)
-				if err != nil {
-					return fmt.Errorf("failed to issue new token: %v", err)
-				}
-				accessToken = newToken
+		if accessToken != "" {
+			if isValid, _ := CheckJWTTokenExpirationWithLeeway(accessToken, holder.leeway); isValid {
+				return nil
 			}
+		}
 
+		accessToken, err := holder.Token(r.Context())
+		if err != nil {
+			return fmt.Errorf("failed to issue new token: %w", err)
 		}
-		r.Header.Set("Authorization", accessToken)
+		holder.authorize(r, accessToken)
 
 		return nil
 	}
 }
+
+// authorize sets token as the Authorization header of r, in the format of
+// the holder's credential, so a token is sent the same way by every
+// request. The header is set right away so hooks see it, and the request
+// token keeps a client-wide API key from replacing a bearer token.
+func (h *tokenHolder) authorize(r *resty.Request, token string) *resty.Request {
+	if !h.bearer {
+		r.Header.Set("Authorization", token)
+		return r
+	}
+	r.Header.Set("Authorization", "Bearer "+token)
+	return r.SetAuthToken(token)
+}
@@ -1,8 +1,11 @@
 package client_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"sync"
 	"testing"
 	"time"
 
@@ -117,13 +120,13 @@ func TestIssueJWT_LoginError(t *testing.T) {
 	httpmock.RegisterResponder("GET", "=~.*/auth/message",
 		httpmock.NewStringResponder(200, fixtureMessage))
 
-	httpmock.RegisterResponder("POST", "=~.*/auth/login",
+	httpmock.RegisterResponder("POST", gateway.AuthenticateAccount,
 		httpmock.NewStringResponder(500, `{"error": "internal server error"}`))
 
+	// No verifier handles the mock signing key, so the API decides.
 	_, err := gateway.IssueJWT(*client, mockWallet)
 
-	assert.Error(t, err)
-	assert.EqualError(t, err, "solana signature verification failed: failed to decode signature from Base58: invalid base58 digit ('-')")
+	assert.ErrorIs(t, err, gateway.ErrServerError)
 }
 
 func TestIssueJWT_FailSignMessage(t *testing.T) {
@@ -167,3 +170,206 @@ func TestAuthMiddleware_ExistingValidToken(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, tokenString, req.Header.Get("Authorization"), "Authorization header should not change if token is valid")
 }
+
+func TestIssueJWTCtx_Canceled(t *testing.T) {
+	client := resty.New()
+	httpmock.ActivateNonDefault(client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	mockWallet := new(MockWallet)
+
+	httpmock.RegisterResponder("GET", "=~.*/auth/message",
+		httpmock.NewStringResponder(200, `{"message": "mock-message"}`))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := gateway.IssueJWTCtx(ctx, *client, mockWallet)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	mockWallet.AssertNotCalled(t, "SignMessage", mock.Anything)
+}
+
+func TestAuthMiddleware_UsesRequestContext(t *testing.T) {
+	client := resty.New()
+	client.SetBaseURL("https://example.com")
+	httpmock.ActivateNonDefault(client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "=~.*/auth/message",
+		httpmock.NewStringResponder(200, `{"message": "mock-message"}`))
+
+	params := gateway.MiddlewareParams{
+		Client: client,
+		Wallet: gateway.WalletService{},
+	}
+
+	middleware := gateway.AuthMiddleware(params)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req := client.R().SetContext(ctx)
+	req.URL = gateway.GetMyAccount
+
+	err := middleware(client, req)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, req.Header.Get("Authorization"))
+}
+
+func TestCheckJWTTokenExpirationWithLeeway(t *testing.T) {
+	tokenString := newTestJWT(20 * time.Second)
+
+	isValid, err := gateway.CheckJWTTokenExpirationWithLeeway(tokenString, time.Second)
+	assert.NoError(t, err)
+	assert.True(t, isValid)
+
+	isValid, err = gateway.CheckJWTTokenExpirationWithLeeway(tokenString, time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, isValid)
+}
+
+func newTestJWT(expiresIn time.Duration) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn)),
+	})
+	tokenString, _ := token.SignedString([]byte("secret"))
+	return tokenString
+}
+
+func newTestAuthMiddleware(t *testing.T, client *resty.Client, leeway time.Duration) gateway.MiddlewareParams {
+	wallet, err := gateway.NewWalletService("edb0ba5a63c5f9e4f4394560907794fca750704b355413bc04baab896254036a", gateway.Ethereum)
+	assert.NoError(t, err)
+
+	return gateway.MiddlewareParams{
+		Client:      client,
+		Wallet:      *wallet,
+		TokenLeeway: leeway,
+	}
+}
+
+func TestAuthMiddleware_ReusesIssuedToken(t *testing.T) {
+	client := resty.New()
+	client.SetBaseURL("https://example.com")
+	httpmock.ActivateNonDefault(client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	tokenString := newTestJWT(10 * time.Minute)
+	httpmock.RegisterResponder("GET", "=~.*/auth/message",
+		httpmock.NewJsonResponderOrPanic(200, gateway.MessageResponse{Message: "mock-message"}))
+	httpmock.RegisterResponder("POST", "=~.*/auth$",
+		httpmock.NewJsonResponderOrPanic(200, gateway.TokenResponse{Token: tokenString}))
+
+	middleware := gateway.AuthMiddleware(newTestAuthMiddleware(t, client, 0))
+
+	for i := 0; i < 3; i++ {
+		req := client.R()
+		req.URL = gateway.GetMyAccount
+
+		err := middleware(client, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tokenString, req.Header.Get("Authorization"))
+	}
+
+	info := httpmock.GetCallCountInfo()
+	assert.Equal(t, 1, info["GET =~.*/auth/message"])
+	assert.Equal(t, 1, info["POST =~.*/auth$"])
+}
+
+func TestAuthMiddleware_ReissuesTokenWithinLeeway(t *testing.T) {
+	client := resty.New()
+	client.SetBaseURL("https://example.com")
+	httpmock.ActivateNonDefault(client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "=~.*/auth/message",
+		httpmock.NewJsonResponderOrPanic(200, gateway.MessageResponse{Message: "mock-message"}))
+	httpmock.RegisterResponder("POST", "=~.*/auth$",
+		httpmock.NewJsonResponderOrPanic(200, gateway.TokenResponse{Token: newTestJWT(10 * time.Second)}))
+
+	middleware := gateway.AuthMiddleware(newTestAuthMiddleware(t, client, time.Minute))
+
+	for i := 0; i < 2; i++ {
+		req := client.R()
+		req.URL = gateway.GetMyAccount
+		assert.NoError(t, middleware(client, req))
+	}
+
+	assert.Equal(t, 2, httpmock.GetCallCountInfo()["GET =~.*/auth/message"])
+}
+
+func TestAuthMiddleware_SingleFlight(t *testing.T) {
+	client := resty.New()
+	client.SetBaseURL("https://example.com")
+	httpmock.ActivateNonDefault(client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	tokenString := newTestJWT(10 * time.Minute)
+	httpmock.RegisterResponder("GET", "=~.*/auth/message", func(req *http.Request) (*http.Response, error) {
+		time.Sleep(20 * time.Millisecond)
+		return httpmock.NewJsonResponse(200, gateway.MessageResponse{Message: "mock-message"})
+	})
+	httpmock.RegisterResponder("POST", "=~.*/auth$",
+		httpmock.NewJsonResponderOrPanic(200, gateway.TokenResponse{Token: tokenString}))
+
+	middleware := gateway.AuthMiddleware(newTestAuthMiddleware(t, client, 0))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := client.R()
+			req.URL = gateway.GetMyAccount
+			assert.NoError(t, middleware(client, req))
+			assert.Equal(t, tokenString, req.Header.Get("Authorization"))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["GET =~.*/auth/message"])
+}
+
+func TestAuthMiddleware_SingleFlightOutlivesCanceledCaller(t *testing.T) {
+	client := resty.New()
+	client.SetBaseURL("https://example.com")
+	httpmock.ActivateNonDefault(client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	tokenString := newTestJWT(10 * time.Minute)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	httpmock.RegisterResponder("GET", "=~.*/auth/message", func(req *http.Request) (*http.Response, error) {
+		close(started)
+		<-release
+		return httpmock.NewJsonResponse(200, gateway.MessageResponse{Message: "mock-message"})
+	})
+	httpmock.RegisterResponder("POST", "=~.*/auth$",
+		httpmock.NewJsonResponderOrPanic(200, gateway.TokenResponse{Token: tokenString}))
+
+	middleware := gateway.AuthMiddleware(newTestAuthMiddleware(t, client, 0))
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		req := client.R().SetContext(leaderCtx)
+		req.URL = gateway.GetMyAccount
+		leaderErr <- middleware(client, req)
+	}()
+	<-started
+
+	waiterErr := make(chan error, 1)
+	waiter := client.R()
+	waiter.URL = gateway.GetMyAccount
+	go func() { waiterErr <- middleware(client, waiter) }()
+
+	cancelLeader()
+	assert.ErrorIs(t, <-leaderErr, context.Canceled)
+
+	close(release)
+	assert.NoError(t, <-waiterErr)
+	assert.Equal(t, tokenString, waiter.Header.Get("Authorization"))
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["GET =~.*/auth/message"])
+}
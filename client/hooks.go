@@ -0,0 +1,219 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// HookRequest describes an SDK request to the hooks. BeforeRequest hooks
+// can change Header, PathParams and QueryParams; the changes are sent.
+type HookRequest struct {
+	// Operation is the SDK call that made the request, for example
+	// "DataAsset.Share". It is empty for requests made directly on
+	// Config.Client.
+	Operation string
+	Method    string
+	// Route is the route template, for example "/data-assets/{id}/share".
+	Route string
+	// Attempt counts from 1 and grows when the request is retried.
+	Attempt int
+	// Start is when the first attempt began.
+	Start       time.Time
+	Header      http.Header
+	PathParams  map[string]string
+	QueryParams url.Values
+
+	request *resty.Request
+}
+
+// HookResponse describes the response to an SDK request.
+type HookResponse struct {
+	StatusCode int
+	Header     http.Header
+	// Duration runs from HookRequest.Start until the response was read,
+	// retries included.
+	Duration time.Duration
+}
+
+// BeforeRequest runs before every attempt of a request. Returning an error
+// aborts the request: the SDK call fails with that error and later hooks
+// do not run.
+type BeforeRequest func(ctx context.Context, req *HookRequest) error
+
+// AfterResponse runs once the server has answered a request, whatever the
+// status code.
+type AfterResponse func(ctx context.Context, req *HookRequest, res *HookResponse)
+
+// OnError runs when a request fails: it could not be sent, a hook aborted
+// it or the server answered with an error status, reported as an
+// *APIError.
+type OnError func(ctx context.Context, req *HookRequest, err error)
+
+// Hook is a BeforeRequest, AfterResponse or OnError hook.
+type Hook interface {
+	register(chain *hookChain)
+}
+
+func (h BeforeRequest) register(chain *hookChain) {
+	chain.before = append(chain.before, h)
+}
+
+func (h AfterResponse) register(chain *hookChain) {
+	chain.after = append(chain.after, h)
+}
+
+func (h OnError) register(chain *hookChain) {
+	chain.onError = append(chain.onError, h)
+}
+
+// Use adds hooks to every request the SDK sends. Hooks of the same kind
+// run in the order they were added.
+func (sdk *SDK) Use(hooks ...Hook) {
+	if sdk.hooks != nil {
+		sdk.hooks.use(hooks...)
+	}
+}
+
+// hookChain holds the hooks of an SDK.
+type hookChain struct {
+	mu      sync.RWMutex
+	before  []BeforeRequest
+	after   []AfterResponse
+	onError []OnError
+}
+
+// newHookChain installs a chain holding hooks on client. It must run after
+// the authentication middleware so that hooks see the Authorization
+// header.
+func newHookChain(client *resty.Client, hooks []Hook) *hookChain {
+	chain := &hookChain{}
+	chain.use(hooks...)
+
+	client.OnBeforeRequest(chain.beforeRequest)
+	client.OnSuccess(chain.success)
+	client.OnError(chain.failure)
+
+	return chain
+}
+
+func (c *hookChain) use(hooks ...Hook) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, hook := range hooks {
+		if hook != nil {
+			hook.register(c)
+		}
+	}
+}
+
+func (c *hookChain) beforeRequest(_ *resty.Client, r *resty.Request) error {
+	c.mu.RLock()
+	hooks := c.before
+	c.mu.RUnlock()
+
+	info := hookRequestFor(r)
+	info.Attempt = r.Attempt
+
+	for _, hook := range hooks {
+		if err := hook(r.Context(), info); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *hookChain) success(_ *resty.Client, res *resty.Response) {
+	c.mu.RLock()
+	after, onError := c.after, c.onError
+	c.mu.RUnlock()
+
+	r := res.Request
+	info := hookRequestFor(r)
+
+	response := &HookResponse{
+		StatusCode: res.StatusCode(),
+		Header:     res.Header(),
+		Duration:   res.ReceivedAt().Sub(info.Start),
+	}
+	for _, hook := range after {
+		hook(r.Context(), info, response)
+	}
+
+	if !res.IsError() || len(onError) == 0 {
+		return
+	}
+
+	var body Error
+	if parsed, ok := r.Error.(*Error); ok && parsed != nil {
+		body = *parsed
+	}
+	err := newAPIError(res, info.Route, body)
+	for _, hook := range onError {
+		hook(r.Context(), info, err)
+	}
+}
+
+func (c *hookChain) failure(r *resty.Request, err error) {
+	c.mu.RLock()
+	hooks := c.onError
+	c.mu.RUnlock()
+
+	var responseErr *resty.ResponseError
+	if errors.As(err, &responseErr) {
+		err = responseErr.Err
+	}
+
+	info := hookRequestFor(r)
+	for _, hook := range hooks {
+		hook(r.Context(), info, err)
+	}
+}
+
+type hookRequestKey struct{}
+
+// hookRequestFor returns the HookRequest of r, creating it on the first
+// attempt. It is kept in the request context so that retries share it;
+// the owner check skips the one of an enclosing request, such as the SDK
+// call that triggered a wallet sign-in.
+func hookRequestFor(r *resty.Request) *HookRequest {
+	ctx := r.Context()
+	if info, ok := ctx.Value(hookRequestKey{}).(*HookRequest); ok && info.request == r {
+		return info
+	}
+
+	info := &HookRequest{
+		Operation:   operationFrom(ctx),
+		Method:      r.Method,
+		Route:       r.URL,
+		Attempt:     r.Attempt,
+		Start:       time.Now(),
+		Header:      r.Header,
+		PathParams:  r.PathParams,
+		QueryParams: r.QueryParam,
+		request:     r,
+	}
+	r.SetContext(context.WithValue(ctx, hookRequestKey{}, info))
+
+	return info
+}
+
+type operationKey struct{}
+
+// withOperation tags ctx with the SDK call it is used for, for example
+// "DataAsset.Share".
+func withOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+func operationFrom(ctx context.Context) string {
+	operation, _ := ctx.Value(operationKey{}).(string)
+	return operation
+}
@@ -0,0 +1,201 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/Gateway-DAO/gateway-go-sdk/gatewaytest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUse_BeforeRequestMutatesRequest(t *testing.T) {
+	var header, page string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Tenant")
+		page = r.URL.Query().Get("page")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": [], "meta": {}, "links": {}}`))
+	}))
+	defer server.Close()
+
+	sdk, err := gateway.New(gateway.WithAPIKey("test-key"), gateway.WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	var seen gateway.HookRequest
+	sdk.Use(gateway.BeforeRequest(func(ctx context.Context, req *gateway.HookRequest) error {
+		seen = *req
+		req.Header.Set("X-Tenant", "acme")
+		req.QueryParams.Set("page", "7")
+		return nil
+	}))
+
+	_, err = sdk.DataModel.GetAll(1, 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, "acme", header)
+	assert.Equal(t, "7", page)
+	assert.Equal(t, "DataModel.GetAll", seen.Operation)
+	assert.Equal(t, http.MethodGet, seen.Method)
+	assert.Equal(t, gateway.GetDataModels, seen.Route)
+	assert.Equal(t, 1, seen.Attempt)
+	assert.Equal(t, "Bearer test-key", seen.Header.Get("Authorization"))
+}
+
+func TestUse_BeforeRequestShortCircuits(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	errBlocked := errors.New("blocked")
+	var order []string
+	var reported error
+
+	sdk, err := gateway.New(
+		gateway.WithAPIKey("test-key"),
+		gateway.WithBaseURL(server.URL),
+		gateway.WithHooks(
+			gateway.BeforeRequest(func(ctx context.Context, req *gateway.HookRequest) error {
+				order = append(order, "first")
+				return nil
+			}),
+			gateway.BeforeRequest(func(ctx context.Context, req *gateway.HookRequest) error {
+				order = append(order, "second")
+				return errBlocked
+			}),
+			gateway.BeforeRequest(func(ctx context.Context, req *gateway.HookRequest) error {
+				order = append(order, "third")
+				return nil
+			}),
+			gateway.OnError(func(ctx context.Context, req *gateway.HookRequest, err error) {
+				assert.Equal(t, "DataAsset.Share", req.Operation)
+				assert.Equal(t, gateway.ShareDataAssetByID, req.Route)
+				reported = err
+			}),
+		),
+	)
+	require.NoError(t, err)
+
+	_, err = sdk.DataAssets.Share(1, []gateway.ShareDataAssetRequest{{Addresses: []string{"0x1"}}})
+
+	assert.ErrorIs(t, err, errBlocked)
+	assert.ErrorIs(t, reported, errBlocked)
+	assert.Equal(t, []string{"first", "second"}, order)
+	assert.Zero(t, atomic.LoadInt32(&calls))
+}
+
+func TestUse_AfterResponseAndOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "data asset not found"}`))
+	}))
+	defer server.Close()
+
+	sdk, err := gateway.New(gateway.WithAPIKey("test-key"), gateway.WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	var response *gateway.HookResponse
+	var reported error
+	sdk.Use(
+		gateway.AfterResponse(func(ctx context.Context, req *gateway.HookRequest, res *gateway.HookResponse) {
+			assert.Equal(t, "DataAsset.Get", req.Operation)
+			response = res
+		}),
+		gateway.OnError(func(ctx context.Context, req *gateway.HookRequest, err error) {
+			reported = err
+		}),
+	)
+
+	_, err = sdk.DataAssets.Get(42)
+	assert.ErrorIs(t, err, gateway.ErrNotFound)
+
+	require.NotNil(t, response)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	assert.GreaterOrEqual(t, response.Duration, 10*time.Millisecond)
+
+	var apiErr *gateway.APIError
+	require.ErrorAs(t, reported, &apiErr)
+	assert.Equal(t, "data asset not found", apiErr.Message)
+	assert.Equal(t, gateway.GetDataAssetByID, apiErr.Route)
+}
+
+func TestUse_RetriesShareTheRequest(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"did": "did:gw:alice"}`))
+	}))
+	defer server.Close()
+
+	var attempts []int
+	var starts []time.Time
+	var afterCalls int
+	sdk, err := gateway.New(
+		gateway.WithAPIKey("test-key"),
+		gateway.WithBaseURL(server.URL),
+		gateway.WithRetry(gateway.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+		gateway.WithHooks(
+			gateway.BeforeRequest(func(ctx context.Context, req *gateway.HookRequest) error {
+				attempts = append(attempts, req.Attempt)
+				starts = append(starts, req.Start)
+				return nil
+			}),
+			gateway.AfterResponse(func(ctx context.Context, req *gateway.HookRequest, res *gateway.HookResponse) {
+				afterCalls++
+				assert.Equal(t, http.StatusOK, res.StatusCode)
+			}),
+		),
+	)
+	require.NoError(t, err)
+
+	_, err = sdk.Account.GetMe()
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2}, attempts)
+	require.Len(t, starts, 2)
+	assert.Equal(t, starts[0], starts[1])
+	assert.Equal(t, 1, afterCalls)
+}
+
+func TestUse_WalletLoginOperations(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	wallet := gateway.WalletDetails{PrivateKey: testEthereumKey, WalletType: gateway.Ethereum}
+	_, err := srv.RegisterWallet("alice", wallet)
+	require.NoError(t, err)
+
+	var operations []string
+	sdk, err := gateway.New(
+		gateway.WithWallet(wallet.PrivateKey, wallet.WalletType),
+		gateway.WithBaseURL(srv.URL),
+		gateway.WithHooks(gateway.AfterResponse(func(ctx context.Context, req *gateway.HookRequest, res *gateway.HookResponse) {
+			operations = append(operations, req.Operation+" "+req.Route)
+		})),
+	)
+	require.NoError(t, err)
+
+	_, err = sdk.Account.GetMe()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"Auth.GetMessage " + gateway.GenerateSignMessage,
+		"Auth.Login " + gateway.AuthenticateAccount,
+		"Accounts.GetMe " + gateway.GetMyAccount,
+	}, operations)
+}
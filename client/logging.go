@@ -0,0 +1,209 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// Redacted replaces secrets in the SDK logs.
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are the attribute and header names whose values are never
+// logged, compared in lower case.
+var sensitiveKeys = map[string]bool{
+	"authorization": true,
+	"cookie":        true,
+	"set-cookie":    true,
+	"token":         true,
+	"jwt":           true,
+	"access_token":  true,
+	"refresh_token": true,
+	"api_key":       true,
+	"apikey":        true,
+	"signature":     true,
+	"private_key":   true,
+	"privatekey":    true,
+	"password":      true,
+}
+
+// redactingHandler hides secrets from the records it passes to the next
+// handler: values of sensitive keys and anything shaped like a JWT.
+type redactingHandler struct {
+	next slog.Handler
+}
+
+func newRedactingHandler(next slog.Handler) slog.Handler {
+	if _, ok := next.(*redactingHandler); ok {
+		return next
+	}
+	return &redactingHandler{next: next}
+}
+
+func (h *redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *redactingHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, redactString(record.Message), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(attr))
+		return true
+	})
+	return h.next.Handle(ctx, redacted)
+}
+
+func (h *redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		redacted[i] = redactAttr(attr)
+	}
+	return &redactingHandler{next: h.next.WithAttrs(redacted)}
+}
+
+func (h *redactingHandler) WithGroup(name string) slog.Handler {
+	return &redactingHandler{next: h.next.WithGroup(name)}
+}
+
+func redactAttr(attr slog.Attr) slog.Attr {
+	if sensitiveKeys[strings.ToLower(attr.Key)] {
+		return slog.String(attr.Key, Redacted)
+	}
+
+	value := attr.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindGroup:
+		group := value.Group()
+		redacted := make([]any, len(group))
+		for i, member := range group {
+			redacted[i] = redactAttr(member)
+		}
+		return slog.Group(attr.Key, redacted...)
+	case slog.KindString:
+		return slog.String(attr.Key, redactString(value.String()))
+	case slog.KindAny:
+		switch v := value.Any().(type) {
+		case http.Header:
+			return slog.Any(attr.Key, redactHeader(v))
+		case error:
+			return slog.String(attr.Key, redactString(v.Error()))
+		}
+	}
+
+	return slog.Attr{Key: attr.Key, Value: value}
+}
+
+func redactHeader(header http.Header) http.Header {
+	redacted := make(http.Header, len(header))
+	for key, values := range header {
+		if sensitiveKeys[strings.ToLower(key)] {
+			redacted[key] = []string{Redacted}
+			continue
+		}
+		redacted[key] = values
+	}
+	return redacted
+}
+
+// redactString replaces the JWTs in s, spotted by their base64url encoded
+// JSON header.
+func redactString(s string) string {
+	if !strings.Contains(s, "eyJ") {
+		return s
+	}
+
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '"' || r == '\'' || r == ',' || r == ';' || r == '='
+	})
+	for _, field := range fields {
+		if looksLikeJWT(field) {
+			s = strings.ReplaceAll(s, field, Redacted)
+		}
+	}
+	return s
+}
+
+func looksLikeJWT(s string) bool {
+	s = strings.TrimPrefix(s, "Bearer ")
+	if !strings.HasPrefix(s, "eyJ") {
+		return false
+	}
+	return strings.Count(s, ".") == 2
+}
+
+// discardHandler drops every record.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+var discardLogger = slog.New(discardHandler{})
+
+// logger returns the Logger of config, or one that drops everything.
+func (config Config) logger() *slog.Logger {
+	if config.Logger == nil {
+		return discardLogger
+	}
+	return config.Logger
+}
+
+// newSDKLogger returns logger with secrets redacted, or nil when logger is
+// nil.
+func newSDKLogger(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return nil
+	}
+	return slog.New(newRedactingHandler(logger.Handler()))
+}
+
+// loggingHooks logs every request at debug level and the failures and
+// retries as warnings.
+func loggingHooks(logger *slog.Logger) []Hook {
+	return []Hook{
+		BeforeRequest(func(ctx context.Context, req *HookRequest) error {
+			if req.Attempt > 1 {
+				logger.WarnContext(ctx, "retrying gateway request",
+					"operation", req.Operation, "method", req.Method, "route", req.Route, "attempt", req.Attempt)
+				return nil
+			}
+			logger.DebugContext(ctx, "gateway request",
+				"operation", req.Operation, "method", req.Method, "route", req.Route, "header", req.Header)
+			return nil
+		}),
+		AfterResponse(func(ctx context.Context, req *HookRequest, res *HookResponse) {
+			logger.DebugContext(ctx, "gateway response",
+				"operation", req.Operation, "route", req.Route, "status", res.StatusCode, "duration", res.Duration, "header", res.Header)
+		}),
+		OnError(func(ctx context.Context, req *HookRequest, err error) {
+			logger.WarnContext(ctx, "gateway request failed",
+				"operation", req.Operation, "method", req.Method, "route", req.Route, "error", err)
+		}),
+	}
+}
+
+// LogValue keeps the private key out of logs.
+func (d WalletDetails) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("wallet_type", string(d.WalletType)),
+		slog.String("private_key", Redacted),
+	)
+}
+
+// LogValue keeps the API key and private key out of logs.
+func (config SDKConfig) LogValue() slog.Value {
+	apiKey := ""
+	if config.ApiKey != "" {
+		apiKey = Redacted
+	}
+	return slog.GroupValue(
+		slog.String("url", config.URL),
+		slog.Any("urls", config.URLs),
+		slog.String("environment", string(config.Environment)),
+		slog.String("api_key", apiKey),
+		slog.Any("wallet", config.WalletDetails),
+		slog.Duration("timeout", config.Timeout),
+	)
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Metrics receives measurements of the SDK traffic and authentication.
+// Implementations must be safe for concurrent use; gatewayprom provides a
+// Prometheus collector.
+type Metrics interface {
+	// ObserveRequest reports a finished request of operation, for example
+	// "DataAsset.Share". status is 0 when no response was received; err is
+	// set when the request failed, including error statuses.
+	ObserveRequest(operation string, status int, duration time.Duration, err error)
+	// JWTIssued reports that a wallet signed in to obtain a new token.
+	JWTIssued()
+	// TokenRefreshed reports that a token was exchanged for a new one.
+	TokenRefreshed()
+	// SignatureFailed reports that a login message could not be signed or
+	// its signature did not verify.
+	SignatureFailed()
+	// BytesUploaded reports file content sent by operation.
+	BytesUploaded(operation string, n int64)
+	// BytesDownloaded reports file content received by operation.
+	BytesDownloaded(operation string, n int64)
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) ObserveRequest(string, int, time.Duration, error) {}
+func (nopMetrics) JWTIssued()                                       {}
+func (nopMetrics) TokenRefreshed()                                  {}
+func (nopMetrics) SignatureFailed()                                 {}
+func (nopMetrics) BytesUploaded(string, int64)                      {}
+func (nopMetrics) BytesDownloaded(string, int64)                    {}
+
+// metrics returns the Metrics of config, or one that drops everything.
+func (config Config) metrics() Metrics {
+	if config.Metrics == nil {
+		return nopMetrics{}
+	}
+	return config.Metrics
+}
+
+// metricsHooks reports every request to metrics once, when it finished.
+func metricsHooks(metrics Metrics) []Hook {
+	return []Hook{
+		AfterResponse(func(ctx context.Context, req *HookRequest, res *HookResponse) {
+			if res.StatusCode < http.StatusBadRequest {
+				metrics.ObserveRequest(req.Operation, res.StatusCode, res.Duration, nil)
+			}
+		}),
+		OnError(func(ctx context.Context, req *HookRequest, err error) {
+			var status int
+			var apiErr *APIError
+			if errors.As(err, &apiErr) {
+				status = apiErr.StatusCode
+			}
+			metrics.ObserveRequest(req.Operation, status, time.Since(req.Start), err)
+		}),
+	}
+}
+
+// countingReader reports the bytes read from a download to metrics once,
+// when it is closed.
+type countingReader struct {
+	io.ReadCloser
+	operation string
+	metrics   Metrics
+	n         int64
+	closed    bool
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+func (r *countingReader) Close() error {
+	if !r.closed {
+		r.closed = true
+		r.metrics.BytesDownloaded(r.operation, r.n)
+	}
+	return r.ReadCloser.Close()
+}
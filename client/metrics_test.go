@@ -0,0 +1,196 @@
+package client_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/Gateway-DAO/gateway-go-sdk/gatewaytest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type observedRequest struct {
+	operation string
+	status    int
+	failed    bool
+}
+
+type recordingMetrics struct {
+	mu                sync.Mutex
+	requests          []observedRequest
+	jwtIssued         int
+	tokenRefreshes    int
+	signatureFailures int
+	uploaded          map[string]int64
+	downloaded        map[string]int64
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{uploaded: map[string]int64{}, downloaded: map[string]int64{}}
+}
+
+func (m *recordingMetrics) ObserveRequest(operation string, status int, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, observedRequest{operation: operation, status: status, failed: err != nil})
+}
+
+func (m *recordingMetrics) JWTIssued() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jwtIssued++
+}
+
+func (m *recordingMetrics) TokenRefreshed() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokenRefreshes++
+}
+
+func (m *recordingMetrics) SignatureFailed() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.signatureFailures++
+}
+
+func (m *recordingMetrics) BytesUploaded(operation string, n int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.uploaded[operation] += n
+}
+
+func (m *recordingMetrics) BytesDownloaded(operation string, n int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.downloaded[operation] += n
+}
+
+func TestMetrics_WalletLoginAndRequests(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	wallet := gateway.WalletDetails{PrivateKey: testEthereumKey, WalletType: gateway.Ethereum}
+	_, err := srv.RegisterWallet("alice", wallet)
+	require.NoError(t, err)
+
+	metrics := newRecordingMetrics()
+	sdk, err := gateway.New(
+		gateway.WithWallet(wallet.PrivateKey, wallet.WalletType),
+		gateway.WithBaseURL(srv.URL),
+		gateway.WithMetrics(metrics),
+	)
+	require.NoError(t, err)
+
+	_, err = sdk.Account.GetMe()
+	require.NoError(t, err)
+	_, err = sdk.DataAssets.Get(404)
+	assert.ErrorIs(t, err, gateway.ErrNotFound)
+
+	assert.Equal(t, []observedRequest{
+		{operation: "Auth.GetMessage", status: 200},
+		{operation: "Auth.Login", status: 200},
+		{operation: "Accounts.GetMe", status: 200},
+		{operation: "DataAsset.Get", status: 404, failed: true},
+	}, metrics.requests)
+	assert.Equal(t, 1, metrics.jwtIssued)
+	assert.Zero(t, metrics.signatureFailures)
+}
+
+func TestMetrics_SignatureFailure(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	metrics := newRecordingMetrics()
+	sdk, err := gateway.New(
+		gateway.WithAPIKey("test-key"),
+		gateway.WithBaseURL(srv.URL),
+		gateway.WithMetrics(metrics),
+	)
+	require.NoError(t, err)
+
+	signer, err := gateway.NewWalletService(testEthereumKey, gateway.Ethereum)
+	require.NoError(t, err)
+	signature, err := signer.SignMessage("signed message")
+	require.NoError(t, err)
+
+	_, err = sdk.Auth.Login("another message", signature.Signature, signature.SigningKey)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, metrics.signatureFailures)
+	assert.Empty(t, metrics.requests)
+}
+
+func TestMetrics_TokenRefreshed(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	did, err := srv.CreateAccount("alice", "0x26bDbA4D3B8a2A4B8e2D2a0d8E5e3d5D6e2A8B4f")
+	require.NoError(t, err)
+	token, err := srv.IssueToken(did)
+	require.NoError(t, err)
+
+	metrics := newRecordingMetrics()
+	sdk, err := gateway.New(
+		gateway.WithAPIKey(token),
+		gateway.WithBaseURL(srv.URL),
+		gateway.WithMetrics(metrics),
+	)
+	require.NoError(t, err)
+
+	_, err = sdk.Auth.GetRefreshToken()
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, metrics.tokenRefreshes)
+	assert.Equal(t, []observedRequest{{operation: "Auth.GetRefreshToken", status: 200}}, metrics.requests)
+}
+
+func TestMetrics_TransferredBytes(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	did, err := srv.CreateAccount("alice", "0x26bDbA4D3B8a2A4B8e2D2a0d8E5e3d5D6e2A8B4f")
+	require.NoError(t, err)
+	token, err := srv.IssueToken(did)
+	require.NoError(t, err)
+
+	metrics := newRecordingMetrics()
+	sdk, err := gateway.New(
+		gateway.WithAPIKey(token),
+		gateway.WithBaseURL(srv.URL),
+		gateway.WithMetrics(metrics),
+	)
+	require.NoError(t, err)
+
+	created, err := sdk.DataAssets.UploadFile("notes.txt", []byte("hello gateway"), nil, nil)
+	require.NoError(t, err)
+	streamed, err := sdk.DataAssets.UploadFileReader("stream.txt", strings.NewReader("streamed content"), gateway.UploadOptions{})
+	require.NoError(t, err)
+
+	_, err = sdk.DataAssets.Download(int64(created.Id))
+	require.NoError(t, err)
+	_, err = sdk.DataAssets.DownloadTo(int64(streamed.Id), &bytes.Buffer{})
+	require.NoError(t, err)
+
+	body, _, err := sdk.DataAssets.DownloadStream(int64(created.Id))
+	require.NoError(t, err)
+	_, err = io.Copy(io.Discard, body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	require.NoError(t, body.Close())
+
+	assert.Equal(t, map[string]int64{
+		"DataAsset.UploadFile":       13,
+		"DataAsset.UploadFileReader": 16,
+	}, metrics.uploaded)
+	assert.Equal(t, map[string]int64{
+		"DataAsset.Download":       13,
+		"DataAsset.DownloadTo":     16,
+		"DataAsset.DownloadStream": 13,
+	}, metrics.downloaded)
+}
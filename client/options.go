@@ -0,0 +1,354 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option configures the SDK built by New.
+type Option func(config *SDKConfig) error
+
+// New builds an SDK from opts. Either WithAPIKey or WithWallet is required;
+// keys and settings are checked up front and reported as errors.
+func New(opts ...Option) (*SDK, error) {
+	var config SDKConfig
+	for _, opt := range opts {
+		if err := opt(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.ApiKey != "" && config.WalletDetails.PrivateKey != "" {
+		return nil, errors.New("WithAPIKey and WithWallet are mutually exclusive")
+	}
+
+	return newSDK(config)
+}
+
+// WithAPIKey authenticates every request with apiKey.
+func WithAPIKey(apiKey string) Option {
+	return func(config *SDKConfig) error {
+		if apiKey == "" {
+			return errors.New("WithAPIKey: empty API key")
+		}
+		config.ApiKey = apiKey
+		return nil
+	}
+}
+
+// WithWallet signs in with the wallet of privateKey and walletType, issuing
+// new tokens as they expire.
+func WithWallet(privateKey string, walletType WalletTypeEnum) Option {
+	return func(config *SDKConfig) error {
+		if privateKey == "" {
+			return errors.New("WithWallet: empty private key")
+		}
+		config.WalletDetails = WalletDetails{PrivateKey: privateKey, WalletType: walletType}
+		return nil
+	}
+}
+
+// WithBaseURL points the SDK at another Gateway API than DefaultBaseURL.
+func WithBaseURL(baseURL string) Option {
+	return func(config *SDKConfig) error {
+		config.URL = baseURL
+		return nil
+	}
+}
+
+// WithBaseURLs sends requests to the first healthy URL of baseURLs, failing
+// over to the next ones on connection errors and 5xx responses.
+func WithBaseURLs(baseURLs ...string) Option {
+	return func(config *SDKConfig) error {
+		if len(baseURLs) == 0 {
+			return errors.New("WithBaseURLs: no base URLs")
+		}
+		config.URLs = baseURLs
+		return nil
+	}
+}
+
+// WithEnvironment points the SDK at the Gateway API of env.
+func WithEnvironment(env Environment) Option {
+	return func(config *SDKConfig) error {
+		if env.BaseURL() == "" {
+			return fmt.Errorf("WithEnvironment: unknown environment %q", env)
+		}
+		config.Environment = env
+		return nil
+	}
+}
+
+// WithFailover tunes health probing and failover between the URLs given to
+// WithBaseURLs.
+func WithFailover(failover FailoverConfig) Option {
+	return func(config *SDKConfig) error {
+		config.Failover = &failover
+		return nil
+	}
+}
+
+// WithHTTPClient sends requests through client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(config *SDKConfig) error {
+		if client == nil {
+			return errors.New("WithHTTPClient: nil client")
+		}
+		config.HTTPClient = client
+		return nil
+	}
+}
+
+// WithTimeout bounds every request, including reading the response.
+func WithTimeout(timeout time.Duration) Option {
+	return func(config *SDKConfig) error {
+		if timeout <= 0 {
+			return errors.New("WithTimeout: timeout must be positive")
+		}
+		config.Timeout = timeout
+		return nil
+	}
+}
+
+// WithTokenLeeway renews wallet tokens leeway before they expire.
+func WithTokenLeeway(leeway time.Duration) Option {
+	return func(config *SDKConfig) error {
+		config.TokenLeeway = leeway
+		return nil
+	}
+}
+
+// WithSession enables background token renewal; start it with
+// SDK.Session.Start.
+func WithSession(session SessionConfig) Option {
+	return func(config *SDKConfig) error {
+		config.Session = &session
+		return nil
+	}
+}
+
+// WithRetry retries transient failures according to policy.
+func WithRetry(policy RetryPolicy) Option {
+	return func(config *SDKConfig) error {
+		config.Retry = &policy
+		return nil
+	}
+}
+
+// WithHooks runs hooks around every request, in order.
+func WithHooks(hooks ...Hook) Option {
+	return func(config *SDKConfig) error {
+		config.Hooks = append(config.Hooks, hooks...)
+		return nil
+	}
+}
+
+// WithTracerProvider creates a span per SDK operation with provider and
+// propagates it to the API.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(config *SDKConfig) error {
+		if provider == nil {
+			return errors.New("WithTracerProvider: nil tracer provider")
+		}
+		config.TracerProvider = provider
+		return nil
+	}
+}
+
+// WithPropagator sends the trace context to the API with propagator instead
+// of W3C traceparent headers.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(config *SDKConfig) error {
+		config.Propagator = propagator
+		return nil
+	}
+}
+
+// WithMetrics reports request, authentication and transfer measurements to
+// metrics.
+func WithMetrics(metrics Metrics) Option {
+	return func(config *SDKConfig) error {
+		if metrics == nil {
+			return errors.New("WithMetrics: nil metrics")
+		}
+		config.Metrics = metrics
+		return nil
+	}
+}
+
+// WithLogger logs requests at debug level, auth lifecycle events and
+// warnings to logger. Authorization headers, tokens, signatures and keys
+// are redacted.
+func WithLogger(logger *slog.Logger) Option {
+	return func(config *SDKConfig) error {
+		if logger == nil {
+			return errors.New("WithLogger: nil logger")
+		}
+		config.Logger = logger
+		return nil
+	}
+}
+
+// WithRateLimit holds requests back to stay within config. The budget is
+// shared by every goroutine using the SDK.
+func WithRateLimit(config RateLimitConfig) Option {
+	return func(sdkConfig *SDKConfig) error {
+		if err := config.validate(); err != nil {
+			return fmt.Errorf("WithRateLimit: %w", err)
+		}
+		sdkConfig.RateLimiter = NewRateLimiter(config)
+		return nil
+	}
+}
+
+// WithRateLimiter shares limiter, and so its budget, with other SDKs.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(config *SDKConfig) error {
+		if limiter == nil {
+			return errors.New("WithRateLimiter: nil rate limiter")
+		}
+		config.RateLimiter = limiter
+		return nil
+	}
+}
+
+// WithCircuitBreaker fails requests fast with ErrCircuitOpen once the API
+// keeps failing, until trial requests show it has recovered.
+func WithCircuitBreaker(config CircuitBreakerConfig) Option {
+	return func(sdkConfig *SDKConfig) error {
+		sdkConfig.CircuitBreaker = NewCircuitBreaker(config)
+		return nil
+	}
+}
+
+// WithSharedCircuitBreaker shares breaker, and so its state, with other
+// SDKs.
+func WithSharedCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(config *SDKConfig) error {
+		if breaker == nil {
+			return errors.New("WithSharedCircuitBreaker: nil circuit breaker")
+		}
+		config.CircuitBreaker = breaker
+		return nil
+	}
+}
+
+// WithVerifier checks the login signatures of chain with verifier, in
+// addition to the verifiers of DefaultVerifierRegistry.
+func WithVerifier(chain WalletTypeEnum, verifier Verifier) Option {
+	return func(config *SDKConfig) error {
+		if verifier == nil {
+			return errors.New("WithVerifier: nil verifier")
+		}
+		if config.Verifiers == nil {
+			config.Verifiers = DefaultVerifierRegistry()
+		}
+		config.Verifiers.Register(chain, verifier)
+		return nil
+	}
+}
+
+// WithVerifiers checks login signatures with the verifiers of registry
+// only.
+func WithVerifiers(registry *VerifierRegistry) Option {
+	return func(config *SDKConfig) error {
+		if registry == nil {
+			return errors.New("WithVerifiers: nil registry")
+		}
+		config.Verifiers = registry
+		return nil
+	}
+}
+
+// WithStrictVerification rejects logins from wallets no verifier handles.
+func WithStrictVerification() Option {
+	return func(config *SDKConfig) error {
+		config.StrictVerification = true
+		return nil
+	}
+}
+
+// WithRoundTripper sends requests through roundTripper, which can be shared
+// across SDK instances. It cannot be combined with the transport settings
+// below; tune the RoundTripper directly instead.
+func WithRoundTripper(roundTripper http.RoundTripper) Option {
+	return func(config *SDKConfig) error {
+		if roundTripper == nil {
+			return errors.New("WithRoundTripper: nil RoundTripper")
+		}
+		config.RoundTripper = roundTripper
+		return nil
+	}
+}
+
+// WithTransportConfig tunes the proxy, TLS and connection pool of the
+// transport, replacing the settings of earlier transport options.
+func WithTransportConfig(transport TransportConfig) Option {
+	return func(config *SDKConfig) error {
+		transport.ClientCertificates = append([]tls.Certificate(nil), transport.ClientCertificates...)
+		config.Transport = &transport
+		return nil
+	}
+}
+
+// WithProxy routes every request, including sign-in, through proxyURL.
+func WithProxy(proxyURL string) Option {
+	return func(config *SDKConfig) error {
+		if proxyURL == "" {
+			return errors.New("WithProxy: empty proxy URL")
+		}
+		transportConfig(config).Proxy = proxyURL
+		return nil
+	}
+}
+
+// WithTLSConfig uses tlsConfig as the base TLS configuration.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(config *SDKConfig) error {
+		if tlsConfig == nil {
+			return errors.New("WithTLSConfig: nil TLS config")
+		}
+		transportConfig(config).TLSConfig = tlsConfig
+		return nil
+	}
+}
+
+// WithClientCertificate presents cert to servers asking for mutual TLS; see
+// LoadClientCertificate.
+func WithClientCertificate(cert tls.Certificate) Option {
+	return func(config *SDKConfig) error {
+		if len(cert.Certificate) == 0 {
+			return errors.New("WithClientCertificate: empty certificate")
+		}
+		transport := transportConfig(config)
+		transport.ClientCertificates = append(transport.ClientCertificates, cert)
+		return nil
+	}
+}
+
+// WithRootCAs verifies servers against pool instead of the system roots;
+// see LoadRootCAs.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(config *SDKConfig) error {
+		if pool == nil {
+			return errors.New("WithRootCAs: nil pool")
+		}
+		transportConfig(config).RootCAs = pool
+		return nil
+	}
+}
+
+func transportConfig(config *SDKConfig) *TransportConfig {
+	if config.Transport == nil {
+		config.Transport = &TransportConfig{}
+	}
+	return config.Transport
+}
@@ -0,0 +1,133 @@
+package client_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testEthereumKey = "edb0ba5a63c5f9e4f4394560907794fca750704b355413bc04baab896254036a"
+	testSolanaKey   = "T8HMDTLmyQgY6VjvLdEwSSZsexAtiFvfiKBzEsT3ajNQg7jJgnTBK2qDSShz98ND3ihtrwrQcUWokdQr4ozPQt3"
+	testSuiKey      = "suiprivkey1qqqsyqcyq5rqwzqfpg9scrgwpugpzysnzs23v9ccrydpk8qarc0jqa4ffsr"
+)
+
+func TestNew_WithAPIKey(t *testing.T) {
+	sdk, err := gateway.New(gateway.WithAPIKey("test-api-key"), gateway.WithBaseURL("https://example.com"))
+	require.NoError(t, err)
+
+	client := sdk.Account.(*gateway.AccountsImpl).Config.Client
+	assert.Equal(t, "https://example.com", client.BaseURL)
+	assert.Equal(t, "test-api-key", client.Token)
+}
+
+func TestNew_WithWallet(t *testing.T) {
+	for name, wallet := range map[string]struct {
+		key        string
+		walletType gateway.WalletTypeEnum
+	}{
+		"ethereum": {testEthereumKey, gateway.Ethereum},
+		"solana":   {testSolanaKey, gateway.Solana},
+		"sui":      {testSuiKey, gateway.Sui},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sdk, err := gateway.New(gateway.WithWallet(wallet.key, wallet.walletType))
+			require.NoError(t, err)
+			assert.Equal(t, gateway.DefaultBaseURL, sdk.Account.(*gateway.AccountsImpl).Config.Client.BaseURL)
+		})
+	}
+}
+
+func TestNew_InvalidConfiguration(t *testing.T) {
+	tests := map[string]struct {
+		opts []gateway.Option
+		err  string
+	}{
+		"no credentials": {
+			opts: nil,
+			err:  "an API key or a wallet private key is required",
+		},
+		"both credentials": {
+			opts: []gateway.Option{gateway.WithAPIKey("key"), gateway.WithWallet(testEthereumKey, gateway.Ethereum)},
+			err:  "mutually exclusive",
+		},
+		"empty API key": {
+			opts: []gateway.Option{gateway.WithAPIKey("")},
+			err:  "empty API key",
+		},
+		"unsupported wallet type": {
+			opts: []gateway.Option{gateway.WithWallet(testEthereumKey, "bitcoin")},
+			err:  `invalid wallet: unsupported wallet type "bitcoin"`,
+		},
+		"bad ethereum key": {
+			opts: []gateway.Option{gateway.WithWallet("not-hex", gateway.Ethereum)},
+			err:  "invalid wallet: invalid ethereum private key",
+		},
+		"bad solana key": {
+			opts: []gateway.Option{gateway.WithWallet("invalid-private-key", gateway.Solana)},
+			err:  "invalid wallet: invalid solana private key",
+		},
+		"bad sui key": {
+			opts: []gateway.Option{gateway.WithWallet("suiprivkey1invalid", gateway.Sui)},
+			err:  "invalid wallet: invalid sui private key",
+		},
+		"relative base URL": {
+			opts: []gateway.Option{gateway.WithAPIKey("key"), gateway.WithBaseURL("api.gateway.tech")},
+			err:  "invalid base URL",
+		},
+		"nil HTTP client": {
+			opts: []gateway.Option{gateway.WithAPIKey("key"), gateway.WithHTTPClient(nil)},
+			err:  "nil client",
+		},
+		"zero timeout": {
+			opts: []gateway.Option{gateway.WithAPIKey("key"), gateway.WithTimeout(0)},
+			err:  "timeout must be positive",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var sdk *gateway.SDK
+			var err error
+			require.NotPanics(t, func() { sdk, err = gateway.New(test.opts...) })
+			assert.Nil(t, sdk)
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestNewSDK_PanicsOnInvalidConfig(t *testing.T) {
+	assert.PanicsWithError(t, `invalid wallet: unsupported wallet type ""`, func() {
+		gateway.NewSDK(gateway.SDKConfig{WalletDetails: gateway.WalletDetails{PrivateKey: testEthereumKey}})
+	})
+}
+
+func TestNew_WithHTTPClientAndTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message":"late"}`))
+	}))
+	defer srv.Close()
+
+	httpClient := &http.Client{}
+	sdk, err := gateway.New(
+		gateway.WithAPIKey("test-api-key"),
+		gateway.WithBaseURL(srv.URL),
+		gateway.WithHTTPClient(httpClient),
+		gateway.WithTimeout(50*time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	_, err = sdk.Auth.GetMessage()
+	assert.ErrorContains(t, err, "Client.Timeout")
+
+	assert.Nil(t, httpClient.Transport, "the caller's client is left untouched")
+	assert.Zero(t, httpClient.Timeout)
+}
@@ -0,0 +1,241 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"sync"
+)
+
+// PageFetcher loads one page of a paginated list endpoint.
+type PageFetcher[T any] func(ctx context.Context, page int, pageSize int) (HelperPaginatedResponse[[]T], error)
+
+// PageIterator walks every item of a paginated list endpoint, loading pages
+// lazily as they are needed:
+//
+//	it := sdk.DataAsset.AllCreatedByMe(50)
+//	defer it.Close()
+//	for it.Next() {
+//		asset := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// Close must be called once the caller is done with the iterator, including
+// when it stops before the last item: it cancels the page being fetched and
+// releases the context of the iterator.
+//
+// A PageIterator is not safe for concurrent use.
+type PageIterator[T any] struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	fetch    PageFetcher[T]
+	pageSize int
+	prefetch int
+
+	started  bool
+	done     bool
+	closed   bool
+	nextPage int
+	items    []T
+	item     T
+	err      error
+
+	// The prefetched pages are loaded by a goroutine that only runs while
+	// fewer than prefetch pages are waiting to be read, so an iterator that
+	// is dropped without Close never leaves it blocked.
+	mu        sync.Mutex
+	fetching  bool
+	fetchDone bool
+	fetched   []pageResult[T]
+	ready     chan struct{}
+}
+
+type pageResult[T any] struct {
+	items []T
+	err   error
+}
+
+// NewPageIterator returns an iterator over the pages returned by fetch,
+// starting at page 1.
+func NewPageIterator[T any](ctx context.Context, pageSize int, fetch PageFetcher[T]) *PageIterator[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	return &PageIterator[T]{
+		ctx:      ctx,
+		cancel:   cancel,
+		fetch:    fetch,
+		pageSize: pageSize,
+		nextPage: 1,
+	}
+}
+
+// WithPrefetch makes the iterator load up to pages pages ahead of the one
+// being read, in the background. It must be called before the first Next.
+func (it *PageIterator[T]) WithPrefetch(pages int) *PageIterator[T] {
+	if !it.started {
+		it.prefetch = pages
+		it.ready = make(chan struct{}, 1)
+	}
+	return it
+}
+
+// Next advances to the next item, loading the next page when the current
+// one is exhausted. It returns false when there are no more items, when a
+// page failed to load, or after Close.
+func (it *PageIterator[T]) Next() bool {
+	it.started = true
+
+	for len(it.items) == 0 {
+		if it.done || it.closed {
+			return false
+		}
+
+		result := it.load()
+		if result.err != nil {
+			it.err = result.err
+			it.finish()
+			return false
+		}
+		it.items = result.items
+	}
+
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Item returns the item Next advanced to.
+func (it *PageIterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *PageIterator[T]) Err() error {
+	return it.err
+}
+
+// Close stops the iteration early and cancels any page being prefetched.
+func (it *PageIterator[T]) Close() {
+	it.closed = true
+	it.items = nil
+	it.cancel()
+}
+
+func (it *PageIterator[T]) finish() {
+	it.done = true
+	it.cancel()
+}
+
+// load returns the next page, either from the prefetched pages or by
+// fetching it directly.
+func (it *PageIterator[T]) load() pageResult[T] {
+	if it.prefetch > 0 {
+		return it.loadPrefetched()
+	}
+
+	result, next, more := it.fetchPage(it.nextPage)
+	it.nextPage = next
+	if !more && result.err == nil {
+		it.finish()
+	}
+	return result
+}
+
+// loadPrefetched waits for the next prefetched page and keeps the
+// prefetching going behind it.
+func (it *PageIterator[T]) loadPrefetched() pageResult[T] {
+	it.mu.Lock()
+	for len(it.fetched) == 0 {
+		if it.fetchDone {
+			it.mu.Unlock()
+			it.finish()
+			return pageResult[T]{}
+		}
+		it.startPrefetch()
+		it.mu.Unlock()
+
+		select {
+		case <-it.ready:
+		case <-it.ctx.Done():
+			it.finish()
+			return pageResult[T]{err: it.ctx.Err()}
+		}
+		it.mu.Lock()
+	}
+
+	result := it.fetched[0]
+	it.fetched = it.fetched[1:]
+	it.startPrefetch()
+	it.mu.Unlock()
+	return result
+}
+
+// startPrefetch starts the prefetching goroutine unless it is running, the
+// last page was fetched or enough pages are waiting. It must be called with
+// it.mu held.
+func (it *PageIterator[T]) startPrefetch() {
+	if it.fetching || it.fetchDone || len(it.fetched) >= it.prefetch {
+		return
+	}
+	it.fetching = true
+	go it.prefetchPages()
+}
+
+func (it *PageIterator[T]) prefetchPages() {
+	for {
+		it.mu.Lock()
+		if it.fetchDone || len(it.fetched) >= it.prefetch {
+			it.fetching = false
+			it.mu.Unlock()
+			return
+		}
+		page := it.nextPage
+		it.mu.Unlock()
+
+		result, next, more := it.fetchPage(page)
+
+		it.mu.Lock()
+		it.fetched = append(it.fetched, result)
+		it.nextPage = next
+		it.fetchDone = result.err != nil || !more
+		it.mu.Unlock()
+
+		select {
+		case it.ready <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (it *PageIterator[T]) fetchPage(page int) (pageResult[T], int, bool) {
+	res, err := it.fetch(it.ctx, page, it.pageSize)
+	if err != nil {
+		return pageResult[T]{err: err}, page, false
+	}
+
+	next, more := nextPageNumber(res, page, it.pageSize)
+	return pageResult[T]{items: res.Data}, next, more
+}
+
+// nextPageNumber works out which page follows page. HelperLinks.Next is
+// preferred when the server sends it, then HelperMeta.TotalPages, and a short
+// or empty page ends the list when neither is available.
+func nextPageNumber[T any](res HelperPaginatedResponse[[]T], page int, pageSize int) (int, bool) {
+	if len(res.Data) == 0 {
+		return page, false
+	}
+
+	if res.Links.Next != "" {
+		if next, err := url.Parse(res.Links.Next); err == nil {
+			if number, err := strconv.Atoi(next.Query().Get("page")); err == nil {
+				return number, number > page
+			}
+		}
+	}
+
+	if res.Meta.TotalPages > 0 {
+		return page + 1, page < res.Meta.TotalPages
+	}
+
+	return page + 1, len(res.Data) >= pageSize
+}
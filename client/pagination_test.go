@@ -0,0 +1,156 @@
+package client_test
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// registerCreatedPages serves totalItems assets from /data-assets/created,
+// linking each page to the next one when withLinks is set.
+func registerCreatedPages(totalItems int, withLinks bool) {
+	httpmock.RegisterResponder("GET", gateway.GetCreatedDataAssets, func(req *http.Request) (*http.Response, error) {
+		page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+		pageSize, _ := strconv.Atoi(req.URL.Query().Get("page_size"))
+		totalPages := (totalItems + pageSize - 1) / pageSize
+
+		res := gateway.HelperPaginatedResponse[[]gateway.PublicDataAsset]{
+			Data: []gateway.PublicDataAsset{},
+			Meta: gateway.HelperMeta{CurrentPage: page, ItemsPerPage: pageSize, TotalItems: totalItems, TotalPages: totalPages},
+		}
+		for id := (page-1)*pageSize + 1; id <= page*pageSize && id <= totalItems; id++ {
+			res.Data = append(res.Data, gateway.PublicDataAsset{Id: id})
+		}
+		if withLinks && page < totalPages {
+			res.Links.Next = fmt.Sprintf("%s?page=%d&page_size=%d", gateway.GetCreatedDataAssets, page+1, pageSize)
+		}
+
+		return httpmock.NewJsonResponse(200, res)
+	})
+}
+
+func newTestPaginationImpl(t *testing.T) *gateway.DataAssetImpl {
+	client := resty.New()
+	httpmock.ActivateNonDefault(client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+
+	return gateway.NewDataAssetImpl(gateway.Config{Client: client})
+}
+
+func collectIDs(it *gateway.PageIterator[gateway.PublicDataAsset]) []int {
+	var ids []int
+	for it.Next() {
+		ids = append(ids, it.Item().Id)
+	}
+	return ids
+}
+
+func TestAllCreatedByMe(t *testing.T) {
+	dataAssetImpl := newTestPaginationImpl(t)
+	registerCreatedPages(5, false)
+
+	it := dataAssetImpl.AllCreatedByMe(2)
+	defer it.Close()
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, collectIDs(it))
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+}
+
+func TestAllCreatedByMe_FollowsNextLink(t *testing.T) {
+	dataAssetImpl := newTestPaginationImpl(t)
+
+	httpmock.RegisterResponderWithQuery("GET", gateway.GetCreatedDataAssets, "page=1&page_size=2",
+		httpmock.NewJsonResponderOrPanic(200, gateway.HelperPaginatedResponse[[]gateway.PublicDataAsset]{
+			Data:  []gateway.PublicDataAsset{{Id: 1}, {Id: 2}},
+			Links: gateway.HelperLinks{Next: "https://example.com/data-assets/created?page=4&page_size=2"},
+		}))
+	httpmock.RegisterResponderWithQuery("GET", gateway.GetCreatedDataAssets, "page=4&page_size=2",
+		httpmock.NewJsonResponderOrPanic(200, gateway.HelperPaginatedResponse[[]gateway.PublicDataAsset]{
+			Data: []gateway.PublicDataAsset{{Id: 7}},
+		}))
+
+	it := dataAssetImpl.AllCreatedByMe(2)
+	defer it.Close()
+
+	assert.Equal(t, []int{1, 2, 7}, collectIDs(it))
+	assert.NoError(t, it.Err())
+}
+
+func TestAllCreatedByMe_EarlyStop(t *testing.T) {
+	dataAssetImpl := newTestPaginationImpl(t)
+	registerCreatedPages(10, true)
+
+	it := dataAssetImpl.AllCreatedByMe(2)
+	assert.True(t, it.Next())
+	assert.Equal(t, 1, it.Item().Id)
+	it.Close()
+
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestAllCreatedByMe_Error(t *testing.T) {
+	dataAssetImpl := newTestPaginationImpl(t)
+
+	httpmock.RegisterResponderWithQuery("GET", gateway.GetCreatedDataAssets, "page=1&page_size=1",
+		httpmock.NewJsonResponderOrPanic(200, gateway.HelperPaginatedResponse[[]gateway.PublicDataAsset]{
+			Data: []gateway.PublicDataAsset{{Id: 1}},
+			Meta: gateway.HelperMeta{TotalPages: 2},
+		}))
+	httpmock.RegisterResponderWithQuery("GET", gateway.GetCreatedDataAssets, "page=2&page_size=1",
+		httpmock.NewJsonResponderOrPanic(500, gateway.Error{Error: "internal server error"}))
+
+	it := dataAssetImpl.AllCreatedByMe(1).WithPrefetch(2)
+	defer it.Close()
+
+	assert.Equal(t, []int{1}, collectIDs(it))
+	assert.ErrorIs(t, it.Err(), gateway.ErrServerError)
+}
+
+func TestAllCreatedByMe_Prefetch(t *testing.T) {
+	dataAssetImpl := newTestPaginationImpl(t)
+	registerCreatedPages(6, true)
+
+	it := dataAssetImpl.AllCreatedByMe(2).WithPrefetch(1)
+	defer it.Close()
+
+	assert.True(t, it.Next())
+	assert.Eventually(t, func() bool {
+		return httpmock.GetTotalCallCount() == 2
+	}, time.Second, 5*time.Millisecond)
+
+	assert.Equal(t, []int{2, 3, 4, 5, 6}, collectIDs(it))
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+}
+
+func TestAllCreatedByMe_PrefetchStopsWhenAbandoned(t *testing.T) {
+	dataAssetImpl := newTestPaginationImpl(t)
+	registerCreatedPages(20, true)
+
+	goroutines := runtime.NumGoroutine()
+
+	it := dataAssetImpl.AllCreatedByMe(2).WithPrefetch(2)
+	assert.True(t, it.Next())
+
+	// The iterator is dropped without Close: prefetching stops once two
+	// pages are waiting instead of blocking on them. The goroutines are
+	// counted here rather than in assert.Eventually, which adds its own.
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > goroutines && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), goroutines)
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+}
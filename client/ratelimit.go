@@ -0,0 +1,251 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"golang.org/x/time/rate"
+)
+
+// DefaultRateLimitPause is how long a RateLimiter holds requests back after
+// a 429 response that does not say when to try again.
+const DefaultRateLimitPause = time.Second
+
+// OperationClass groups the SDK operations that share a rate limit.
+type OperationClass string
+
+const (
+	ClassRead  OperationClass = "read"
+	ClassWrite OperationClass = "write"
+	ClassAuth  OperationClass = "auth"
+)
+
+// RateLimit is a token bucket: PerSecond requests per second on average,
+// with up to Burst at once. A zero PerSecond leaves requests unlimited.
+type RateLimit struct {
+	PerSecond float64
+	// Burst is at least 1.
+	Burst int
+}
+
+// RateLimitConfig limits the requests of an SDK as a whole and per
+// operation class. A request waits for both its class and the overall
+// budget.
+type RateLimitConfig struct {
+	Overall RateLimit
+	Reads   RateLimit
+	Writes  RateLimit
+	Auth    RateLimit
+}
+
+// RateLimiter holds requests back to stay within a RateLimitConfig and
+// pauses them when the server answers 429 or reports an exhausted budget.
+// It is safe for concurrent use and can be shared by several SDKs.
+type RateLimiter struct {
+	overall *rate.Limiter
+	classes map[OperationClass]*rate.Limiter
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+// NewRateLimiter returns a RateLimiter enforcing config.
+func NewRateLimiter(config RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		overall: config.Overall.limiter(),
+		classes: map[OperationClass]*rate.Limiter{
+			ClassRead:  config.Reads.limiter(),
+			ClassWrite: config.Writes.limiter(),
+			ClassAuth:  config.Auth.limiter(),
+		},
+	}
+}
+
+func (l RateLimit) limiter() *rate.Limiter {
+	if l.PerSecond <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(l.PerSecond), max(l.Burst, 1))
+}
+
+func (l RateLimit) validate(name string) error {
+	if l.PerSecond < 0 || l.Burst < 0 {
+		return fmt.Errorf("invalid %s rate limit: rate and burst must not be negative", name)
+	}
+	return nil
+}
+
+func (c RateLimitConfig) validate() error {
+	limits := []struct {
+		name  string
+		limit RateLimit
+	}{{"overall", c.Overall}, {"read", c.Reads}, {"write", c.Writes}, {"auth", c.Auth}}
+
+	for _, l := range limits {
+		if err := l.limit.validate(l.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Wait blocks until a request of class may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context, class OperationClass) error {
+	if err := l.waitPause(ctx); err != nil {
+		return err
+	}
+
+	if err := waitLimiter(ctx, l.classes[class]); err != nil {
+		return err
+	}
+	return waitLimiter(ctx, l.overall)
+}
+
+func waitLimiter(ctx context.Context, limiter *rate.Limiter) error {
+	if limiter == nil {
+		return nil
+	}
+
+	if err := limiter.Wait(ctx); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// rate fails right away when the wait would outlast the deadline.
+		return fmt.Errorf("rate limit wait would exceed the context deadline: %w", context.DeadlineExceeded)
+	}
+	return nil
+}
+
+// Pause holds every request back for d, on top of the configured limits.
+func (l *RateLimiter) Pause(d time.Duration) {
+	until := time.Now().Add(d)
+
+	l.mu.Lock()
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+	l.mu.Unlock()
+}
+
+func (l *RateLimiter) waitPause(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		wait := time.Until(l.pausedUntil)
+		l.mu.Unlock()
+
+		if wait <= 0 {
+			return ctx.Err()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// observe pauses the limiter when res shows that the server's budget is
+// used up: a 429 response, or rate-limit headers with nothing remaining.
+func (l *RateLimiter) observe(res *http.Response) {
+	now := time.Now()
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After"), now); ok {
+			l.Pause(wait)
+		} else if wait, ok := rateLimitReset(res.Header, now); ok {
+			l.Pause(wait)
+		} else {
+			l.Pause(DefaultRateLimitPause)
+		}
+		return
+	}
+
+	if rateLimitHeader(res.Header, "Remaining") == "0" {
+		if wait, ok := rateLimitReset(res.Header, now); ok {
+			l.Pause(wait)
+		}
+	}
+}
+
+// rateLimitHeader reads the RateLimit-<name> header, falling back to the
+// common X-RateLimit-<name>.
+func rateLimitHeader(header http.Header, name string) string {
+	if value := header.Get("RateLimit-" + name); value != "" {
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(header.Get("X-RateLimit-" + name))
+}
+
+// rateLimitReset reads when the rate-limit window resets, given either in
+// seconds from now or, for large values, as a Unix time.
+func rateLimitReset(header http.Header, now time.Time) (time.Duration, bool) {
+	seconds, err := strconv.ParseInt(rateLimitHeader(header, "Reset"), 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+
+	// Anything beyond a year from now is a Unix time rather than a delay.
+	if seconds > int64(365*24*time.Hour/time.Second) {
+		return max(time.Unix(seconds, 0).Sub(now), 0), true
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// operationClass sorts a request into its rate-limit class: auth for the
+// Auth operations, read for safe methods and write for everything else.
+func operationClass(operation string, method string) OperationClass {
+	switch {
+	case strings.HasPrefix(operation, "Auth."):
+		return ClassAuth
+	case method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions:
+		return ClassRead
+	}
+	return ClassWrite
+}
+
+// rateLimitTransport waits for the budget of every attempt before sending
+// it and adapts the limiter to the responses.
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *RateLimiter
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if err := t.limiter.Wait(ctx, operationClass(operationFrom(ctx), req.Method)); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err == nil {
+		t.limiter.observe(res)
+	}
+	return res, err
+}
+
+// installRateLimiter sends the requests of client through limiter. newSDK
+// installs it last, after failover, tracing and the circuit breaker, so it
+// is the outermost transport: a request waits for the limiter before the
+// breaker sees it, and the limiter observes the response failover settled
+// on.
+func installRateLimiter(client *resty.Client, limiter *RateLimiter) {
+	httpClient := client.GetClient()
+
+	next := httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	httpClient.Transport = &rateLimitTransport{next: next, limiter: limiter}
+}
@@ -0,0 +1,152 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newJSONServer(handler func(w http.ResponseWriter, r *http.Request)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if handler != nil {
+			handler(w, r)
+		}
+		w.Write([]byte(`{}`))
+	}))
+}
+
+func TestRateLimit_SharedAcrossGoroutinesAndSDKs(t *testing.T) {
+	server := newJSONServer(nil)
+	defer server.Close()
+
+	limiter := gateway.NewRateLimiter(gateway.RateLimitConfig{Overall: gateway.RateLimit{PerSecond: 50, Burst: 1}})
+
+	var sdks []*gateway.SDK
+	for i := 0; i < 2; i++ {
+		sdk, err := gateway.New(gateway.WithAPIKey("test-key"), gateway.WithBaseURL(server.URL), gateway.WithRateLimiter(limiter))
+		require.NoError(t, err)
+		sdks = append(sdks, sdk)
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(sdk *gateway.SDK) {
+			defer wg.Done()
+			_, err := sdk.Account.GetMe()
+			assert.NoError(t, err)
+		}(sdks[i%2])
+	}
+	wg.Wait()
+
+	// The first request uses the burst, the other nine wait 20ms each.
+	assert.GreaterOrEqual(t, time.Since(start), 170*time.Millisecond)
+}
+
+func TestRateLimit_PerClass(t *testing.T) {
+	server := newJSONServer(nil)
+	defer server.Close()
+
+	sdk, err := gateway.New(
+		gateway.WithAPIKey("test-key"),
+		gateway.WithBaseURL(server.URL),
+		gateway.WithRateLimit(gateway.RateLimitConfig{Writes: gateway.RateLimit{PerSecond: 1, Burst: 1}}),
+	)
+	require.NoError(t, err)
+
+	_, err = sdk.DataModel.Create(gateway.DataModelCreateRequest{})
+	require.NoError(t, err)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		_, err = sdk.Account.GetMe()
+		require.NoError(t, err)
+	}
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "reads are not limited")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = sdk.DataModel.CreateCtx(ctx, gateway.DataModelCreateRequest{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRateLimit_WaitStopsWithContext(t *testing.T) {
+	limiter := gateway.NewRateLimiter(gateway.RateLimitConfig{Auth: gateway.RateLimit{PerSecond: 0.1, Burst: 1}})
+	require.NoError(t, limiter.Wait(context.Background(), gateway.ClassAuth))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	assert.ErrorIs(t, limiter.Wait(ctx, gateway.ClassAuth), context.Canceled)
+	assert.NoError(t, limiter.Wait(context.Background(), gateway.ClassRead))
+}
+
+func TestRateLimit_PausesAfterTooManyRequests(t *testing.T) {
+	var calls int32
+	server := newJSONServer(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	})
+	defer server.Close()
+
+	sdk, err := gateway.New(
+		gateway.WithAPIKey("test-key"),
+		gateway.WithBaseURL(server.URL),
+		gateway.WithRateLimit(gateway.RateLimitConfig{}),
+	)
+	require.NoError(t, err)
+
+	_, err = sdk.Account.GetMe()
+	assert.ErrorIs(t, err, gateway.ErrRateLimited)
+
+	start := time.Now()
+	_, err = sdk.Account.GetMe()
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+}
+
+func TestRateLimit_PausesWhenBudgetIsExhausted(t *testing.T) {
+	var calls int32
+	server := newJSONServer(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "1")
+		}
+	})
+	defer server.Close()
+
+	limiter := gateway.NewRateLimiter(gateway.RateLimitConfig{})
+	sdk, err := gateway.New(gateway.WithAPIKey("test-key"), gateway.WithBaseURL(server.URL), gateway.WithRateLimiter(limiter))
+	require.NoError(t, err)
+
+	_, err = sdk.Account.GetMe()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx, gateway.ClassRead), context.DeadlineExceeded)
+}
+
+func TestWithRateLimit_Invalid(t *testing.T) {
+	_, err := gateway.New(
+		gateway.WithAPIKey("test-key"),
+		gateway.WithRateLimit(gateway.RateLimitConfig{Reads: gateway.RateLimit{PerSecond: -1}}),
+	)
+	assert.ErrorContains(t, err, "invalid read rate limit")
+
+	_, err = gateway.New(gateway.WithAPIKey("test-key"), gateway.WithRateLimiter(nil))
+	assert.Error(t, err)
+}
@@ -0,0 +1,226 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	DefaultRetryInitialBackoff = 250 * time.Millisecond
+	DefaultRetryMaxBackoff     = 10 * time.Second
+)
+
+// DefaultRetryableStatusCodes are the statuses retried when
+// RetryPolicy.RetryableStatusCodes is empty.
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy controls how requests that fail with a transient error are
+// retried. Connection failures and the statuses in RetryableStatusCodes are
+// retried; POST and PATCH requests are only retried when RetryNonIdempotent
+// is set or their context went through AllowRetry.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per request, including the
+	// first one. Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. It doubles on every
+	// further attempt, with random jitter.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts. A response whose
+	// Retry-After asks for a longer wait, or for one past the context
+	// deadline, is returned instead of retried.
+	MaxBackoff           time.Duration
+	RetryableStatusCodes []int
+	RetryNonIdempotent   bool
+}
+
+type allowRetryKey struct{}
+
+// AllowRetry marks the requests made with ctx as safe to retry even when
+// their method is not idempotent, such as an upload the caller knows it can
+// repeat.
+func AllowRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allowRetryKey{}, true)
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = DefaultRetryInitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = DefaultRetryMaxBackoff
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		p.MaxBackoff = p.InitialBackoff
+	}
+	if len(p.RetryableStatusCodes) == 0 {
+		p.RetryableStatusCodes = DefaultRetryableStatusCodes
+	}
+	return p
+}
+
+// configureRetry installs policy on client. A nil policy leaves retries off.
+func configureRetry(client *resty.Client, policy *RetryPolicy) {
+	if policy == nil || policy.MaxAttempts < 2 {
+		return
+	}
+	p := policy.withDefaults()
+
+	client.SetRetryCount(p.MaxAttempts - 1).
+		SetRetryWaitTime(p.InitialBackoff).
+		SetRetryMaxWaitTime(p.MaxBackoff).
+		SetRetryResetReaders(true).
+		SetRetryAfter(func(_ *resty.Client, res *resty.Response) (time.Duration, error) {
+			return p.delay(res), nil
+		}).
+		AddRetryCondition(p.shouldRetry).
+		AddRetryHook(p.prepareRetry)
+}
+
+// prepareRetry readies the request of res for another attempt. resty runs
+// retry hooks after the last attempt too, whose response goes back to the
+// caller and is left alone.
+func (p RetryPolicy) prepareRetry(res *resty.Response, _ error) {
+	if res == nil || res.Request == nil || res.Request.Attempt >= p.MaxAttempts {
+		return
+	}
+
+	// Responses read with SetDoNotParseResponse are left open by resty.
+	if res.RawResponse != nil {
+		res.RawResponse.Body.Close()
+	}
+
+	if stream, ok := sentUploadStream(res.Request); ok {
+		if err := stream.rewind(); err != nil {
+			stream.body = failedReader{err}
+		}
+	}
+}
+
+// shouldRetry reports whether the attempt that produced res and err should
+// be repeated.
+func (p RetryPolicy) shouldRetry(res *resty.Response, err error) bool {
+	// Without a response the request failed before it was sent, for example
+	// while issuing a token, and repeating it would not help.
+	if res == nil || res.Request == nil {
+		return false
+	}
+
+	ctx := res.Request.Context()
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		var urlError *url.Error
+		if !errors.As(err, &urlError) || errors.Is(err, ErrCircuitOpen) {
+			return false
+		}
+	} else if !p.retryableStatus(res.StatusCode()) {
+		return false
+	}
+
+	if !isIdempotent(res.Request.Method) && !p.RetryNonIdempotent {
+		if allowed, _ := ctx.Value(allowRetryKey{}).(bool); !allowed {
+			return false
+		}
+	}
+
+	if stream, ok := sentUploadStream(res.Request); ok && stream.rewind == nil {
+		return false
+	}
+
+	// Retrying sooner than the API asked would only be rejected again.
+	if wait, ok := retryAfter(res); ok {
+		if wait > p.MaxBackoff {
+			return false
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p RetryPolicy) retryableStatus(status int) bool {
+	for _, code := range p.RetryableStatusCodes {
+		if code == status {
+			return true
+		}
+	}
+	return false
+}
+
+// delay returns how long to wait before the next attempt: the Retry-After
+// of the response when present, which shouldRetry keeps within MaxBackoff,
+// and exponential backoff with jitter otherwise.
+func (p RetryPolicy) delay(res *resty.Response) time.Duration {
+	if wait, ok := retryAfter(res); ok {
+		return wait
+	}
+
+	attempt := 1
+	if res.Request != nil && res.Request.Attempt > 0 {
+		attempt = res.Request.Attempt
+	}
+
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, p.MaxBackoff)
+
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryAfter returns the wait a 429 or 503 response asks for in its
+// Retry-After header.
+func retryAfter(res *resty.Response) (time.Duration, bool) {
+	status := res.StatusCode()
+	if status != http.StatusTooManyRequests && status != http.StatusServiceUnavailable {
+		return 0, false
+	}
+	return parseRetryAfter(res.Header().Get("Retry-After"), time.Now())
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,225 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRetryServer fails the first failures requests with status and answers
+// the rest with a data asset. It records the uploaded file of every attempt.
+func newRetryServer(t *testing.T, failures int32, status int, header http.Header) (*httptest.Server, *int32, *[]string) {
+	var attempts int32
+	var uploads []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempt := atomic.AddInt32(&attempts, 1)
+
+		if file, _, err := r.FormFile("data"); err == nil {
+			content, _ := io.ReadAll(file)
+			uploads = append(uploads, string(content))
+		}
+
+		if attempt <= failures {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(gateway.Error{Error: http.StatusText(status)})
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(gateway.PublicDataAsset{Id: 1, Name: "asset"})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &attempts, &uploads
+}
+
+func newRetrySDK(url string, policy *gateway.RetryPolicy) *gateway.SDK {
+	return gateway.NewSDK(gateway.SDKConfig{ApiKey: "test-api-key", URL: url, Retry: policy})
+}
+
+func TestRetry_TransientStatus(t *testing.T) {
+	server, attempts, _ := newRetryServer(t, 2, http.StatusBadGateway, nil)
+	sdk := newRetrySDK(server.URL, &gateway.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	asset, err := sdk.DataAssets.Get(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "asset", asset.Name)
+	assert.Equal(t, int32(3), atomic.LoadInt32(attempts))
+}
+
+func TestRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	server, attempts, _ := newRetryServer(t, 5, http.StatusServiceUnavailable, nil)
+	sdk := newRetrySDK(server.URL, &gateway.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+
+	_, err := sdk.DataAssets.Get(1)
+
+	assert.ErrorIs(t, err, gateway.ErrServerError)
+	assert.Equal(t, int32(2), atomic.LoadInt32(attempts))
+}
+
+func TestRetry_Disabled(t *testing.T) {
+	server, attempts, _ := newRetryServer(t, 1, http.StatusBadGateway, nil)
+	sdk := newRetrySDK(server.URL, nil)
+
+	_, err := sdk.DataAssets.Get(1)
+
+	assert.ErrorIs(t, err, gateway.ErrServerError)
+	assert.Equal(t, int32(1), atomic.LoadInt32(attempts))
+}
+
+func TestRetry_NonRetryableStatus(t *testing.T) {
+	server, attempts, _ := newRetryServer(t, 1, http.StatusNotFound, nil)
+	sdk := newRetrySDK(server.URL, &gateway.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	_, err := sdk.DataAssets.Get(1)
+
+	assert.ErrorIs(t, err, gateway.ErrNotFound)
+	assert.Equal(t, int32(1), atomic.LoadInt32(attempts))
+}
+
+func TestRetry_HonorsRetryAfter(t *testing.T) {
+	server, attempts, _ := newRetryServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}})
+	sdk := newRetrySDK(server.URL, &gateway.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+
+	start := time.Now()
+	_, err := sdk.DataAssets.Get(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(attempts))
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestRetry_RetryAfterBeyondMaxBackoff(t *testing.T) {
+	server, attempts, _ := newRetryServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"120"}})
+	sdk := newRetrySDK(server.URL, &gateway.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Second})
+
+	start := time.Now()
+	_, err := sdk.DataAssets.Get(1)
+
+	assert.ErrorIs(t, err, gateway.ErrRateLimited)
+	assert.Equal(t, int32(1), atomic.LoadInt32(attempts))
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetry_RetryAfterBeyondDeadline(t *testing.T) {
+	server, attempts, _ := newRetryServer(t, 1, http.StatusServiceUnavailable, http.Header{"Retry-After": {"5"}})
+	sdk := newRetrySDK(server.URL, &gateway.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := sdk.DataAssets.GetCtx(ctx, 1)
+
+	assert.ErrorIs(t, err, gateway.ErrServerError)
+	assert.Equal(t, int32(1), atomic.LoadInt32(attempts))
+}
+
+func TestRetry_ConnectionReset(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(gateway.PublicDataAsset{Id: 1})
+	}))
+	defer server.Close()
+
+	sdk := newRetrySDK(server.URL, &gateway.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+
+	asset, err := sdk.DataAssets.Get(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, asset.Id)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestRetry_PostRequiresOptIn(t *testing.T) {
+	server, attempts, uploads := newRetryServer(t, 1, http.StatusServiceUnavailable, nil)
+	sdk := newRetrySDK(server.URL, &gateway.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	_, err := sdk.DataAssets.UploadFile("file.txt", []byte("content"), nil, nil)
+
+	assert.ErrorIs(t, err, gateway.ErrServerError)
+	assert.Equal(t, int32(1), atomic.LoadInt32(attempts))
+
+	_, err = sdk.DataAssets.UploadFileCtx(gateway.AllowRetry(context.Background()), "file.txt", []byte("content"), nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(attempts))
+	assert.Equal(t, []string{"content", "content"}, *uploads)
+}
+
+func TestRetry_ReplaysMultipartBody(t *testing.T) {
+	server, attempts, uploads := newRetryServer(t, 1, http.StatusBadGateway, nil)
+	sdk := newRetrySDK(server.URL, &gateway.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	_, err := sdk.DataAssets.UpdateFile("1", "file.txt", []byte("content"), nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(attempts))
+	assert.Equal(t, []string{"content", "content"}, *uploads)
+}
+
+func TestRetry_ReplaysSeekableStream(t *testing.T) {
+	server, attempts, uploads := newRetryServer(t, 1, http.StatusBadGateway, nil)
+	sdk := newRetrySDK(server.URL, &gateway.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	var transferred []int64
+	_, err := sdk.DataAssets.UpdateFileReader("1", "file.txt", bytes.NewReader([]byte("streamed")), gateway.UploadOptions{
+		Size:     8,
+		Progress: func(sent int64, total int64) { transferred = append(transferred, sent) },
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(attempts))
+	assert.Equal(t, []string{"streamed", "streamed"}, *uploads)
+	assert.Equal(t, int64(8), transferred[len(transferred)-1])
+}
+
+func TestRetry_SkipsUnseekableStream(t *testing.T) {
+	server, attempts, _ := newRetryServer(t, 1, http.StatusBadGateway, nil)
+	sdk := newRetrySDK(server.URL, &gateway.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	_, err := sdk.DataAssets.UpdateFileReader("1", "file.txt", io.MultiReader(bytes.NewReader([]byte("streamed"))), gateway.UploadOptions{})
+
+	assert.ErrorIs(t, err, gateway.ErrServerError)
+	assert.Equal(t, int32(1), atomic.LoadInt32(attempts))
+}
+
+func TestRetry_DownloadKeepsLastResponse(t *testing.T) {
+	server, attempts, _ := newRetryServer(t, 5, http.StatusServiceUnavailable, nil)
+	sdk := newRetrySDK(server.URL, &gateway.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+
+	_, _, err := sdk.DataAssets.DownloadStream(1)
+
+	var apiErr *gateway.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.ErrorIs(t, err, gateway.ErrServerError)
+	assert.Equal(t, int32(2), atomic.LoadInt32(attempts))
+
+	_, err = sdk.DataAssets.DownloadTo(1, io.Discard)
+
+	require.ErrorAs(t, err, &apiErr)
+	assert.ErrorIs(t, err, gateway.ErrServerError)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.Equal(t, int32(4), atomic.LoadInt32(attempts))
+}
@@ -1,22 +1,89 @@
 package client
 
 import (
-	
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
 	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type SDK struct {
-	DataAssets DataAsset
-	DataModel  DataModel
-	Account    *AccountsImpl
-	ACL        ACL
-	Auth       Auth
+	DataAssets     DataAsset
+	DataModel      DataModel
+	Account        Accounts
+	ACL            ACL
+	Auth           Auth
+	ComputeRequest ComputeRequest
+	Session        *SessionManager
+
+	hooks *hookChain
 }
 
+// DefaultBaseURL is the dev Gateway API, which the SDK talks to when no URL
+// or Environment is set.
+const DefaultBaseURL = "https://dev.api.gateway.tech"
+
 type SDKConfig struct {
 	ApiKey        string
 	WalletDetails WalletDetails
 	URL           string
+	// URLs lists base URLs in order of preference, replacing URL; requests
+	// fail over between them as configured by Failover.
+	URLs []string
+	// Environment picks the base URL of a Gateway deployment instead of URL
+	// or URLs.
+	Environment Environment
+	// Failover tunes health probing and failover between URLs; nil uses the
+	// defaults.
+	Failover    *FailoverConfig
+	TokenLeeway time.Duration
+	// Session enables background token renewal; start it with SDK.Session.Start.
+	Session *SessionConfig
+	// Retry enables retries of transient failures; nil leaves them off.
+	Retry *RetryPolicy
+	// HTTPClient sends the requests; nil uses a default client.
+	HTTPClient *http.Client
+	// RoundTripper replaces the transport of the HTTP client. It can be
+	// shared across SDK instances.
+	RoundTripper http.RoundTripper
+	// Transport tunes the proxy, TLS and connection pool of the HTTP
+	// client's *http.Transport.
+	Transport *TransportConfig
+	// Timeout bounds every request, including reading the response; zero
+	// leaves requests unbounded.
+	Timeout time.Duration
+	// Hooks run around every request; SDK.Use adds more later.
+	Hooks []Hook
+	// TracerProvider enables a span per SDK operation; nil leaves tracing
+	// off.
+	TracerProvider trace.TracerProvider
+	// Propagator sends the trace context to the API; nil uses W3C
+	// traceparent headers.
+	Propagator propagation.TextMapPropagator
+	// Metrics receives request, authentication and transfer measurements;
+	// nil leaves them off.
+	Metrics Metrics
+	// Logger receives debug request and response logs, auth lifecycle
+	// events and warnings, with secrets redacted; nil leaves logging off.
+	Logger *slog.Logger
+	// RateLimiter holds requests back to stay within its budget; SDKs
+	// given the same RateLimiter share it. nil leaves requests unlimited.
+	RateLimiter *RateLimiter
+	// CircuitBreaker stops sending requests while the API keeps failing;
+	// SDKs given the same CircuitBreaker share its state. nil leaves it off.
+	CircuitBreaker *CircuitBreaker
+	// Verifiers check the signatures of Auth.Login; nil uses
+	// DefaultVerifierRegistry.
+	Verifiers *VerifierRegistry
+	// StrictVerification makes Auth.Login reject wallets no Verifier
+	// handles instead of leaving them to the API.
+	StrictVerification bool
 }
 
 type WalletDetails struct {
@@ -24,67 +91,197 @@ type WalletDetails struct {
 	WalletType WalletTypeEnum
 }
 
+// NewSDK builds an SDK from config. It panics when config is invalid; New
+// reports the problem as an error instead.
 func NewSDK(config SDKConfig) *SDK {
-	client := resty.New()
-	if config.URL != "" {
-		client.SetBaseURL(config.URL)
-	} else {
-		client.SetBaseURL("https://dev.api.gateway.tech")
+	sdk, err := newSDK(config)
+	if err != nil {
+		panic(err)
 	}
+	return sdk
+}
 
-	if config.ApiKey != "" {
-		client.SetAuthToken(config.ApiKey)
-	} else {
-		wallet, _ := NewWalletService(config.WalletDetails.PrivateKey, config.WalletDetails.WalletType)
-		params := MiddlewareParams{
-			Client: client,
-			Wallet: *wallet,
+// Reinitialize builds a new SDK from config, leaving sdk untouched.
+func (sdk *SDK) Reinitialize(config SDKConfig) *SDK {
+	return NewSDK(config)
+}
+
+func newSDK(config SDKConfig) (*SDK, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	var wallet *WalletService
+	if config.ApiKey == "" {
+		var err error
+		wallet, err = NewWalletService(config.WalletDetails.PrivateKey, config.WalletDetails.WalletType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid wallet: %w", err)
 		}
-		client.OnBeforeRequest(AuthMiddleware(params))
+	}
+
+	client := resty.New()
+	if config.usesCustomHTTP() {
+		httpClient, err := newHTTPClient(config)
+		if err != nil {
+			return nil, err
+		}
+		client = resty.NewWithClient(httpClient)
+	}
+	logger := newSDKLogger(config.Logger)
+	baseURLs := config.baseURLs()
+	client.SetBaseURL(baseURLs[0])
+	if config.usesDefaultURL() {
+		Config{Logger: logger}.logger().Warn("no base URL or environment set, using the dev Gateway API", "url", DefaultBaseURL)
+	}
+	if config.Timeout > 0 {
+		client.SetTimeout(config.Timeout)
+	}
+	configureRetry(client, config.Retry)
+	if len(baseURLs) > 1 {
+		var failover FailoverConfig
+		if config.Failover != nil {
+			failover = *config.Failover
+		}
+		if err := installFailover(client, baseURLs, failover, Config{Logger: logger}.logger()); err != nil {
+			return nil, err
+		}
+	}
+	if config.TracerProvider != nil {
+		installTracing(client, config.TracerProvider, config.Propagator)
+	}
+	if config.CircuitBreaker != nil {
+		installCircuitBreaker(client, config.CircuitBreaker)
+	}
+	if config.RateLimiter != nil {
+		installRateLimiter(client, config.RateLimiter)
 	}
 
 	sdkClient := Config{
-		Client: client,
+		Client:             client,
+		Metrics:            config.Metrics,
+		Logger:             logger,
+		Verifiers:          config.Verifiers,
+		StrictVerification: config.StrictVerification,
+	}
+	session := configureAuth(sdkClient, config, wallet)
+
+	var hookList []Hook
+	if sdkClient.Metrics != nil {
+		hookList = append(hookList, metricsHooks(sdkClient.Metrics)...)
+	}
+	if sdkClient.Logger != nil {
+		hookList = append(hookList, loggingHooks(sdkClient.Logger)...)
 	}
+	hooks := newHookChain(client, append(hookList, config.Hooks...))
 
 	return &SDK{
-		DataAssets: NewDataAssetImpl(sdkClient),
-		DataModel:  NewDataModelImpl(sdkClient),
-		Auth:       NewAuthImpl(sdkClient),
-		ACL:        NewACLImpl(sdkClient),
-		Account:    NewAccountsImpl(sdkClient),
+		DataAssets:     NewDataAssetImpl(sdkClient),
+		DataModel:      NewDataModelImpl(sdkClient),
+		Auth:           NewAuthImpl(sdkClient),
+		ACL:            NewACLImpl(sdkClient),
+		Account:        NewAccountsImpl(sdkClient),
+		ComputeRequest: NewComputeRequestImpl(sdkClient),
+		Session:        session,
+		hooks:          hooks,
+	}, nil
+}
+
+// validate checks the settings that do not need a wallet to be loaded.
+func (config SDKConfig) validate() error {
+	if config.ApiKey == "" && config.WalletDetails.PrivateKey == "" {
+		return errors.New("an API key or a wallet private key is required")
+	}
+
+	set := 0
+	for _, isSet := range []bool{config.URL != "", len(config.URLs) > 0, config.Environment != ""} {
+		if isSet {
+			set++
+		}
+	}
+	if set > 1 {
+		return errors.New("URL, URLs and Environment are mutually exclusive")
 	}
+	if config.Environment != "" && config.Environment.BaseURL() == "" {
+		return fmt.Errorf("unknown environment %q", config.Environment)
+	}
+	if !config.usesDefaultURL() {
+		for _, baseURL := range config.baseURLs() {
+			if err := validateBaseURL(baseURL); err != nil {
+				return err
+			}
+		}
+	}
+
+	if config.Timeout < 0 {
+		return fmt.Errorf("invalid timeout %s: must not be negative", config.Timeout)
+	}
+	if config.TokenLeeway < 0 {
+		return fmt.Errorf("invalid token leeway %s: must not be negative", config.TokenLeeway)
+	}
+
+	return nil
 }
 
-func (sdk *SDK) Reinitialize(config SDKConfig) *SDK {
-	client := resty.New()
+func validateBaseURL(baseURL string) error {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid base URL: %w", err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid base URL %q: expected an absolute http or https URL", baseURL)
+	}
+	return nil
+}
 
-	if config.URL != "" {
-		client.SetBaseURL(config.URL)
-	} else {
-		client.SetBaseURL("https://dev.api.gateway.tech")
+// baseURLs returns the base URLs of config in order of preference.
+func (config SDKConfig) baseURLs() []string {
+	switch {
+	case len(config.URLs) > 0:
+		return config.URLs
+	case config.URL != "":
+		return []string{config.URL}
+	case config.Environment != "":
+		return []string{config.Environment.BaseURL()}
 	}
+	return []string{DefaultBaseURL}
+}
+
+func (config SDKConfig) usesDefaultURL() bool {
+	return config.URL == "" && len(config.URLs) == 0 && config.Environment == ""
+}
 
+// configureAuth installs the authentication for config on the client and
+// returns the session manager when config.Session is set. wallet signs in
+// when config has no API key.
+func configureAuth(sdkClient Config, config SDKConfig, wallet *WalletService) *SessionManager {
+	client := sdkClient.Client
+
+	var holder *tokenHolder
 	if config.ApiKey != "" {
 		client.SetAuthToken(config.ApiKey)
+		if config.Session == nil {
+			return nil
+		}
+		holder = newTokenHolder(config.TokenLeeway, nil)
+		holder.bearer = true
+		holder.Set(config.ApiKey)
+		client.OnBeforeRequest(apiKeyMiddleware(holder))
 	} else {
-		wallet, _ := NewWalletService(config.WalletDetails.PrivateKey, config.WalletDetails.WalletType)
-		params := MiddlewareParams{
-			Client: client,
-			Wallet: *wallet,
+		holder = newWalletTokenHolder(MiddlewareParams{
+			Client:             client,
+			Wallet:             *wallet,
+			TokenLeeway:        config.TokenLeeway,
+			Metrics:            sdkClient.Metrics,
+			Logger:             sdkClient.Logger,
+			Verifiers:          sdkClient.Verifiers,
+			StrictVerification: sdkClient.StrictVerification,
+		})
+		client.OnBeforeRequest(authMiddleware(holder))
+		if config.Session == nil {
+			return nil
 		}
-		client.OnBeforeRequest(AuthMiddleware(params))
 	}
 
-	sdkClient := Config{
-		Client: client,
-	}
-
-	return &SDK{
-		DataAssets: NewDataAssetImpl(sdkClient),
-		DataModel:  NewDataModelImpl(sdkClient),
-		Auth:       NewAuthImpl(sdkClient),
-		ACL:        NewACLImpl(sdkClient),
-		Account:    NewAccountsImpl(sdkClient),
-	}
+	return newSessionManager(NewAuthImpl(sdkClient), holder, *config.Session)
 }
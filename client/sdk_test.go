@@ -38,7 +38,7 @@ func TestNewSDK_WithoutAPIKey_UseWallet(t *testing.T) {
 	assert.NotNil(t, sdk.DataAssets, "DataAssets should not be nil")
 	assert.NotNil(t, sdk.Account, "Account should not be nil")
 
-	assert.Equal(t, "https://example.com", sdk.Account.Config.Client.BaseURL, "BaseURL should be set correctly")
+	assert.Equal(t, "https://example.com", sdk.Account.(*client.AccountsImpl).Config.Client.BaseURL, "BaseURL should be set correctly")
 }
 
 func TestSDK_Reinitialize_WithAPIKey(t *testing.T) {
@@ -58,7 +58,7 @@ func TestSDK_Reinitialize_WithAPIKey(t *testing.T) {
 	assert.NotNil(t, reinitializedSDK, "Reinitialized SDK instance should not be nil")
 	assert.NotNil(t, reinitializedSDK.Auth, "Auth should not be nil")
 	assert.NotNil(t, reinitializedSDK.DataAssets, "DataAssets should not be nil")
-	assert.Equal(t, "https://new-example.com", reinitializedSDK.Account.Config.Client.BaseURL, "BaseURL should be updated correctly")
+	assert.Equal(t, "https://new-example.com", reinitializedSDK.Account.(*client.AccountsImpl).Config.Client.BaseURL, "BaseURL should be updated correctly")
 }
 
 func TestSDK_Reinitialize_WithoutAPIKey_UseWallet(t *testing.T) {
@@ -87,5 +87,5 @@ func TestSDK_Reinitialize_WithoutAPIKey_UseWallet(t *testing.T) {
 	assert.NotNil(t, reinitializedSDK, "Reinitialized SDK instance should not be nil")
 	assert.NotNil(t, reinitializedSDK.Auth, "Auth should not be nil")
 	assert.NotNil(t, reinitializedSDK.DataAssets, "DataAssets should not be nil")
-	assert.Equal(t, "https://new-example.com", reinitializedSDK.Account.Config.Client.BaseURL, "BaseURL should be updated correctly")
+	assert.Equal(t, "https://new-example.com", reinitializedSDK.Account.(*client.AccountsImpl).Config.Client.BaseURL, "BaseURL should be updated correctly")
 }
@@ -0,0 +1,207 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	DefaultSessionRefreshBefore = 5 * time.Minute
+	DefaultSessionRetryInterval = 30 * time.Second
+
+	// minSessionRefreshWait is the shortest wait between two renewals, for
+	// tokens that live no longer than RefreshBefore.
+	minSessionRefreshWait = 5 * time.Second
+)
+
+type SessionConfig struct {
+	// RefreshBefore is how long before the token expires the session renews it.
+	RefreshBefore time.Duration
+	// RetryInterval is how long the session waits after a failed renewal.
+	RetryInterval    time.Duration
+	OnTokenRefreshed func(token string)
+	OnAuthFailure    func(err error)
+}
+
+// SessionManager keeps the SDK token fresh in the background. It renews the
+// token through the refresh-token endpoint and only falls back to a full
+// wallet sign-in when the refresh fails.
+type SessionManager struct {
+	auth   *AuthImpl
+	holder *tokenHolder
+	config SessionConfig
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newSessionManager(auth *AuthImpl, holder *tokenHolder, config SessionConfig) *SessionManager {
+	if config.RefreshBefore == 0 {
+		config.RefreshBefore = DefaultSessionRefreshBefore
+	}
+	if config.RetryInterval == 0 {
+		config.RetryInterval = DefaultSessionRetryInterval
+	}
+	return &SessionManager{
+		auth:   auth,
+		holder: holder,
+		config: config,
+	}
+}
+
+// apiKeyMiddleware sends the session's current token in place of the
+// static API key, so renewed tokens are picked up by every request.
+func apiKeyMiddleware(holder *tokenHolder) resty.RequestMiddleware {
+	return func(c *resty.Client, r *resty.Request) error {
+		if token := holder.Current(); token != "" {
+			holder.authorize(r, token)
+		}
+		return nil
+	}
+}
+
+// Start runs the renewal loop until ctx is done or Stop is called.
+func (s *SessionManager) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return
+	}
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.done = make(chan struct{})
+	go s.run(ctx, s.done)
+}
+
+// Stop ends the renewal loop and waits for it to exit.
+func (s *SessionManager) Stop() {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.cancel, s.done = nil, nil
+	s.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+// Token returns the token the session currently holds.
+func (s *SessionManager) Token() string {
+	return s.holder.Current()
+}
+
+// Refresh renews the token immediately.
+func (s *SessionManager) Refresh(ctx context.Context) (string, error) {
+	var refreshErr error
+
+	if current := s.holder.Current(); current != "" {
+		token, err := s.auth.refreshToken(ctx, s.holder, current)
+		if err == nil && token != "" {
+			s.holder.Set(token)
+			s.tokenRefreshed(token)
+			return token, nil
+		}
+		if err == nil {
+			err = errors.New("refresh-token endpoint returned an empty token")
+		}
+		refreshErr = err
+		s.auth.Config.logger().DebugContext(ctx, "session could not refresh token, signing in again", "error", err)
+	}
+
+	token, err := s.holder.Reissue(ctx)
+	if err != nil {
+		if refreshErr != nil {
+			err = fmt.Errorf("failed to refresh token: %w; %w", refreshErr, err)
+		}
+		s.authFailure(err)
+		return "", err
+	}
+
+	s.tokenRefreshed(token)
+	return token, nil
+}
+
+func (s *SessionManager) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	renewed := false
+	for {
+		wait, ok := s.nextRefresh(renewed)
+		if !ok {
+			<-ctx.Done()
+			return
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		_, err := s.Refresh(ctx)
+		renewed = err == nil
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			timer := time.NewTimer(s.config.RetryInterval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// nextRefresh is the delay until the current token enters the renewal window.
+// A missing token is renewed immediately; a token without an exp claim never
+// expires, so there is nothing to schedule. A token that was just renewed
+// but already is in the window, as tokens that live no longer than
+// RefreshBefore are, is renewed halfway through its remaining lifetime
+// instead, so the session does not renew it over and over.
+func (s *SessionManager) nextRefresh(renewed bool) (time.Duration, bool) {
+	current := s.holder.Current()
+	if current == "" {
+		return 0, true
+	}
+
+	expiresAt, ok := jwtExpiration(current)
+	if !ok {
+		return 0, false
+	}
+
+	remaining := time.Until(expiresAt)
+	if wait := remaining - s.config.RefreshBefore; wait > 0 {
+		return wait, true
+	}
+	if !renewed {
+		return 0, true
+	}
+	return max(remaining/2, minSessionRefreshWait), true
+}
+
+func (s *SessionManager) tokenRefreshed(token string) {
+	s.auth.Config.logger().Debug("session renewed token")
+	if s.config.OnTokenRefreshed != nil {
+		s.config.OnTokenRefreshed(token)
+	}
+}
+
+func (s *SessionManager) authFailure(err error) {
+	s.auth.Config.logger().Warn("session could not renew token", "error", err)
+	if s.config.OnAuthFailure != nil {
+		s.config.OnAuthFailure(err)
+	}
+}
@@ -0,0 +1,227 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSessionSDK(t *testing.T, config gateway.SDKConfig) *gateway.SDK {
+	config.URL = "https://example.com"
+	sdk := gateway.NewSDK(config)
+	httpmock.ActivateNonDefault(sdk.Account.(*gateway.AccountsImpl).Config.Client.GetClient())
+	t.Cleanup(httpmock.DeactivateAndReset)
+	return sdk
+}
+
+func registerWalletLogin(token string) {
+	httpmock.RegisterResponder("GET", "https://example.com/auth/message",
+		httpmock.NewJsonResponderOrPanic(200, gateway.MessageResponse{Message: "mock-message"}))
+	httpmock.RegisterResponder("POST", "https://example.com/auth",
+		httpmock.NewJsonResponderOrPanic(200, gateway.TokenResponse{Token: token}))
+}
+
+func TestSessionManager_RefreshUsesRefreshToken(t *testing.T) {
+	var refreshed []string
+	sdk := newTestSessionSDK(t, gateway.SDKConfig{
+		WalletDetails: gateway.WalletDetails{
+			PrivateKey: "edb0ba5a63c5f9e4f4394560907794fca750704b355413bc04baab896254036a",
+			WalletType: gateway.Ethereum,
+		},
+		Session: &gateway.SessionConfig{
+			OnTokenRefreshed: func(token string) { refreshed = append(refreshed, token) },
+		},
+	})
+
+	issuedToken := newTestJWT(time.Minute)
+	refreshedToken := newTestJWT(time.Hour)
+	registerWalletLogin(issuedToken)
+	httpmock.RegisterResponder("GET", "https://example.com/auth/refresh-token", func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, issuedToken, req.Header.Get("Authorization"))
+		return httpmock.NewJsonResponse(200, gateway.TokenResponse{Token: refreshedToken})
+	})
+
+	token, err := sdk.Session.Refresh(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, issuedToken, token)
+
+	token, err = sdk.Session.Refresh(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, refreshedToken, token)
+	assert.Equal(t, refreshedToken, sdk.Session.Token())
+
+	info := httpmock.GetCallCountInfo()
+	assert.Equal(t, 1, info["GET https://example.com/auth/message"])
+	assert.Equal(t, 1, info["GET https://example.com/auth/refresh-token"])
+	assert.Equal(t, []string{issuedToken, refreshedToken}, refreshed)
+}
+
+func TestSessionManager_FallsBackToIssueJWT(t *testing.T) {
+	sdk := newTestSessionSDK(t, gateway.SDKConfig{
+		WalletDetails: gateway.WalletDetails{
+			PrivateKey: "edb0ba5a63c5f9e4f4394560907794fca750704b355413bc04baab896254036a",
+			WalletType: gateway.Ethereum,
+		},
+		Session: &gateway.SessionConfig{},
+	})
+
+	issuedToken := newTestJWT(time.Minute)
+	registerWalletLogin(issuedToken)
+	httpmock.RegisterResponder("GET", "https://example.com/auth/refresh-token",
+		httpmock.NewJsonResponderOrPanic(401, gateway.Error{Error: "token revoked"}))
+
+	_, err := sdk.Session.Refresh(context.Background())
+	assert.NoError(t, err)
+
+	token, err := sdk.Session.Refresh(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, issuedToken, token)
+	assert.Equal(t, 2, httpmock.GetCallCountInfo()["GET https://example.com/auth/message"])
+}
+
+func TestSessionManager_APIKeyRenewal(t *testing.T) {
+	apiKey := newTestJWT(time.Minute)
+	sdk := newTestSessionSDK(t, gateway.SDKConfig{
+		ApiKey:  apiKey,
+		Session: &gateway.SessionConfig{},
+	})
+
+	refreshedToken := newTestJWT(time.Hour)
+	httpmock.RegisterResponder("GET", "https://example.com/auth/refresh-token", func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "Bearer "+apiKey, req.Header.Get("Authorization"))
+		return httpmock.NewJsonResponse(200, gateway.TokenResponse{Token: refreshedToken})
+	})
+	httpmock.RegisterResponder("GET", "https://example.com/accounts/me", func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "Bearer "+refreshedToken, req.Header.Get("Authorization"))
+		return httpmock.NewJsonResponse(200, gateway.MyAccountResponse{Username: "test"})
+	})
+
+	_, err := sdk.Session.Refresh(context.Background())
+	assert.NoError(t, err)
+
+	account, err := sdk.Account.GetMe()
+	assert.NoError(t, err)
+	assert.Equal(t, "test", account.Username)
+}
+
+func TestSessionManager_APIKeyRefreshFailure(t *testing.T) {
+	var failure error
+	sdk := newTestSessionSDK(t, gateway.SDKConfig{
+		ApiKey: newTestJWT(time.Minute),
+		Session: &gateway.SessionConfig{
+			OnAuthFailure: func(err error) { failure = err },
+		},
+	})
+
+	httpmock.RegisterResponder("GET", "https://example.com/auth/refresh-token",
+		httpmock.NewJsonResponderOrPanic(500, gateway.Error{Error: "internal server error"}))
+
+	_, err := sdk.Session.Refresh(context.Background())
+
+	assert.ErrorIs(t, err, gateway.ErrServerError)
+	assert.Equal(t, err, failure)
+}
+
+func TestSessionManager_StartRenewsInBackground(t *testing.T) {
+	refreshed := make(chan string, 1)
+	sdk := newTestSessionSDK(t, gateway.SDKConfig{
+		ApiKey: newTestJWT(time.Minute),
+		Session: &gateway.SessionConfig{
+			RefreshBefore:    2 * time.Minute,
+			OnTokenRefreshed: func(token string) { refreshed <- token },
+		},
+	})
+
+	refreshedToken := newTestJWT(time.Hour)
+	httpmock.RegisterResponder("GET", "https://example.com/auth/refresh-token",
+		httpmock.NewJsonResponderOrPanic(200, gateway.TokenResponse{Token: refreshedToken}))
+
+	sdk.Session.Start(context.Background())
+	defer sdk.Session.Stop()
+
+	select {
+	case token := <-refreshed:
+		assert.Equal(t, refreshedToken, token)
+	case <-time.After(time.Second):
+		t.Fatal("session did not renew the token")
+	}
+}
+
+func TestSessionManager_ShortLivedTokensAreNotRenewedRepeatedly(t *testing.T) {
+	refreshed := make(chan string, 1)
+	sdk := newTestSessionSDK(t, gateway.SDKConfig{
+		ApiKey: newTestJWT(time.Minute),
+		Session: &gateway.SessionConfig{
+			OnTokenRefreshed: func(token string) {
+				select {
+				case refreshed <- token:
+				default:
+				}
+			},
+		},
+	})
+
+	// Every renewed token lives a minute, less than the default
+	// RefreshBefore, so it is in the renewal window as soon as it is issued.
+	httpmock.RegisterResponder("GET", "https://example.com/auth/refresh-token", func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewJsonResponse(200, gateway.TokenResponse{Token: newTestJWT(time.Minute)})
+	})
+
+	sdk.Session.Start(context.Background())
+	defer sdk.Session.Stop()
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("session did not renew the token")
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["GET https://example.com/auth/refresh-token"])
+}
+
+func TestNewSDK_WithoutSession(t *testing.T) {
+	sdk := gateway.NewSDK(gateway.SDKConfig{ApiKey: "test-api-key"})
+
+	assert.Nil(t, sdk.Session)
+}
+
+func TestSessionManager_WalletSendsTokensBare(t *testing.T) {
+	sdk := newTestSessionSDK(t, gateway.SDKConfig{
+		WalletDetails: gateway.WalletDetails{
+			PrivateKey: "edb0ba5a63c5f9e4f4394560907794fca750704b355413bc04baab896254036a",
+			WalletType: gateway.Ethereum,
+		},
+		Session: &gateway.SessionConfig{},
+	})
+
+	issuedToken := newTestJWT(time.Minute)
+	refreshedToken := newTestJWT(time.Hour)
+	registerWalletLogin(issuedToken)
+
+	var headers []string
+	httpmock.RegisterResponder("GET", "https://example.com/auth/refresh-token", func(req *http.Request) (*http.Response, error) {
+		headers = append(headers, req.Header.Get("Authorization"))
+		return httpmock.NewJsonResponse(200, gateway.TokenResponse{Token: refreshedToken})
+	})
+	httpmock.RegisterResponder("GET", "https://example.com/accounts/me", func(req *http.Request) (*http.Response, error) {
+		headers = append(headers, req.Header.Get("Authorization"))
+		return httpmock.NewJsonResponse(200, gateway.MyAccountResponse{Username: "test"})
+	})
+
+	_, err := sdk.Session.Refresh(context.Background())
+	assert.NoError(t, err)
+	_, err = sdk.Session.Refresh(context.Background())
+	assert.NoError(t, err)
+
+	_, err = sdk.Account.GetMe()
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{issuedToken, refreshedToken}, headers)
+}
@@ -2,7 +2,6 @@ package client
 
 import (
 	"fmt"
-	"log"
 
 	"golang.org/x/crypto/ed25519"
 
@@ -16,19 +15,32 @@ type SolanaService struct {
 	wallet           types.Account
 }
 
+// NewSolanaService panics when walletPrivateKey is not a valid key; use
+// ParseSolanaService to get the error instead.
 func NewSolanaService(walletPrivateKey string) *SolanaService {
-	privateKey := solana.MustPrivateKeyFromBase58(walletPrivateKey)
+	service, err := ParseSolanaService(walletPrivateKey)
+	if err != nil {
+		panic(err)
+	}
+	return service
+}
+
+// ParseSolanaService loads a base58 encoded ed25519 keypair.
+func ParseSolanaService(walletPrivateKey string) (*SolanaService, error) {
+	privateKey, err := solana.PrivateKeyFromBase58(walletPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid solana private key: %w", err)
+	}
 
 	wallet, err := types.AccountFromBytes(privateKey)
 	if err != nil {
-		log.Printf("Failed to create wallet from private key: %v", err)
-		panic(err)
+		return nil, fmt.Errorf("invalid solana private key: %w", err)
 	}
 
 	return &SolanaService{
 		walletPrivateKey: privateKey,
 		wallet:           wallet,
-	}
+	}, nil
 }
 
 func (ss *SolanaService) SignMessage(message string) (WalletSignMessageType, error) {
@@ -0,0 +1,246 @@
+package client
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secp256k1ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+const (
+	ED25519_PUBLIC_KEY_SIZE = 32
+	// SECP256_PUBLIC_KEY_SIZE is the size of the compressed Secp256k1 and
+	// Secp256r1 public keys Sui uses.
+	SECP256_PUBLIC_KEY_SIZE = 33
+	SUI_SIGNATURE_SIZE      = 64
+)
+
+// suiKeypair signs Sui digests with one of the single-key schemes.
+type suiKeypair interface {
+	Flag() SigFlag
+	// PublicKey returns the key as Sui serializes it: 32 bytes for ED25519,
+	// 33 compressed bytes for Secp256k1 and Secp256r1.
+	PublicKey() []byte
+	// Sign signs digest the way the Sui TypeScript SDK does: ED25519 signs
+	// it as is, the ECDSA schemes sign its SHA-256 hash with a low S.
+	Sign(digest []byte) ([]byte, error)
+}
+
+// newSuiKeypair loads secretKey as a private key of the scheme of flag.
+func newSuiKeypair(flag SigFlag, secretKey []byte) (suiKeypair, error) {
+	if len(secretKey) != PRIVATE_KEY_SIZE {
+		return nil, fmt.Errorf("wrong secretKey size. Expected %d bytes, got %d", PRIVATE_KEY_SIZE, len(secretKey))
+	}
+
+	switch flag {
+	case SigFlagEd25519:
+		_, private, err := fromSecretKey(secretKey)
+		if err != nil {
+			return nil, err
+		}
+		return ed25519Keypair{private}, nil
+	case SigFlagSecp256k1:
+		var scalar secp256k1.ModNScalar
+		if overflow := scalar.SetByteSlice(secretKey); overflow || scalar.IsZero() {
+			return nil, errors.New("provided secretKey is not a valid Secp256k1 scalar")
+		}
+		return secp256k1Keypair{secp256k1.NewPrivateKey(&scalar)}, nil
+	case SigFlagSecp256r1:
+		if _, err := ecdh.P256().NewPrivateKey(secretKey); err != nil {
+			return nil, errors.New("provided secretKey is not a valid Secp256r1 scalar")
+		}
+		curve := elliptic.P256()
+		private := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(secretKey)}
+		private.PublicKey.Curve = curve
+		private.PublicKey.X, private.PublicKey.Y = curve.ScalarBaseMult(secretKey)
+		return secp256r1Keypair{private}, nil
+	}
+	return nil, fmt.Errorf("unsupported signature scheme flag 0x%02x", byte(flag))
+}
+
+type ed25519Keypair struct {
+	private ed25519.PrivateKey
+}
+
+func (k ed25519Keypair) Flag() SigFlag { return SigFlagEd25519 }
+
+func (k ed25519Keypair) PublicKey() []byte {
+	return k.private.Public().(ed25519.PublicKey)
+}
+
+func (k ed25519Keypair) Sign(digest []byte) ([]byte, error) {
+	return ed25519.Sign(k.private, digest), nil
+}
+
+type secp256k1Keypair struct {
+	private *secp256k1.PrivateKey
+}
+
+func (k secp256k1Keypair) Flag() SigFlag { return SigFlagSecp256k1 }
+
+func (k secp256k1Keypair) PublicKey() []byte {
+	return k.private.PubKey().SerializeCompressed()
+}
+
+func (k secp256k1Keypair) Sign(digest []byte) ([]byte, error) {
+	hash := sha256.Sum256(digest)
+	// Sign is deterministic (RFC 6979) and always returns a low S.
+	signature := secp256k1ecdsa.Sign(k.private, hash[:])
+
+	r, s := signature.R(), signature.S()
+	compact := make([]byte, SUI_SIGNATURE_SIZE)
+	r.PutBytesUnchecked(compact[:32])
+	s.PutBytesUnchecked(compact[32:])
+	return compact, nil
+}
+
+type secp256r1Keypair struct {
+	private *ecdsa.PrivateKey
+}
+
+func (k secp256r1Keypair) Flag() SigFlag { return SigFlagSecp256r1 }
+
+func (k secp256r1Keypair) PublicKey() []byte {
+	return elliptic.MarshalCompressed(k.private.Curve, k.private.X, k.private.Y)
+}
+
+func (k secp256r1Keypair) Sign(digest []byte) ([]byte, error) {
+	hash := sha256.Sum256(digest)
+	curve := k.private.Curve
+	n := curve.Params().N
+	e := new(big.Int).SetBytes(hash[:])
+
+	// The nonce is deterministic (RFC 6979), like Secp256k1's, so that a key
+	// always signs a message the same way.
+	nonces := newRFC6979Nonces(k.private.D, hash[:], n)
+	for {
+		nonce := nonces.next()
+		x, _ := curve.ScalarBaseMult(nonce.FillBytes(make([]byte, 32)))
+		r := new(big.Int).Mod(x, n)
+		if r.Sign() == 0 {
+			continue
+		}
+		s := new(big.Int).Mul(r, k.private.D)
+		s.Add(s, e).Mul(s, nonce.ModInverse(nonce, n)).Mod(s, n)
+		if s.Sign() == 0 {
+			continue
+		}
+
+		// Sui only accepts the lower of the two equivalent S values.
+		if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+			s.Sub(n, s)
+		}
+
+		compact := make([]byte, SUI_SIGNATURE_SIZE)
+		r.FillBytes(compact[:32])
+		s.FillBytes(compact[32:])
+		return compact, nil
+	}
+}
+
+// rfc6979Nonces generates the RFC 6979 nonces of a 256-bit private key and
+// a SHA-256 hash, with HMAC-SHA256.
+type rfc6979Nonces struct {
+	k, v []byte
+	n    *big.Int
+}
+
+func newRFC6979Nonces(private *big.Int, hash []byte, n *big.Int) *rfc6979Nonces {
+	g := &rfc6979Nonces{k: make([]byte, 32), v: bytes.Repeat([]byte{1}, 32), n: n}
+	x := private.FillBytes(make([]byte, 32))
+	h := new(big.Int).Mod(new(big.Int).SetBytes(hash), n).FillBytes(make([]byte, 32))
+
+	g.k = g.mac(g.v, []byte{0}, x, h)
+	g.v = g.mac(g.v)
+	g.k = g.mac(g.v, []byte{1}, x, h)
+	g.v = g.mac(g.v)
+	return g
+}
+
+func (g *rfc6979Nonces) mac(data ...[]byte) []byte {
+	m := hmac.New(sha256.New, g.k)
+	for _, d := range data {
+		m.Write(d)
+	}
+	return m.Sum(nil)
+}
+
+// next returns the next candidate nonce in [1, n).
+func (g *rfc6979Nonces) next() *big.Int {
+	for {
+		g.v = g.mac(g.v)
+		k := new(big.Int).SetBytes(g.v)
+		// The state moves on right away: next is only called again once the
+		// caller rejects k, which is when RFC 6979 updates it.
+		g.k = g.mac(g.v, []byte{0})
+		g.v = g.mac(g.v)
+		if k.Sign() > 0 && k.Cmp(g.n) < 0 {
+			return k
+		}
+	}
+}
+
+// publicKeySize returns the size of the public keys of the scheme of flag.
+func publicKeySize(flag SigFlag) (int, error) {
+	switch flag {
+	case SigFlagEd25519:
+		return ED25519_PUBLIC_KEY_SIZE, nil
+	case SigFlagSecp256k1, SigFlagSecp256r1:
+		return SECP256_PUBLIC_KEY_SIZE, nil
+	}
+	return 0, fmt.Errorf("unsupported signature scheme flag 0x%02x", byte(flag))
+}
+
+// verifySuiSignature checks signature over digest against pubKey, both
+// serialized the Sui way for the scheme of flag. ECDSA signatures with a
+// high S are rejected as Sui does.
+func verifySuiSignature(flag SigFlag, pubKey []byte, digest []byte, signature []byte) (bool, error) {
+	size, err := publicKeySize(flag)
+	if err != nil {
+		return false, err
+	}
+	if len(pubKey) != size {
+		return false, fmt.Errorf("invalid %s public key size: expected %d bytes, got %d", SIGNATURE_FLAG_TO_SCHEME[byte(flag)], size, len(pubKey))
+	}
+	if len(signature) != SUI_SIGNATURE_SIZE {
+		return false, fmt.Errorf("invalid %s signature size: expected %d bytes, got %d", SIGNATURE_FLAG_TO_SCHEME[byte(flag)], SUI_SIGNATURE_SIZE, len(signature))
+	}
+
+	switch flag {
+	case SigFlagEd25519:
+		return ed25519.Verify(pubKey, digest, signature), nil
+	case SigFlagSecp256k1:
+		key, err := secp256k1.ParsePubKey(pubKey)
+		if err != nil {
+			return false, fmt.Errorf("invalid Secp256k1 public key: %w", err)
+		}
+		var r, s secp256k1.ModNScalar
+		if r.SetByteSlice(signature[:32]) || s.SetByteSlice(signature[32:]) || s.IsOverHalfOrder() {
+			return false, nil
+		}
+		hash := sha256.Sum256(digest)
+		return secp256k1ecdsa.NewSignature(&r, &s).Verify(hash[:], key), nil
+	default:
+		curve := elliptic.P256()
+		x, y := elliptic.UnmarshalCompressed(curve, pubKey)
+		if x == nil {
+			return false, errors.New("invalid Secp256r1 public key")
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if s.Cmp(new(big.Int).Rsh(curve.Params().N, 1)) > 0 {
+			return false, nil
+		}
+		hash := sha256.Sum256(digest)
+		return ecdsa.Verify(&ecdsa.PublicKey{Curve: curve, X: x, Y: y}, hash[:], r, s), nil
+	}
+}
@@ -0,0 +1,254 @@
+package client
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"github.com/Gateway-DAO/gateway-go-sdk/bcs"
+	"golang.org/x/crypto/blake2b"
+)
+
+// MAX_SIGNER_IN_MULTISIG is the most public keys a Sui MultiSig can have.
+const MAX_SIGNER_IN_MULTISIG = 10
+
+// suiEnumFlags maps the variants of Sui's PublicKey and CompressedSignature
+// BCS enums to the flags of their schemes.
+var suiEnumFlags = []SigFlag{SigFlagEd25519, SigFlagSecp256k1, SigFlagSecp256r1}
+
+// SuiMultiSigMember is one of the public keys of a Sui MultiSig, with the
+// weight its signature counts for.
+type SuiMultiSigMember struct {
+	Flag      SigFlag
+	PublicKey []byte
+	Weight    uint8
+}
+
+// SuiMultiSigPublicKey is the set of keys behind a Sui MultiSig address: a
+// signature is valid when the weights of its signers reach Threshold.
+type SuiMultiSigPublicKey struct {
+	Members   []SuiMultiSigMember
+	Threshold uint16
+}
+
+// Validate applies Sui's rules: one to MAX_SIGNER_IN_MULTISIG distinct
+// keys of non-zero weight, and a reachable non-zero threshold.
+func (pk SuiMultiSigPublicKey) Validate() error {
+	if len(pk.Members) == 0 || len(pk.Members) > MAX_SIGNER_IN_MULTISIG {
+		return fmt.Errorf("invalid multisig public key: expected 1 to %d keys, got %d", MAX_SIGNER_IN_MULTISIG, len(pk.Members))
+	}
+	if pk.Threshold == 0 {
+		return errors.New("invalid multisig public key: threshold must not be zero")
+	}
+
+	total := 0
+	for i, member := range pk.Members {
+		size, err := publicKeySize(member.Flag)
+		if err != nil {
+			return fmt.Errorf("invalid multisig public key: %w", err)
+		}
+		if len(member.PublicKey) != size {
+			return fmt.Errorf("invalid multisig public key: key %d has %d bytes, expected %d", i, len(member.PublicKey), size)
+		}
+		if member.Weight == 0 {
+			return fmt.Errorf("invalid multisig public key: key %d has no weight", i)
+		}
+		for _, other := range pk.Members[:i] {
+			if other.Flag == member.Flag && bytes.Equal(other.PublicKey, member.PublicKey) {
+				return fmt.Errorf("invalid multisig public key: key %d is repeated", i)
+			}
+		}
+		total += int(member.Weight)
+	}
+	if total < int(pk.Threshold) {
+		return fmt.Errorf("invalid multisig public key: total weight %d is below the threshold %d", total, pk.Threshold)
+	}
+	return nil
+}
+
+// Address derives the MultiSig address: the BLAKE2b-256 hash of the MultiSig
+// flag, the little endian threshold and every key's flag, bytes and weight.
+func (pk SuiMultiSigPublicKey) Address() string {
+	var e bcs.Encoder
+	e.U8(byte(SigFlagMultiSig))
+	e.U16(pk.Threshold)
+	for _, member := range pk.Members {
+		e.U8(byte(member.Flag))
+		e.FixedBytes(member.PublicKey)
+		e.U8(member.Weight)
+	}
+
+	addrBytes := blake2b.Sum256(e.Bytes())
+	return "0x" + hex.EncodeToString(addrBytes[:])
+}
+
+func (pk SuiMultiSigPublicKey) MarshalBCS(e *bcs.Encoder) {
+	e.Length(len(pk.Members))
+	for _, member := range pk.Members {
+		e.Variant(uint32(member.Flag))
+		e.FixedBytes(member.PublicKey)
+		e.U8(member.Weight)
+	}
+	e.U16(pk.Threshold)
+}
+
+func (pk *SuiMultiSigPublicKey) UnmarshalBCS(d *bcs.Decoder) {
+	n := d.Length()
+	pk.Members = make([]SuiMultiSigMember, 0, n)
+	for i := 0; i < n && d.Err() == nil; i++ {
+		flag := decodeSuiEnumFlag(d, "public key")
+		size, _ := publicKeySize(flag)
+		pk.Members = append(pk.Members, SuiMultiSigMember{
+			Flag:      flag,
+			PublicKey: d.FixedBytes(size),
+			Weight:    d.U8(),
+		})
+	}
+	pk.Threshold = d.U16()
+}
+
+// suiMultiSig is the BCS layout of a MultiSig serialized signature after
+// its flag. Bit i of bitmap is set when the member i signed; signatures
+// are in the order of the members.
+type suiMultiSig struct {
+	signatures []suiCompressedSignature
+	bitmap     uint16
+	publicKey  SuiMultiSigPublicKey
+}
+
+type suiCompressedSignature struct {
+	flag      SigFlag
+	signature []byte
+}
+
+func (m suiMultiSig) MarshalBCS(e *bcs.Encoder) {
+	e.Length(len(m.signatures))
+	for _, sig := range m.signatures {
+		e.Variant(uint32(sig.flag))
+		e.FixedBytes(sig.signature)
+	}
+	e.U16(m.bitmap)
+	e.Encode(m.publicKey)
+}
+
+func (m *suiMultiSig) UnmarshalBCS(d *bcs.Decoder) {
+	n := d.Length()
+	m.signatures = make([]suiCompressedSignature, 0, n)
+	for i := 0; i < n && d.Err() == nil; i++ {
+		m.signatures = append(m.signatures, suiCompressedSignature{
+			flag:      decodeSuiEnumFlag(d, "signature"),
+			signature: d.FixedBytes(SUI_SIGNATURE_SIZE),
+		})
+	}
+	m.bitmap = d.U16()
+	d.Decode(&m.publicKey)
+}
+
+// decodeSuiEnumFlag reads the variant of a PublicKey or CompressedSignature
+// enum. Only the single-key schemes are supported as MultiSig members.
+func decodeSuiEnumFlag(d *bcs.Decoder, what string) SigFlag {
+	variant := d.Variant()
+	if d.Err() != nil {
+		return 0
+	}
+	if int(variant) >= len(suiEnumFlags) {
+		d.Fail(fmt.Errorf("unsupported multisig %s scheme %d", what, variant))
+		return 0
+	}
+	return suiEnumFlags[variant]
+}
+
+// CombineSuiMultiSig combines signatures serialized by members of pk, such
+// as those returned by SuiService.SignMessage, into a MultiSig serialized
+// signature. It does not check that their weights reach the threshold.
+func CombineSuiMultiSig(pk SuiMultiSigPublicKey, signatures []string) (string, error) {
+	if err := pk.Validate(); err != nil {
+		return "", err
+	}
+
+	signed := make(map[int]suiCompressedSignature, len(signatures))
+	for _, signature := range signatures {
+		parsed, err := parseSerializedSignature(signature)
+		if err != nil {
+			return "", err
+		}
+
+		index := -1
+		for i, member := range pk.Members {
+			if member.Flag == parsed.Flag && bytes.Equal(member.PublicKey, parsed.PubKey) {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return "", errors.New("signature is not from a member of the multisig")
+		}
+		if _, ok := signed[index]; ok {
+			return "", errors.New("duplicate signature from a member of the multisig")
+		}
+		signed[index] = suiCompressedSignature{flag: parsed.Flag, signature: parsed.Signature}
+	}
+
+	multiSig := suiMultiSig{publicKey: pk}
+	for i := range pk.Members {
+		if sig, ok := signed[i]; ok {
+			multiSig.bitmap |= 1 << i
+			multiSig.signatures = append(multiSig.signatures, sig)
+		}
+	}
+
+	serialized := append([]byte{byte(SigFlagMultiSig)}, bcs.Marshal(multiSig)...)
+	return base64.StdEncoding.EncodeToString(serialized), nil
+}
+
+// verifySuiMultiSig checks the MultiSig serialized signature, without its
+// flag, over digest and reports whether it belongs to walletAddress.
+func verifySuiMultiSig(serialized []byte, digest []byte, walletAddress string) (bool, error) {
+	var multiSig suiMultiSig
+	if err := bcs.Unmarshal(serialized, &multiSig); err != nil {
+		return false, fmt.Errorf("invalid multisig signature: %w", err)
+	}
+	pk := multiSig.publicKey
+	if err := pk.Validate(); err != nil {
+		return false, err
+	}
+
+	if bits.OnesCount16(multiSig.bitmap) != len(multiSig.signatures) {
+		return false, errors.New("invalid multisig signature: bitmap does not match the signatures")
+	}
+	if multiSig.bitmap>>len(pk.Members) != 0 {
+		return false, errors.New("invalid multisig signature: bitmap refers to unknown keys")
+	}
+
+	weight := 0
+	next := 0
+	for i, member := range pk.Members {
+		if multiSig.bitmap&(1<<i) == 0 {
+			continue
+		}
+		sig := multiSig.signatures[next]
+		next++
+
+		if sig.flag != member.Flag {
+			return false, fmt.Errorf("invalid multisig signature: signature %d does not match the scheme of key %d", next-1, i)
+		}
+		pass, err := verifySuiSignature(member.Flag, member.PublicKey, digest, sig.signature)
+		if err != nil {
+			return false, err
+		}
+		if !pass {
+			return false, errors.New("signature verification failed")
+		}
+		weight += int(member.Weight)
+	}
+
+	if weight < int(pk.Threshold) {
+		return false, fmt.Errorf("multisig signature verification failed: weight %d is below the threshold %d", weight, pk.Threshold)
+	}
+
+	return strings.EqualFold(pk.Address(), walletAddress), nil
+}
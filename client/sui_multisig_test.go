@@ -0,0 +1,170 @@
+package client_test
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+type multiSigSigner struct {
+	wallet *gateway.WalletService
+	member gateway.SuiMultiSigMember
+}
+
+func newMultiSigSigners(t *testing.T) ([]multiSigSigner, gateway.SuiMultiSigPublicKey) {
+	t.Helper()
+
+	keys := []struct {
+		key    string
+		weight uint8
+	}{
+		{testSuiKey, 1},
+		{encodeSuiPrivateKey(t, gateway.SigFlagSecp256k1, testSuiSecret), 1},
+		{encodeSuiPrivateKey(t, gateway.SigFlagSecp256r1, testSuiSecret), 2},
+	}
+
+	var signers []multiSigSigner
+	pk := gateway.SuiMultiSigPublicKey{Threshold: 2}
+	for _, key := range keys {
+		wallet, err := gateway.NewWalletService(key.key, gateway.Sui)
+		require.NoError(t, err)
+
+		signed, err := wallet.SignMessage("public key")
+		require.NoError(t, err)
+		serialized, err := base64.StdEncoding.DecodeString(signed.Signature)
+		require.NoError(t, err)
+
+		member := gateway.SuiMultiSigMember{Flag: gateway.SigFlag(serialized[0]), PublicKey: serialized[65:], Weight: key.weight}
+		signers = append(signers, multiSigSigner{wallet: wallet, member: member})
+		pk.Members = append(pk.Members, member)
+	}
+	return signers, pk
+}
+
+func signMultiSig(t *testing.T, pk gateway.SuiMultiSigPublicKey, message string, signers ...multiSigSigner) string {
+	t.Helper()
+	var signatures []string
+	for _, signer := range signers {
+		signed, err := signer.wallet.SignMessage(message)
+		require.NoError(t, err)
+		signatures = append(signatures, signed.Signature)
+	}
+	combined, err := gateway.CombineSuiMultiSig(pk, signatures)
+	require.NoError(t, err)
+	return combined
+}
+
+func TestSuiMultiSigPublicKey_Address(t *testing.T) {
+	_, pk := newMultiSigSigners(t)
+
+	preimage := []byte{byte(gateway.SigFlagMultiSig)}
+	preimage = binary.LittleEndian.AppendUint16(preimage, pk.Threshold)
+	for _, member := range pk.Members {
+		preimage = append(preimage, byte(member.Flag))
+		preimage = append(preimage, member.PublicKey...)
+		preimage = append(preimage, member.Weight)
+	}
+	address := blake2b.Sum256(preimage)
+
+	assert.Equal(t, "0x"+hex.EncodeToString(address[:]), pk.Address())
+}
+
+func TestVerifySuiMessage_MultiSig(t *testing.T) {
+	signers, pk := newMultiSigSigners(t)
+	address := pk.Address()
+
+	for name, test := range map[string]struct {
+		signers []multiSigSigner
+		valid   bool
+	}{
+		"heavy key alone":      {signers: signers[2:], valid: true},
+		"two light keys":       {signers: signers[:2], valid: true},
+		"every key":            {signers: signers, valid: true},
+		"below the threshold":  {signers: signers[1:2]},
+		"no signatures at all": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			signature := signMultiSig(t, pk, "test message", test.signers...)
+
+			valid, err := gateway.VerifySuiMessage(signature, "test message", address)
+			if !test.valid {
+				assert.ErrorContains(t, err, "below the threshold")
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, valid)
+
+			valid, err = gateway.VerifySuiMessage(signature, "test message", signers[0].wallet.Wallet.(*gateway.SuiService).GetWallet())
+			require.NoError(t, err)
+			assert.False(t, valid, "a member's own address is not the multisig address")
+
+			_, err = gateway.VerifySuiMessage(signature, "another message", address)
+			assert.ErrorContains(t, err, "signature verification failed")
+		})
+	}
+}
+
+func TestVerifySuiMessage_MalformedMultiSig(t *testing.T) {
+	signers, pk := newMultiSigSigners(t)
+	signature := signMultiSig(t, pk, "test message", signers[2])
+	serialized, err := base64.StdEncoding.DecodeString(signature)
+	require.NoError(t, err)
+
+	// flag, one signature (length, variant and 64 bytes), then the bitmap.
+	bitmap := 1 + 1 + 1 + 64
+	require.Equal(t, []byte{0b100, 0}, serialized[bitmap:bitmap+2])
+
+	tampered := append([]byte(nil), serialized...)
+	tampered[bitmap] = 0b110
+	_, err = gateway.VerifySuiMessage(base64.StdEncoding.EncodeToString(tampered), "test message", pk.Address())
+	assert.ErrorContains(t, err, "bitmap does not match")
+
+	tampered[bitmap] = 0b1000
+	_, err = gateway.VerifySuiMessage(base64.StdEncoding.EncodeToString(tampered), "test message", pk.Address())
+	assert.ErrorContains(t, err, "unknown keys")
+
+	tampered[bitmap] = 0b001
+	_, err = gateway.VerifySuiMessage(base64.StdEncoding.EncodeToString(tampered), "test message", pk.Address())
+	assert.ErrorContains(t, err, "does not match the scheme")
+
+	_, err = gateway.VerifySuiMessage(base64.StdEncoding.EncodeToString(serialized[:40]), "test message", pk.Address())
+	assert.ErrorContains(t, err, "invalid multisig signature")
+}
+
+func TestSuiMultiSigPublicKey_Validate(t *testing.T) {
+	_, pk := newMultiSigSigners(t)
+	require.NoError(t, pk.Validate())
+
+	unreachable := pk
+	unreachable.Threshold = 5
+	assert.ErrorContains(t, unreachable.Validate(), "below the threshold")
+
+	repeated := pk
+	repeated.Members = append([]gateway.SuiMultiSigMember{pk.Members[0]}, pk.Members...)
+	assert.ErrorContains(t, repeated.Validate(), "repeated")
+
+	weightless := gateway.SuiMultiSigPublicKey{Threshold: 1, Members: []gateway.SuiMultiSigMember{pk.Members[0]}}
+	weightless.Members[0].Weight = 0
+	assert.ErrorContains(t, weightless.Validate(), "no weight")
+
+	assert.Error(t, gateway.SuiMultiSigPublicKey{Threshold: 1}.Validate())
+}
+
+func TestCombineSuiMultiSig_RejectsStrangers(t *testing.T) {
+	signers, pk := newMultiSigSigners(t)
+	outsider := gateway.SuiMultiSigPublicKey{Threshold: 1, Members: []gateway.SuiMultiSigMember{pk.Members[0]}}
+
+	signed, err := signers[1].wallet.SignMessage("test message")
+	require.NoError(t, err)
+	_, err = gateway.CombineSuiMultiSig(outsider, []string{signed.Signature})
+	assert.ErrorContains(t, err, "not from a member")
+
+	_, err = gateway.CombineSuiMultiSig(pk, []string{signed.Signature, signed.Signature})
+	assert.ErrorContains(t, err, "duplicate")
+}
@@ -9,46 +9,66 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/Gateway-DAO/gateway-go-sdk/bcs"
 	"github.com/btcsuite/btcutil/bech32"
 	"golang.org/x/crypto/blake2b"
 )
 
 type SuiService struct {
-	walletPrivateKey ed25519.PrivateKey
-	walletAddress    string
+	keypair       suiKeypair
+	walletAddress string
 }
 
 const (
 	SUI_PRIVATE_KEY_PREFIX = "suiprivkey"
 	PRIVATE_KEY_SIZE       = 32
-	SUI_ADDRESS_LENGTH     = 20
+	SUI_ADDRESS_LENGTH     = 32
 )
 
 type SignaturePubkeyPair struct {
 	SignatureScheme string
+	Flag            SigFlag
 	Signature       []byte
 	PubKey          []byte
 }
 
 var SIGNATURE_FLAG_TO_SCHEME = map[byte]string{
 	0x00: "ED25519",
+	0x01: "Secp256k1",
+	0x02: "Secp256r1",
+	0x03: "MultiSig",
+	0x05: "ZkLogin",
 }
 
 type ParsedKeypair struct {
 	Schema    string
+	Flag      SigFlag
 	SecretKey []byte
 }
 
 type SigFlag byte
 
+// SignatureScheme is the intent scope of Sui personal messages.
+//
+// Deprecated: serialized signatures start with the SigFlag of their scheme.
 const SignatureScheme = 3
 
 const (
-	SigFlagEd25519 SigFlag = 0x00
+	SigFlagEd25519   SigFlag = 0x00
+	SigFlagSecp256k1 SigFlag = 0x01
+	SigFlagSecp256r1 SigFlag = 0x02
+	SigFlagMultiSig  SigFlag = 0x03
+	SigFlagZkLogin   SigFlag = 0x05
 )
 
 func ed25519PublicKeyToSuiAddress(pubKey []byte) string {
-	newPubkey := []byte{byte(SigFlagEd25519)}
+	return suiPublicKeyToAddress(SigFlagEd25519, pubKey)
+}
+
+// suiPublicKeyToAddress derives the Sui address of pubKey: the BLAKE2b-256
+// hash of the scheme flag followed by the key.
+func suiPublicKeyToAddress(flag SigFlag, pubKey []byte) string {
+	newPubkey := []byte{byte(flag)}
 	newPubkey = append(newPubkey, pubKey...)
 
 	addrBytes := blake2b.Sum256(newPubkey)
@@ -68,12 +88,19 @@ func decodeSuiPrivateKey(value string) (ParsedKeypair, error) {
 	if err != nil {
 		return ParsedKeypair{}, err
 	}
+	if len(extendedSecretKey) == 0 {
+		return ParsedKeypair{}, errors.New("empty private key")
+	}
 
-	signatureScheme := SIGNATURE_FLAG_TO_SCHEME[extendedSecretKey[0]]
+	signatureScheme, ok := SIGNATURE_FLAG_TO_SCHEME[extendedSecretKey[0]]
+	if !ok {
+		return ParsedKeypair{}, fmt.Errorf("unsupported signature scheme flag 0x%02x", extendedSecretKey[0])
+	}
 	secretKey := extendedSecretKey[1:]
 
 	return ParsedKeypair{
 		Schema:    signatureScheme,
+		Flag:      SigFlag(extendedSecretKey[0]),
 		SecretKey: secretKey,
 	}, nil
 }
@@ -102,17 +129,29 @@ func parseSerializedSignature(serializedSignature string) (*SignaturePubkeyPair,
 	if err != nil {
 		return nil, err
 	}
+	if len(_bytes) == 0 {
+		return nil, errors.New("empty signature")
+	}
 
-	signatureScheme := "ED25519"
-	if strings.EqualFold(serializedSignature, "") {
-		return nil, fmt.Errorf("multiSig is not supported")
+	flag := SigFlag(_bytes[0])
+	signatureScheme, ok := SIGNATURE_FLAG_TO_SCHEME[byte(flag)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported signature scheme flag 0x%02x", byte(flag))
+	}
+	pubKeySize, err := publicKeySize(flag)
+	if err != nil {
+		return nil, err
+	}
+	if len(_bytes) != 1+SUI_SIGNATURE_SIZE+pubKeySize {
+		return nil, fmt.Errorf("invalid %s signature length %d", signatureScheme, len(_bytes))
 	}
 
-	signature := _bytes[1 : len(_bytes)-32]
-	pubKeyBytes := _bytes[1+len(signature):]
+	signature := _bytes[1 : 1+SUI_SIGNATURE_SIZE]
+	pubKeyBytes := _bytes[1+SUI_SIGNATURE_SIZE:]
 
 	keyPair := &SignaturePubkeyPair{
 		SignatureScheme: signatureScheme,
+		Flag:            flag,
 		Signature:       signature,
 		PubKey:          pubKeyBytes,
 	}
@@ -127,7 +166,16 @@ func messageWithIntent(message []byte) []byte {
 	return intentMessage
 }
 
-func toSerializedSignature(signature []byte, scheme byte, publicKey ed25519.PublicKey) string {
+// suiPersonalMessageDigest returns the digest Sui signs for a personal
+// message: the BLAKE2b-256 hash of the intent followed by the message as a
+// BCS vector<u8>.
+func suiPersonalMessageDigest(message []byte) [32]byte {
+	var e bcs.Encoder
+	e.ByteVector(message)
+	return blake2b.Sum256(messageWithIntent(e.Bytes()))
+}
+
+func toSerializedSignature(signature []byte, scheme byte, publicKey []byte) string {
 	serialized := make([]byte, 1+len(signature)+len(publicKey))
 	serialized[0] = scheme
 	copy(serialized[1:], signature)
@@ -148,39 +196,45 @@ func getHexByteLength(value string) int {
 }
 
 // Note this is a custom implementation of Sui Wallet in go.
+//
+// NewSuiService panics when walletPrivateKey is not a valid key; use
+// ParseSuiService to get the error instead.
 func NewSuiService(walletPrivateKey string) *SuiService {
-	decoded, err := decodeSuiPrivateKey(walletPrivateKey)
+	service, err := ParseSuiService(walletPrivateKey)
 	if err != nil {
-		fmt.Println("Error decoding private key:", err)
+		panic(err)
 	}
+	return service
+}
 
-	if decoded.Schema != "ED25519" {
-		fmt.Printf("Expected an ED25519 keypair, got %s\n", decoded.Schema)
+// ParseSuiService loads a bech32 encoded "suiprivkey" key of the ED25519,
+// Secp256k1 or Secp256r1 scheme.
+func ParseSuiService(walletPrivateKey string) (*SuiService, error) {
+	decoded, err := decodeSuiPrivateKey(walletPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sui private key: %w", err)
 	}
 
-	pub, private, err := fromSecretKey(decoded.SecretKey)
+	keypair, err := newSuiKeypair(decoded.Flag, decoded.SecretKey)
 	if err != nil {
-		fmt.Println("Error creating keypair:", err)
+		return nil, fmt.Errorf("invalid sui private key: %w", err)
 	}
 
-	publicKeyHex := ed25519PublicKeyToSuiAddress(pub)
-
 	return &SuiService{
-		walletPrivateKey: private,
-		walletAddress:    publicKeyHex,
-	}
+		keypair:       keypair,
+		walletAddress: suiPublicKeyToAddress(keypair.Flag(), keypair.PublicKey()),
+	}, nil
 }
 
 func (es *SuiService) SignMessage(message string) (WalletSignMessageType, error) {
-	messageBytes := []byte(message)
-	bcsBytes := append([]byte{uint8(len(messageBytes))}, messageBytes...)
-	intentMessage := messageWithIntent(bcsBytes)
-
-	digest := blake2b.Sum256(intentMessage)
+	digest := suiPersonalMessageDigest([]byte(message))
 
-	signature := ed25519.Sign(es.walletPrivateKey, digest[:])
+	signature, err := es.keypair.Sign(digest[:])
+	if err != nil {
+		return WalletSignMessageType{}, fmt.Errorf("failed to sign message: %w", err)
+	}
 
-	serializedSignature := toSerializedSignature(signature, SignatureScheme, es.walletPrivateKey.Public().(ed25519.PublicKey))
+	serializedSignature := toSerializedSignature(signature, byte(es.keypair.Flag()), es.keypair.PublicKey())
 
 	return WalletSignMessageType{
 		Signature:  serializedSignature,
@@ -188,27 +242,11 @@ func (es *SuiService) SignMessage(message string) (WalletSignMessageType, error)
 	}, nil
 }
 
+// VerifySuiMessage checks a serialized signature of message, from a single
+// key or a MultiSig, and reports whether it belongs to walletAddress. Use a
+// SuiVerifier with a ZkLoginConfig to verify zkLogin signatures too.
 func VerifySuiMessage(signature string, message, walletAddress string) (bool, error) {
-	serializedSignature, err := parseSerializedSignature(signature)
-	if err != nil {
-		return false, err
-	}
-
-	messageBytes := []byte(message)
-	bcsBytes := append([]byte{uint8(len(messageBytes))}, messageBytes...)
-
-	messageBytes = messageWithIntent(bcsBytes)
-
-	digest := blake2b.Sum256(messageBytes)
-	pass := ed25519.Verify(serializedSignature.PubKey[:], digest[:], serializedSignature.Signature)
-
-	if !pass {
-		return false, errors.New("signature verification failed")
-	}
-
-	derivedAddress := ed25519PublicKeyToSuiAddress(serializedSignature.PubKey)
-
-	return strings.EqualFold(derivedAddress, walletAddress), nil
+	return SuiVerifier{}.VerifyMessage(signature, message, walletAddress)
 }
 
 func ValidateSuiWallet(walletAddress string) bool {
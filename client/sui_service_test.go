@@ -0,0 +1,299 @@
+package client_test
+
+import (
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/btcsuite/btcutil/bech32"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+var testSuiSecret = []byte{
+	0x3b, 0x94, 0x0b, 0x55, 0x86, 0x82, 0x3d, 0xfd, 0x02, 0xae, 0x3b, 0x46, 0x1b, 0xb4, 0x33, 0x6b,
+	0x5e, 0xcb, 0xae, 0xfd, 0x66, 0x27, 0xaa, 0x92, 0x2e, 0xfc, 0x04, 0x8f, 0xec, 0x0c, 0x88, 0x1c,
+}
+
+func encodeSuiPrivateKey(t *testing.T, flag gateway.SigFlag, secret []byte) string {
+	t.Helper()
+	words, err := bech32.ConvertBits(append([]byte{byte(flag)}, secret...), 8, 5, true)
+	require.NoError(t, err)
+	encoded, err := bech32.Encode(gateway.SUI_PRIVATE_KEY_PREFIX, words)
+	require.NoError(t, err)
+	return encoded
+}
+
+// suiTestPublicKey derives the public key Sui expects for secret, without
+// going through the SDK.
+func suiTestPublicKey(t *testing.T, flag gateway.SigFlag, secret []byte) []byte {
+	t.Helper()
+	switch flag {
+	case gateway.SigFlagSecp256k1:
+		return secp256k1.PrivKeyFromBytes(secret).PubKey().SerializeCompressed()
+	case gateway.SigFlagSecp256r1:
+		key, err := ecdh.P256().NewPrivateKey(secret)
+		require.NoError(t, err)
+		uncompressed := key.PublicKey().Bytes()
+		return append([]byte{0x02 | uncompressed[64]&1}, uncompressed[1:33]...)
+	}
+	t.Fatalf("unexpected flag %d", flag)
+	return nil
+}
+
+func TestSuiService_Secp256Schemes(t *testing.T) {
+	for name, flag := range map[string]gateway.SigFlag{
+		"secp256k1": gateway.SigFlagSecp256k1,
+		"secp256r1": gateway.SigFlagSecp256r1,
+	} {
+		t.Run(name, func(t *testing.T) {
+			service, err := gateway.NewWalletService(encodeSuiPrivateKey(t, flag, testSuiSecret), gateway.Sui)
+			require.NoError(t, err)
+
+			signed, err := service.SignMessage("test message")
+			require.NoError(t, err)
+
+			publicKey := suiTestPublicKey(t, flag, testSuiSecret)
+			serialized, err := base64.StdEncoding.DecodeString(signed.Signature)
+			require.NoError(t, err)
+			require.Len(t, serialized, 1+64+33)
+			assert.Equal(t, byte(flag), serialized[0])
+			assert.Equal(t, publicKey, serialized[65:])
+
+			address := blake2b.Sum256(append([]byte{byte(flag)}, publicKey...))
+			assert.Equal(t, "0x"+hex.EncodeToString(address[:]), signed.SigningKey)
+
+			valid, err := gateway.VerifySuiMessage(signed.Signature, "test message", signed.SigningKey)
+			require.NoError(t, err)
+			assert.True(t, valid)
+
+			_, err = gateway.VerifySuiMessage(signed.Signature, "another message", signed.SigningKey)
+			assert.Error(t, err)
+
+			valid, err = gateway.VerifySuiMessage(signed.Signature, "test message", "0x"+hex.EncodeToString(make([]byte, 32)))
+			require.NoError(t, err)
+			assert.False(t, valid)
+		})
+	}
+}
+
+func TestVerifySuiMessage_RejectsHighS(t *testing.T) {
+	for name, test := range map[string]struct {
+		flag  gateway.SigFlag
+		order *big.Int
+	}{
+		"secp256k1": {gateway.SigFlagSecp256k1, secp256k1.S256().N},
+		"secp256r1": {gateway.SigFlagSecp256r1, elliptic.P256().Params().N},
+	} {
+		t.Run(name, func(t *testing.T) {
+			service, err := gateway.NewWalletService(encodeSuiPrivateKey(t, test.flag, testSuiSecret), gateway.Sui)
+			require.NoError(t, err)
+			signed, err := service.SignMessage("test message")
+			require.NoError(t, err)
+
+			serialized, err := base64.StdEncoding.DecodeString(signed.Signature)
+			require.NoError(t, err)
+			s := new(big.Int).SetBytes(serialized[33:65])
+			new(big.Int).Sub(test.order, s).FillBytes(serialized[33:65])
+
+			_, err = gateway.VerifySuiMessage(base64.StdEncoding.EncodeToString(serialized), "test message", signed.SigningKey)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSuiService_Ed25519SignatureFlag(t *testing.T) {
+	service, err := gateway.NewWalletService(testSuiKey, gateway.Sui)
+	require.NoError(t, err)
+	signed, err := service.SignMessage("test message")
+	require.NoError(t, err)
+
+	serialized, err := base64.StdEncoding.DecodeString(signed.Signature)
+	require.NoError(t, err)
+	assert.Len(t, serialized, 1+64+32)
+	assert.Equal(t, byte(gateway.SigFlagEd25519), serialized[0])
+}
+
+func TestParseSuiService_InvalidKeys(t *testing.T) {
+	_, err := gateway.ParseSuiService(encodeSuiPrivateKey(t, 0x07, testSuiSecret))
+	assert.ErrorContains(t, err, "unsupported signature scheme flag 0x07")
+
+	_, err = gateway.ParseSuiService(encodeSuiPrivateKey(t, gateway.SigFlagSecp256k1, make([]byte, 32)))
+	assert.ErrorContains(t, err, "not a valid Secp256k1 scalar")
+
+	_, err = gateway.ParseSuiService(encodeSuiPrivateKey(t, gateway.SigFlagSecp256r1, testSuiSecret[:16]))
+	assert.ErrorContains(t, err, "wrong secretKey size")
+}
+
+func TestVerifySuiMessage_MalformedSignature(t *testing.T) {
+	service, err := gateway.NewWalletService(testSuiKey, gateway.Sui)
+	require.NoError(t, err)
+	signed, err := service.SignMessage("test message")
+	require.NoError(t, err)
+
+	serialized, _ := base64.StdEncoding.DecodeString(signed.Signature)
+	_, err = gateway.VerifySuiMessage(base64.StdEncoding.EncodeToString(serialized[:50]), "test message", signed.SigningKey)
+	assert.ErrorContains(t, err, "invalid ED25519 signature length")
+
+	serialized[0] = 0x07
+	_, err = gateway.VerifySuiMessage(base64.StdEncoding.EncodeToString(serialized), "test message", signed.SigningKey)
+	assert.ErrorContains(t, err, "unsupported signature scheme flag")
+
+	_, err = gateway.VerifySuiMessage("", "test message", signed.SigningKey)
+	assert.Error(t, err)
+}
+
+func TestSuiService_LongMessages(t *testing.T) {
+	service, err := gateway.NewWalletService(testSuiKey, gateway.Sui)
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		length int
+		prefix []byte
+	}{
+		{127, []byte{0x7f}},
+		{128, []byte{0x80, 0x01}},
+		{300, []byte{0xac, 0x02}},
+		{20000, []byte{0xa0, 0x9c, 0x01}},
+	} {
+		message := strings.Repeat("m", test.length)
+		signed, err := service.SignMessage(message)
+		require.NoError(t, err)
+
+		// Sui signs the intent and the message as a BCS vector<u8>.
+		intentMessage := append([]byte{3, 0, 0}, test.prefix...)
+		digest := blake2b.Sum256(append(intentMessage, message...))
+		serialized, err := base64.StdEncoding.DecodeString(signed.Signature)
+		require.NoError(t, err)
+		assert.True(t, ed25519.Verify(serialized[65:], digest[:], serialized[1:65]), "length %d", test.length)
+
+		valid, err := gateway.VerifySuiMessage(signed.Signature, message, signed.SigningKey)
+		require.NoError(t, err)
+		assert.True(t, valid, "length %d", test.length)
+	}
+}
+
+const testSuiSignInMessage = "Welcome to Gateway!\n\nSign this message to prove you own this wallet. " +
+	"It will not trigger a blockchain transaction or cost any gas fees.\n\nNonce: 3f9c2e71-8d4a-4b6e-9a55-0c1d2e3f4a5b"
+
+// testSuiLongSignInMessage is longer than 255 bytes, so its length prefix
+// has a second byte above 1.
+const testSuiLongSignInMessage = testSuiSignInMessage + "\n\nURI: https://mygateway.xyz/sign-in\nVersion: 1\n" +
+	"Chain: sui:mainnet\nIssued At: 2024-05-01T12:00:00Z\nExpiration Time: 2024-05-01T12:15:00Z"
+
+// suiMessageVector is a personal message signed with a pinned key. The
+// vectors were computed outside the SDK with an independent implementation
+// of Sui personal message signing over the BLAKE2b-256 intent digest.
+type suiMessageVector struct {
+	key       string
+	address   string
+	message   string
+	signature string
+}
+
+// testSecp256Vectors are signed like the Ed25519 vectors, with RFC 6979
+// nonces and low S values. Both schemes are deterministic, so the signatures
+// are compared byte for byte.
+var testSecp256Vectors = map[gateway.SigFlag][]suiMessageVector{
+	gateway.SigFlagSecp256k1: {
+		{
+			key:       "suiprivkey1qyqsyqcyq5rqwzqfpg9scrgwpugpzysnzs23v9ccrydpk8qarc0jq82ukn5",
+			address:   "0x888ccd887822e692bceebbd29743917e77932d72c7bd5da6a2a502ddef4f5837",
+			message:   "test message",
+			signature: "ATdCrYOTDOmYsD7aEieJV5M/LzqjtfV3v09h+8DleKU8KVkZrx1Kjdtfg1IBAOeo6fIY5lEAOqKGHxZH8GPV0Q8ChL91YiYrvWlACFdI875q+lKuMXFVGB7OMbZjUcz/pLA=",
+		},
+		{
+			key:       "suiprivkey1qyqsyqcyq5rqwzqfpg9scrgwpugpzysnzs23v9ccrydpk8qarc0jq82ukn5",
+			address:   "0x888ccd887822e692bceebbd29743917e77932d72c7bd5da6a2a502ddef4f5837",
+			message:   testSuiSignInMessage,
+			signature: "AQzSz7taGoTbAq1XlYnl9xd2y04P4nY4ftKk1B48sWuEcRGXqRsvGcwm07ASw3qu3/mpqZwP75zVdauY2eclwwIChL91YiYrvWlACFdI875q+lKuMXFVGB7OMbZjUcz/pLA=",
+		},
+		{
+			key:       "suiprivkey1qyqsyqcyq5rqwzqfpg9scrgwpugpzysnzs23v9ccrydpk8qarc0jq82ukn5",
+			address:   "0x888ccd887822e692bceebbd29743917e77932d72c7bd5da6a2a502ddef4f5837",
+			message:   testSuiLongSignInMessage,
+			signature: "AaFwlravc2y2mWqAHyuOrkmLqzPVnPEQTEFcABzXuOYmLaJlS2Srni4CsGKvL3dgWGsUksPtA6tmMeoOd91Q0ZUChL91YiYrvWlACFdI875q+lKuMXFVGB7OMbZjUcz/pLA=",
+		},
+	},
+	gateway.SigFlagSecp256r1: {
+		{
+			key:       "suiprivkey1qgqsyqcyq5rqwzqfpg9scrgwpugpzysnzs23v9ccrydpk8qarc0jqqz27ky",
+			address:   "0xae4618a47eb09f9015de8028a5775f4349eb387f2081c596b14b7bbf7e5a7551",
+			message:   "test message",
+			signature: "Ai9RCt5+Bg2QAdKUOLIh3UC+5/NbmmQmsXeCbAEjmuffGmUSeQggJT1LNoW7McOW6tHXiLwynRZ23HQVLzRVI80CUVw9brnjlrkE0/7Kf1T9zQzB6Ze/N13KUVrQpsO0A18=",
+		},
+		{
+			key:       "suiprivkey1qgqsyqcyq5rqwzqfpg9scrgwpugpzysnzs23v9ccrydpk8qarc0jqqz27ky",
+			address:   "0xae4618a47eb09f9015de8028a5775f4349eb387f2081c596b14b7bbf7e5a7551",
+			message:   testSuiSignInMessage,
+			signature: "AlFq0c8R6AI/wUVX8Jce6l6lpS9wJmUQZXHfawncxGf9LLRgpCFY1mU/PtoFY//d6nRI6wMEXkpxO78aruhHi/oCUVw9brnjlrkE0/7Kf1T9zQzB6Ze/N13KUVrQpsO0A18=",
+		},
+		{
+			key:       "suiprivkey1qgqsyqcyq5rqwzqfpg9scrgwpugpzysnzs23v9ccrydpk8qarc0jqqz27ky",
+			address:   "0xae4618a47eb09f9015de8028a5775f4349eb387f2081c596b14b7bbf7e5a7551",
+			message:   testSuiLongSignInMessage,
+			signature: "AuL18w352tQGuiS4r0NAN9Q5p4uzNsbgLAGLyLTwCYl8Sng4CW0NHKaiTo2a1vJN7LVOy0buL/gs4QHkmPttiUACUVw9brnjlrkE0/7Kf1T9zQzB6Ze/N13KUVrQpsO0A18=",
+		},
+	},
+}
+
+func TestSuiService_PersonalMessageVectors(t *testing.T) {
+	for _, test := range []suiMessageVector{
+		{
+			key:       testSuiKey,
+			address:   "0x7573c697fa68450f04fa0dee2d39dcdc8a5ccf5db547f3e47638a6f8eeeec110",
+			message:   "test message",
+			signature: "AA8sQAX2kbGe6qInu8qBBnK8R0rYodlA8I5JVlpfhtr55EqZ77CvZKUjwTW3Es1+tswiK4hx9bqBOignhtP46wR5tVYuj+ZU+UB4sRLoqYunkB+FOuaVvtfg45ELrQSWZA==",
+		},
+		{
+			key:       testSuiKey,
+			address:   "0x7573c697fa68450f04fa0dee2d39dcdc8a5ccf5db547f3e47638a6f8eeeec110",
+			message:   testSuiSignInMessage,
+			signature: "AL7H5l95sMNiRCSb+86efgDbwFo19vQApekmSzkTEFUnIvVyvF9Dz6HJRQZhrM4pk+dcJB5oW6r/L971xriP2w15tVYuj+ZU+UB4sRLoqYunkB+FOuaVvtfg45ELrQSWZA==",
+		},
+		{
+			key:       testSuiKey,
+			address:   "0x7573c697fa68450f04fa0dee2d39dcdc8a5ccf5db547f3e47638a6f8eeeec110",
+			message:   testSuiLongSignInMessage,
+			signature: "AO9jYK3zIDMtEspWzgUHOIU1Y9RdlERNwjd4ILcGVA6NvT7O8I86YvOt/WRqaVgob1iJjQj85EVtCOIMKHd0jgh5tVYuj+ZU+UB4sRLoqYunkB+FOuaVvtfg45ELrQSWZA==",
+		},
+	} {
+		service, err := gateway.NewWalletService(test.key, gateway.Sui)
+		require.NoError(t, err)
+
+		signed, err := service.SignMessage(test.message)
+		require.NoError(t, err)
+		assert.Equal(t, test.address, signed.SigningKey)
+		assert.Equal(t, test.signature, signed.Signature, "%d byte message", len(test.message))
+
+		valid, err := gateway.VerifySuiMessage(test.signature, test.message, test.address)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	}
+}
+
+func TestSuiService_Secp256Vectors(t *testing.T) {
+	for flag, vectors := range testSecp256Vectors {
+		for _, test := range vectors {
+			service, err := gateway.NewWalletService(test.key, gateway.Sui)
+			require.NoError(t, err)
+
+			signed, err := service.SignMessage(test.message)
+			require.NoError(t, err)
+			assert.Equal(t, test.address, signed.SigningKey, "flag %d", flag)
+			assert.Equal(t, test.signature, signed.Signature, "flag %d, %d byte message", flag, len(test.message))
+
+			valid, err := gateway.VerifySuiMessage(test.signature, test.message, test.address)
+			require.NoError(t, err)
+			assert.True(t, valid, "flag %d, %d byte message", flag, len(test.message))
+		}
+	}
+}
@@ -0,0 +1,518 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Gateway-DAO/gateway-go-sdk/bcs"
+	"github.com/Gateway-DAO/gateway-go-sdk/internal/poseidon"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// ZKLOGIN_MAX_ISS_LEN_B64 and ZKLOGIN_MAX_HEADER_LEN_B64 are the longest
+	// base64 iss claim and JWT header the zkLogin circuit accepts.
+	ZKLOGIN_MAX_ISS_LEN_B64    = 224
+	ZKLOGIN_MAX_HEADER_LEN_B64 = 248
+
+	zkLoginPackWidth    = 248
+	zkLoginModulusWidth = 2048
+)
+
+// ZkLoginProofPoints is the Groth16 proof of a zkLogin signature, with the
+// points as decimal projective coordinates.
+type ZkLoginProofPoints struct {
+	A []string   `json:"a"`
+	B [][]string `json:"b"`
+	C []string   `json:"c"`
+}
+
+// ZkLoginClaim is a claim of the JWT as a slice of its base64url payload.
+// IndexMod4 is the position of the slice in the payload modulo 4.
+type ZkLoginClaim struct {
+	Value     string `json:"value"`
+	IndexMod4 uint8  `json:"indexMod4"`
+}
+
+// ZkLoginInputs are the inputs of a zkLogin proof, as returned by the
+// zkLogin prover.
+type ZkLoginInputs struct {
+	ProofPoints      ZkLoginProofPoints `json:"proofPoints"`
+	IssBase64Details ZkLoginClaim       `json:"issBase64Details"`
+	HeaderBase64     string             `json:"headerBase64"`
+	AddressSeed      string             `json:"addressSeed"`
+}
+
+// ZkLoginSignature is a zkLogin serialized signature after its flag: a
+// signature of the ephemeral key, valid up to MaxEpoch, and the proof that
+// the key was bound to a JWT.
+type ZkLoginSignature struct {
+	Inputs        ZkLoginInputs
+	MaxEpoch      uint64
+	UserSignature []byte
+}
+
+func (s ZkLoginSignature) MarshalBCS(e *bcs.Encoder) {
+	proof := s.Inputs.ProofPoints
+	encodeStrings(e, proof.A)
+	e.Length(len(proof.B))
+	for _, b := range proof.B {
+		encodeStrings(e, b)
+	}
+	encodeStrings(e, proof.C)
+	e.UTF8String(s.Inputs.IssBase64Details.Value)
+	e.U8(s.Inputs.IssBase64Details.IndexMod4)
+	e.UTF8String(s.Inputs.HeaderBase64)
+	e.UTF8String(s.Inputs.AddressSeed)
+	e.U64(s.MaxEpoch)
+	e.ByteVector(s.UserSignature)
+}
+
+func (s *ZkLoginSignature) UnmarshalBCS(d *bcs.Decoder) {
+	proof := &s.Inputs.ProofPoints
+	proof.A = decodeStrings(d)
+	n := d.Length()
+	proof.B = make([][]string, 0, n)
+	for i := 0; i < n && d.Err() == nil; i++ {
+		proof.B = append(proof.B, decodeStrings(d))
+	}
+	proof.C = decodeStrings(d)
+	s.Inputs.IssBase64Details.Value = d.UTF8String()
+	s.Inputs.IssBase64Details.IndexMod4 = d.U8()
+	s.Inputs.HeaderBase64 = d.UTF8String()
+	s.Inputs.AddressSeed = d.UTF8String()
+	s.MaxEpoch = d.U64()
+	s.UserSignature = d.ByteVector()
+}
+
+func encodeStrings(e *bcs.Encoder, values []string) {
+	e.Length(len(values))
+	for _, value := range values {
+		e.UTF8String(value)
+	}
+}
+
+func decodeStrings(d *bcs.Decoder) []string {
+	n := d.Length()
+	values := make([]string, 0, n)
+	for i := 0; i < n && d.Err() == nil; i++ {
+		values = append(values, d.UTF8String())
+	}
+	return values
+}
+
+// Serialize returns the zkLogin serialized signature, as VerifySuiMessage
+// takes it.
+func (s ZkLoginSignature) Serialize() string {
+	serialized := append([]byte{byte(SigFlagZkLogin)}, bcs.Marshal(s)...)
+	return base64.StdEncoding.EncodeToString(serialized)
+}
+
+// Iss decodes the iss claim the signature was issued for.
+func (s ZkLoginSignature) Iss() (string, error) {
+	claim, err := decodeZkLoginClaim(s.Inputs.IssBase64Details)
+	if err != nil {
+		return "", err
+	}
+	return parseZkLoginClaim(claim, "iss")
+}
+
+// Address derives the zkLogin address of the signature from its iss claim
+// and address seed.
+func (s ZkLoginSignature) Address() (string, error) {
+	iss, err := s.Iss()
+	if err != nil {
+		return "", err
+	}
+	return ZkLoginAddress(iss, s.Inputs.AddressSeed)
+}
+
+// PublicInputsHash returns the single public input of the zkLogin circuit:
+// the Poseidon hash of the ephemeral key, the address seed, the max epoch,
+// the iss claim, the JWT header and the RSA modulus of the JWK.
+func (s ZkLoginSignature) PublicInputsHash(modulus []byte) (*big.Int, error) {
+	userSignature, err := parseSerializedSignature(base64.StdEncoding.EncodeToString(s.UserSignature))
+	if err != nil {
+		return nil, fmt.Errorf("invalid zkLogin user signature: %w", err)
+	}
+	extendedKey := append([]byte{byte(userSignature.Flag)}, userSignature.PubKey...)
+	split := len(extendedKey) - 16
+
+	addressSeed, err := parseZkLoginField(s.Inputs.AddressSeed)
+	if err != nil {
+		return nil, err
+	}
+	iss, err := hashZkLoginASCII(s.Inputs.IssBase64Details.Value, ZKLOGIN_MAX_ISS_LEN_B64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid zkLogin iss claim: %w", err)
+	}
+	header, err := hashZkLoginASCII(s.Inputs.HeaderBase64, ZKLOGIN_MAX_HEADER_LEN_B64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid zkLogin header: %w", err)
+	}
+	if len(modulus) > zkLoginModulusWidth/8 {
+		return nil, errors.New("invalid zkLogin modulus: longer than 2048 bits")
+	}
+	modulusHash, err := hashZkLoginPacked(new(big.Int).SetBytes(modulus), zkLoginModulusWidth)
+	if err != nil {
+		return nil, err
+	}
+
+	return hashZkLoginFields([]*big.Int{
+		new(big.Int).SetBytes(extendedKey[:split]),
+		new(big.Int).SetBytes(extendedKey[split:]),
+		addressSeed,
+		new(big.Int).SetUint64(s.MaxEpoch),
+		iss,
+		big.NewInt(int64(s.Inputs.IssBase64Details.IndexMod4)),
+		header,
+		modulusHash,
+	})
+}
+
+// ZkLoginAddress derives the Sui address of a zkLogin user: the BLAKE2b-256
+// hash of the zkLogin flag, the length prefixed iss and the address seed as
+// 32 big endian bytes.
+func ZkLoginAddress(iss string, addressSeed string) (string, error) {
+	if iss == "accounts.google.com" {
+		iss = "https://accounts.google.com"
+	}
+	if len(iss) > 255 {
+		return "", errors.New("invalid zkLogin iss: longer than 255 bytes")
+	}
+	seed, err := parseZkLoginField(addressSeed)
+	if err != nil {
+		return "", err
+	}
+
+	preimage := []byte{byte(SigFlagZkLogin), byte(len(iss))}
+	preimage = append(preimage, iss...)
+	preimage = append(preimage, seed.FillBytes(make([]byte, 32))...)
+	addrBytes := blake2b.Sum256(preimage)
+	return "0x" + hex.EncodeToString(addrBytes[:]), nil
+}
+
+// ZkLoginJWK is an RSA key an OpenID provider signs its JWTs with. N is the
+// base64url modulus, as in the provider's JWK set.
+type ZkLoginJWK struct {
+	Iss string
+	Kid string
+	N   string
+}
+
+// ParseZkLoginJWKs reads the RSA keys of a JWK set, such as the one Google
+// serves at https://www.googleapis.com/oauth2/v3/certs, issued by iss.
+func ParseZkLoginJWKs(iss string, data []byte) ([]ZkLoginJWK, error) {
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWK set: %w", err)
+	}
+
+	var jwks []ZkLoginJWK
+	for _, key := range set.Keys {
+		if key.Kty != "RSA" {
+			continue
+		}
+		jwks = append(jwks, ZkLoginJWK{Iss: iss, Kid: key.Kid, N: key.N})
+	}
+	return jwks, nil
+}
+
+// ZkLoginConfig is what a SuiVerifier checks zkLogin signatures against.
+//
+// zkLogin verification is experimental: it has not yet been tested against a
+// production signature, verifying key and provider JWK, so a valid mainnet
+// signature may still be rejected.
+type ZkLoginConfig struct {
+	// VerifyingKey is the Groth16 verifying key of the zkLogin circuit.
+	VerifyingKey *Groth16VerifyingKey
+	// JWKs are the keys of the OpenID providers the signatures may use.
+	JWKs []ZkLoginJWK
+	// CurrentEpoch rejects signatures whose max epoch has passed. Zero skips
+	// the check.
+	CurrentEpoch uint64
+}
+
+// SuiVerifier checks Sui serialized signatures of personal messages. The
+// zero value verifies single key and MultiSig signatures; ZkLogin must be
+// set to verify zkLogin signatures.
+type SuiVerifier struct {
+	ZkLogin *ZkLoginConfig
+}
+
+// VerifyMessage checks a serialized signature of message and reports
+// whether it belongs to walletAddress.
+func (v SuiVerifier) VerifyMessage(signature string, message, walletAddress string) (bool, error) {
+	digest := suiPersonalMessageDigest([]byte(message))
+
+	raw, err := base64.StdEncoding.DecodeString(signature)
+	if err == nil && len(raw) > 0 {
+		switch SigFlag(raw[0]) {
+		case SigFlagMultiSig:
+			return verifySuiMultiSig(raw[1:], digest[:], walletAddress)
+		case SigFlagZkLogin:
+			return v.verifyZkLogin(raw[1:], digest[:], walletAddress)
+		}
+	}
+
+	serializedSignature, err := parseSerializedSignature(signature)
+	if err != nil {
+		return false, err
+	}
+	pass, err := verifySuiSignature(serializedSignature.Flag, serializedSignature.PubKey, digest[:], serializedSignature.Signature)
+	if err != nil {
+		return false, err
+	}
+
+	if !pass {
+		return false, errors.New("signature verification failed")
+	}
+
+	derivedAddress := suiPublicKeyToAddress(serializedSignature.Flag, serializedSignature.PubKey)
+
+	return strings.EqualFold(derivedAddress, walletAddress), nil
+}
+
+// verifyZkLogin checks the zkLogin serialized signature, without its flag,
+// over digest and reports whether it belongs to walletAddress.
+func (v SuiVerifier) verifyZkLogin(serialized []byte, digest []byte, walletAddress string) (bool, error) {
+	config := v.ZkLogin
+	if config == nil || config.VerifyingKey == nil {
+		return false, errors.New("zkLogin verification is not configured")
+	}
+
+	var zkLogin ZkLoginSignature
+	if err := bcs.Unmarshal(serialized, &zkLogin); err != nil {
+		return false, fmt.Errorf("invalid zkLogin signature: %w", err)
+	}
+	if config.CurrentEpoch != 0 && zkLogin.MaxEpoch < config.CurrentEpoch {
+		return false, fmt.Errorf("zkLogin signature expired at epoch %d", zkLogin.MaxEpoch)
+	}
+
+	userSignature, err := parseSerializedSignature(base64.StdEncoding.EncodeToString(zkLogin.UserSignature))
+	if err != nil {
+		return false, fmt.Errorf("invalid zkLogin user signature: %w", err)
+	}
+	pass, err := verifySuiSignature(userSignature.Flag, userSignature.PubKey, digest, userSignature.Signature)
+	if err != nil {
+		return false, err
+	}
+	if !pass {
+		return false, errors.New("signature verification failed")
+	}
+
+	iss, err := zkLogin.Iss()
+	if err != nil {
+		return false, err
+	}
+	header, err := parseZkLoginHeader(zkLogin.Inputs.HeaderBase64)
+	if err != nil {
+		return false, err
+	}
+	var jwk *ZkLoginJWK
+	for i := range config.JWKs {
+		if config.JWKs[i].Iss == iss && config.JWKs[i].Kid == header.Kid {
+			jwk = &config.JWKs[i]
+			break
+		}
+	}
+	if jwk == nil {
+		return false, fmt.Errorf("no JWK for iss %q and kid %q", iss, header.Kid)
+	}
+	modulus, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(jwk.N, "="))
+	if err != nil {
+		return false, fmt.Errorf("invalid JWK modulus: %w", err)
+	}
+
+	inputsHash, err := zkLogin.PublicInputsHash(modulus)
+	if err != nil {
+		return false, err
+	}
+	a, b, c, err := parseZkLoginProof(zkLogin.Inputs.ProofPoints)
+	if err != nil {
+		return false, err
+	}
+	pass, err = config.VerifyingKey.verify(a, b, c, []*big.Int{inputsHash})
+	if err != nil {
+		return false, err
+	}
+	if !pass {
+		return false, errors.New("zkLogin proof verification failed")
+	}
+
+	address, err := ZkLoginAddress(iss, zkLogin.Inputs.AddressSeed)
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(address, walletAddress), nil
+}
+
+type zkLoginHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+func parseZkLoginHeader(headerBase64 string) (zkLoginHeader, error) {
+	var header zkLoginHeader
+	decoded, err := base64.RawURLEncoding.DecodeString(headerBase64)
+	if err != nil {
+		return header, fmt.Errorf("invalid zkLogin header: %w", err)
+	}
+	if err := json.Unmarshal(decoded, &header); err != nil {
+		return header, fmt.Errorf("invalid zkLogin header: %w", err)
+	}
+	if header.Alg != "RS256" {
+		return header, fmt.Errorf("unsupported zkLogin header alg %q", header.Alg)
+	}
+	return header, nil
+}
+
+func parseZkLoginProof(proof ZkLoginProofPoints) (a bn254.G1Affine, b bn254.G2Affine, c bn254.G1Affine, err error) {
+	if a, err = parseG1(proof.A); err != nil {
+		return a, b, c, fmt.Errorf("invalid zkLogin proof point a: %w", err)
+	}
+	if b, err = parseG2(proof.B); err != nil {
+		return a, b, c, fmt.Errorf("invalid zkLogin proof point b: %w", err)
+	}
+	if c, err = parseG1(proof.C); err != nil {
+		return a, b, c, fmt.Errorf("invalid zkLogin proof point c: %w", err)
+	}
+	return a, b, c, nil
+}
+
+// decodeZkLoginClaim decodes a slice of a base64url string that starts at
+// an index of claim.IndexMod4 modulo 4, dropping the bits of the characters
+// at either end that belong to its neighbours.
+func decodeZkLoginClaim(claim ZkLoginClaim) (string, error) {
+	value := claim.Value
+	if len(value) < 2 {
+		return "", errors.New("invalid zkLogin claim: too short")
+	}
+
+	var bits []byte
+	for _, char := range []byte(value) {
+		index := strings.IndexByte(base64URLAlphabet, char)
+		if index < 0 {
+			return "", fmt.Errorf("invalid zkLogin claim: %q is not base64url", char)
+		}
+		for i := 5; i >= 0; i-- {
+			bits = append(bits, byte(index>>i)&1)
+		}
+	}
+
+	switch claim.IndexMod4 % 4 {
+	case 0:
+	case 1:
+		bits = bits[2:]
+	case 2:
+		bits = bits[4:]
+	default:
+		return "", errors.New("invalid zkLogin claim: bad first character offset")
+	}
+	switch (int(claim.IndexMod4) + len(value) - 1) % 4 {
+	case 3:
+	case 2:
+		bits = bits[:len(bits)-2]
+	case 1:
+		bits = bits[:len(bits)-4]
+	default:
+		return "", errors.New("invalid zkLogin claim: bad last character offset")
+	}
+	if len(bits)%8 != 0 {
+		return "", errors.New("invalid zkLogin claim: not a whole number of bytes")
+	}
+
+	decoded := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		decoded[i/8] |= bit << (7 - i%8)
+	}
+	return string(decoded), nil
+}
+
+const base64URLAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+// parseZkLoginClaim reads the value of key from a claim such as
+// `"iss":"https://accounts.google.com",`, which ends in a comma or a brace.
+func parseZkLoginClaim(claim string, key string) (string, error) {
+	if !strings.HasSuffix(claim, ",") && !strings.HasSuffix(claim, "}") {
+		return "", errors.New("invalid zkLogin claim: must end with a comma or a brace")
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte("{"+claim[:len(claim)-1]+"}"), &fields); err != nil {
+		return "", fmt.Errorf("invalid zkLogin claim: %w", err)
+	}
+	raw, ok := fields[key]
+	if len(fields) != 1 || !ok {
+		return "", fmt.Errorf("invalid zkLogin claim: expected only %q", key)
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", fmt.Errorf("invalid zkLogin claim %q: %w", key, err)
+	}
+	return value, nil
+}
+
+func parseZkLoginField(value string) (*big.Int, error) {
+	field, ok := new(big.Int).SetString(value, 10)
+	if !ok || field.Sign() < 0 || field.Cmp(fr.Modulus()) >= 0 {
+		return nil, fmt.Errorf("invalid zkLogin field element %q", value)
+	}
+	return field, nil
+}
+
+// hashZkLoginASCII hashes value padded with zeros to maxLength bytes.
+func hashZkLoginASCII(value string, maxLength int) (*big.Int, error) {
+	if len(value) > maxLength {
+		return nil, fmt.Errorf("longer than %d characters", maxLength)
+	}
+	padded := make([]byte, maxLength)
+	copy(padded, value)
+	return hashZkLoginPacked(new(big.Int).SetBytes(padded), maxLength*8)
+}
+
+// hashZkLoginPacked splits the width bit value into 248 bit field elements,
+// counted from its least significant end, and hashes them most significant
+// first.
+func hashZkLoginPacked(value *big.Int, width int) (*big.Int, error) {
+	var packed []*big.Int
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), zkLoginPackWidth), big.NewInt(1))
+	for shift := 0; shift < width; shift += zkLoginPackWidth {
+		chunk := new(big.Int).Rsh(value, uint(shift))
+		packed = append([]*big.Int{chunk.And(chunk, mask)}, packed...)
+	}
+	return hashZkLoginFields(packed)
+}
+
+// hashZkLoginFields hashes up to 32 field elements, in two halves when
+// there are more than Poseidon takes at once.
+func hashZkLoginFields(inputs []*big.Int) (*big.Int, error) {
+	if len(inputs) <= poseidon.MaxInputs {
+		return poseidon.Hash(inputs)
+	}
+	if len(inputs) > 2*poseidon.MaxInputs {
+		return nil, fmt.Errorf("too many zkLogin inputs: %d", len(inputs))
+	}
+	first, err := poseidon.Hash(inputs[:poseidon.MaxInputs])
+	if err != nil {
+		return nil, err
+	}
+	second, err := poseidon.Hash(inputs[poseidon.MaxInputs:])
+	if err != nil {
+		return nil, err
+	}
+	return poseidon.Hash([]*big.Int{first, second})
+}
@@ -0,0 +1,339 @@
+package client_test
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/Gateway-DAO/gateway-go-sdk/bcs"
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	testZkLoginIss  = "https://accounts.google.com"
+	testZkLoginKid  = "test-kid"
+	testZkLoginSeed = "2455937816256448139867613186842212412342634251853591180313407437040347862108"
+)
+
+// toyGroth16 is a Groth16 setup over one public input whose trapdoor the
+// test knows, so it can forge proofs for any input.
+type toyGroth16 struct {
+	alpha, beta, gamma, delta, ic0, ic1 *big.Int
+}
+
+func newToyGroth16(t *testing.T) toyGroth16 {
+	t.Helper()
+	scalar := func() *big.Int {
+		s, err := rand.Int(rand.Reader, fr.Modulus())
+		require.NoError(t, err)
+		return s
+	}
+	return toyGroth16{alpha: scalar(), beta: scalar(), gamma: scalar(), delta: scalar(), ic0: scalar(), ic1: scalar()}
+}
+
+func g1Coordinates(s *big.Int) []string {
+	_, _, g1, _ := bn254.Generators()
+	var p bn254.G1Affine
+	p.ScalarMultiplication(&g1, s)
+	return []string{p.X.String(), p.Y.String(), "1"}
+}
+
+func g2Coordinates(s *big.Int) [][]string {
+	_, _, _, g2 := bn254.Generators()
+	var p bn254.G2Affine
+	p.ScalarMultiplication(&g2, s)
+	return [][]string{{p.X.A0.String(), p.X.A1.String()}, {p.Y.A0.String(), p.Y.A1.String()}, {"1", "0"}}
+}
+
+func (g toyGroth16) verifyingKey(t *testing.T) *gateway.Groth16VerifyingKey {
+	t.Helper()
+	data, err := json.Marshal(map[string]interface{}{
+		"protocol":   "groth16",
+		"curve":      "bn128",
+		"nPublic":    1,
+		"vk_alpha_1": g1Coordinates(g.alpha),
+		"vk_beta_2":  g2Coordinates(g.beta),
+		"vk_gamma_2": g2Coordinates(g.gamma),
+		"vk_delta_2": g2Coordinates(g.delta),
+		"IC":         [][]string{g1Coordinates(g.ic0), g1Coordinates(g.ic1)},
+	})
+	require.NoError(t, err)
+	vk, err := gateway.ParseGroth16VerifyingKey(data)
+	require.NoError(t, err)
+	return vk
+}
+
+// prove returns A = a, B = b and C = (ab - alpha*beta - vk_x*gamma) / delta,
+// in the exponent.
+func (g toyGroth16) prove(t *testing.T, input *big.Int) gateway.ZkLoginProofPoints {
+	t.Helper()
+	n := fr.Modulus()
+	a, b := big.NewInt(7), big.NewInt(11)
+
+	vkX := new(big.Int).Mul(input, g.ic1)
+	vkX.Add(vkX, g.ic0)
+	c := new(big.Int).Mul(a, b)
+	c.Sub(c, new(big.Int).Mul(g.alpha, g.beta))
+	c.Sub(c, vkX.Mul(vkX, g.gamma))
+	c.Mul(c, new(big.Int).ModInverse(g.delta, n))
+	c.Mod(c, n)
+
+	return gateway.ZkLoginProofPoints{A: g1Coordinates(a), B: g2Coordinates(b), C: g1Coordinates(c)}
+}
+
+// base64Claim returns the slice of the base64url encoding of payload that
+// covers claim, and the index of the slice modulo 4.
+func base64Claim(t *testing.T, payload string, claim string) gateway.ZkLoginClaim {
+	t.Helper()
+	start := strings.Index(payload, claim)
+	require.GreaterOrEqual(t, start, 0)
+	end := start + len(claim)
+
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	first, last := start*8/6, (end*8+5)/6
+	return gateway.ZkLoginClaim{Value: encoded[first:last], IndexMod4: uint8(first % 4)}
+}
+
+type zkLoginFixture struct {
+	setup     toyGroth16
+	config    *gateway.ZkLoginConfig
+	modulus   []byte
+	ephemeral *gateway.WalletService
+	address   string
+}
+
+func newZkLoginFixture(t *testing.T) zkLoginFixture {
+	t.Helper()
+	modulus := make([]byte, 256)
+	_, err := rand.Read(modulus)
+	require.NoError(t, err)
+	modulus[0] |= 0x80
+
+	ephemeral, err := gateway.NewWalletService(testSuiKey, gateway.Sui)
+	require.NoError(t, err)
+	address, err := gateway.ZkLoginAddress(testZkLoginIss, testZkLoginSeed)
+	require.NoError(t, err)
+
+	setup := newToyGroth16(t)
+	return zkLoginFixture{
+		setup: setup,
+		config: &gateway.ZkLoginConfig{
+			VerifyingKey: setup.verifyingKey(t),
+			JWKs: []gateway.ZkLoginJWK{
+				{Iss: testZkLoginIss, Kid: testZkLoginKid, N: base64.RawURLEncoding.EncodeToString(modulus)},
+			},
+		},
+		modulus:   modulus,
+		ephemeral: ephemeral,
+		address:   address,
+	}
+}
+
+// sign signs message with the ephemeral key and proves the zkLogin inputs.
+func (f zkLoginFixture) sign(t *testing.T, message string, edit func(*gateway.ZkLoginSignature)) string {
+	t.Helper()
+	signed, err := f.ephemeral.SignMessage(message)
+	require.NoError(t, err)
+	userSignature, err := base64.StdEncoding.DecodeString(signed.Signature)
+	require.NoError(t, err)
+
+	payload := `{"sub":"1234567890","iss":"` + testZkLoginIss + `","aud":"gateway","nonce":"abc"}`
+	header := `{"alg":"RS256","kid":"` + testZkLoginKid + `","typ":"JWT"}`
+	signature := gateway.ZkLoginSignature{
+		Inputs: gateway.ZkLoginInputs{
+			IssBase64Details: base64Claim(t, payload, `"iss":"`+testZkLoginIss+`",`),
+			HeaderBase64:     base64.RawURLEncoding.EncodeToString([]byte(header)),
+			AddressSeed:      testZkLoginSeed,
+		},
+		MaxEpoch:      42,
+		UserSignature: userSignature,
+	}
+
+	inputsHash, err := signature.PublicInputsHash(f.modulus)
+	require.NoError(t, err)
+	signature.Inputs.ProofPoints = f.setup.prove(t, inputsHash)
+	if edit != nil {
+		edit(&signature)
+	}
+	return signature.Serialize()
+}
+
+func TestZkLoginAddress(t *testing.T) {
+	seed, ok := new(big.Int).SetString(testZkLoginSeed, 10)
+	require.True(t, ok)
+	preimage := append([]byte{byte(gateway.SigFlagZkLogin), byte(len(testZkLoginIss))}, testZkLoginIss...)
+	preimage = append(preimage, seed.FillBytes(make([]byte, 32))...)
+	expected := blake2b.Sum256(preimage)
+
+	address, err := gateway.ZkLoginAddress(testZkLoginIss, testZkLoginSeed)
+	require.NoError(t, err)
+	assert.Equal(t, "0x"+hex.EncodeToString(expected[:]), address)
+
+	legacy, err := gateway.ZkLoginAddress("accounts.google.com", testZkLoginSeed)
+	require.NoError(t, err)
+	assert.Equal(t, address, legacy)
+
+	_, err = gateway.ZkLoginAddress(testZkLoginIss, fr.Modulus().String())
+	assert.Error(t, err)
+}
+
+func TestVerifySuiMessage_ZkLogin(t *testing.T) {
+	f := newZkLoginFixture(t)
+	verifier := gateway.SuiVerifier{ZkLogin: f.config}
+	signature := f.sign(t, "test message", nil)
+
+	valid, err := verifier.VerifyMessage(signature, "test message", f.address)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = verifier.VerifyMessage(signature, "test message", f.ephemeral.Wallet.(*gateway.SuiService).GetWallet())
+	require.NoError(t, err)
+	assert.False(t, valid, "the ephemeral key's own address is not the zkLogin address")
+
+	_, err = verifier.VerifyMessage(signature, "another message", f.address)
+	assert.ErrorContains(t, err, "signature verification failed")
+
+	_, err = gateway.VerifySuiMessage(signature, "test message", f.address)
+	assert.ErrorContains(t, err, "not configured")
+
+	// Single key signatures still verify.
+	signed, err := f.ephemeral.SignMessage("test message")
+	require.NoError(t, err)
+	valid, err = verifier.VerifyMessage(signed.Signature, "test message", signed.SigningKey)
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestVerifySuiMessage_ZkLoginRejects(t *testing.T) {
+	f := newZkLoginFixture(t)
+
+	for name, test := range map[string]struct {
+		edit   func(*gateway.ZkLoginSignature)
+		config func(*gateway.ZkLoginConfig)
+		err    string
+	}{
+		"tampered address seed": {
+			edit: func(s *gateway.ZkLoginSignature) { s.Inputs.AddressSeed = "1" },
+			err:  "proof verification failed",
+		},
+		"later max epoch": {
+			edit: func(s *gateway.ZkLoginSignature) { s.MaxEpoch++ },
+			err:  "proof verification failed",
+		},
+		"unknown kid": {
+			config: func(c *gateway.ZkLoginConfig) { c.JWKs[0].Kid = "other-kid" },
+			err:    "no JWK",
+		},
+		"another modulus": {
+			config: func(c *gateway.ZkLoginConfig) { c.JWKs[0].N = base64.RawURLEncoding.EncodeToString([]byte{1, 2, 3}) },
+			err:    "proof verification failed",
+		},
+		"expired": {
+			config: func(c *gateway.ZkLoginConfig) { c.CurrentEpoch = 43 },
+			err:    "expired",
+		},
+		"unsupported alg": {
+			edit: func(s *gateway.ZkLoginSignature) {
+				s.Inputs.HeaderBase64 = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","kid":"test-kid"}`))
+			},
+			err: "unsupported zkLogin header alg",
+		},
+		"claim other than iss": {
+			edit: func(s *gateway.ZkLoginSignature) {
+				s.Inputs.IssBase64Details = base64Claim(t, `{"sub":"1234567890"}`, `"sub":"1234567890"}`)
+			},
+			err: `expected only "iss"`,
+		},
+		"bad index": {
+			edit: func(s *gateway.ZkLoginSignature) { s.Inputs.IssBase64Details.IndexMod4 = 3 },
+			err:  "offset",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := *f.config
+			config.JWKs = append([]gateway.ZkLoginJWK(nil), f.config.JWKs...)
+			if test.config != nil {
+				test.config(&config)
+			}
+
+			signature := f.sign(t, "test message", test.edit)
+			_, err := gateway.SuiVerifier{ZkLogin: &config}.VerifyMessage(signature, "test message", f.address)
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestZkLoginSignature_RoundTrip(t *testing.T) {
+	f := newZkLoginFixture(t)
+	serialized, err := base64.StdEncoding.DecodeString(f.sign(t, "test message", nil))
+	require.NoError(t, err)
+	assert.Equal(t, byte(gateway.SigFlagZkLogin), serialized[0])
+
+	var signature gateway.ZkLoginSignature
+	require.NoError(t, bcs.Unmarshal(serialized[1:], &signature))
+	assert.Equal(t, testZkLoginSeed, signature.Inputs.AddressSeed)
+	assert.Equal(t, uint64(42), signature.MaxEpoch)
+
+	iss, err := signature.Iss()
+	require.NoError(t, err)
+	assert.Equal(t, testZkLoginIss, iss)
+
+	address, err := signature.Address()
+	require.NoError(t, err)
+	assert.Equal(t, f.address, address)
+}
+
+func TestParseZkLoginJWKs(t *testing.T) {
+	jwks, err := gateway.ParseZkLoginJWKs(testZkLoginIss, []byte(`{"keys":[
+		{"kty":"RSA","kid":"one","n":"AQAB","e":"AQAB","alg":"RS256"},
+		{"kty":"EC","kid":"two","crv":"P-256"}
+	]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []gateway.ZkLoginJWK{{Iss: testZkLoginIss, Kid: "one", N: "AQAB"}}, jwks)
+
+	_, err = gateway.ParseZkLoginJWKs(testZkLoginIss, []byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestParseGroth16VerifyingKey_Invalid(t *testing.T) {
+	valid := newToyGroth16(t)
+	key := map[string]interface{}{
+		"protocol":   "groth16",
+		"vk_alpha_1": g1Coordinates(valid.alpha),
+		"vk_beta_2":  g2Coordinates(valid.beta),
+		"vk_gamma_2": g2Coordinates(valid.gamma),
+		"vk_delta_2": g2Coordinates(valid.delta),
+		"IC":         [][]string{g1Coordinates(valid.ic0)},
+	}
+
+	for name, edit := range map[string]func(){
+		"plonk":        func() { key["protocol"] = "plonk" },
+		"no IC":        func() { key["IC"] = [][]string{} },
+		"off curve":    func() { key["vk_alpha_1"] = []string{"1", "3"} },
+		"not a number": func() { key["vk_alpha_1"] = []string{"x", "y"} },
+		"G2 shape":     func() { key["vk_beta_2"] = [][]string{{"1"}, {"2"}} },
+	} {
+		t.Run(name, func(t *testing.T) {
+			original := map[string]interface{}{}
+			for k, v := range key {
+				original[k] = v
+			}
+			defer func() { key = original }()
+
+			edit()
+			data, err := json.Marshal(key)
+			require.NoError(t, err)
+			_, err = gateway.ParseGroth16VerifyingKey(data)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// TracerName is the instrumentation scope of the spans the SDK creates.
+const TracerName = "github.com/Gateway-DAO/gateway-go-sdk/client"
+
+// Span attributes for the resource an operation works on.
+const (
+	DataAssetIDKey      = attribute.Key("gateway.data_asset.id")
+	DataModelIDKey      = attribute.Key("gateway.data_model.id")
+	ComputeRequestIDKey = attribute.Key("gateway.compute_request.id")
+)
+
+// tracing creates a client span per SDK operation and propagates it to the
+// API.
+type tracing struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// installTracing traces the requests of client with provider. It must run
+// before the authentication middleware so that signing in is traced as a
+// child of the operation that needed it. A nil propagator sends W3C
+// traceparent headers.
+func installTracing(client *resty.Client, provider trace.TracerProvider, propagator propagation.TextMapPropagator) {
+	if propagator == nil {
+		propagator = propagation.TraceContext{}
+	}
+
+	t := &tracing{
+		tracer:     provider.Tracer(TracerName),
+		propagator: propagator,
+	}
+
+	client.OnBeforeRequest(t.start)
+	client.OnSuccess(t.success)
+	client.OnError(t.failure)
+}
+
+type requestSpanKey struct{}
+
+type requestSpan struct {
+	request *resty.Request
+	span    trace.Span
+}
+
+type tracerKey struct{}
+
+func (t *tracing) start(_ *resty.Client, r *resty.Request) error {
+	ctx := r.Context()
+	if span := spanFor(r); span != nil {
+		span.SetAttributes(semconv.HTTPRequestResendCount(r.Attempt - 1))
+		return nil
+	}
+
+	operation := operationFrom(ctx)
+	name := operation
+	if name == "" {
+		name = r.Method
+	}
+
+	attributes := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(r.Method),
+		semconv.HTTPRoute(r.URL),
+	}
+	if id, ok := r.PathParams["id"]; ok {
+		if key, ok := resourceIDKey(operation); ok {
+			attributes = append(attributes, key.String(id))
+		}
+	}
+
+	ctx, span := t.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+	ctx = context.WithValue(ctx, requestSpanKey{}, &requestSpan{request: r, span: span})
+	ctx = context.WithValue(ctx, tracerKey{}, t.tracer)
+	r.SetContext(ctx)
+
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(r.Header))
+
+	return nil
+}
+
+func (t *tracing) success(_ *resty.Client, res *resty.Response) {
+	span := spanFor(res.Request)
+	if span == nil {
+		return
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(res.StatusCode()))
+	if res.IsError() {
+		span.SetStatus(codes.Error, http.StatusText(res.StatusCode()))
+	}
+	span.End()
+}
+
+func (t *tracing) failure(r *resty.Request, err error) {
+	span := spanFor(r)
+	if span == nil {
+		return
+	}
+
+	var responseErr *resty.ResponseError
+	if errors.As(err, &responseErr) {
+		err = responseErr.Err
+		if responseErr.Response != nil && responseErr.Response.RawResponse != nil {
+			span.SetAttributes(semconv.HTTPResponseStatusCode(responseErr.Response.StatusCode()))
+		}
+	}
+
+	endSpan(span, err)
+}
+
+// spanFor returns the span of r, skipping the one of an enclosing request.
+func spanFor(r *resty.Request) trace.Span {
+	if s, ok := r.Context().Value(requestSpanKey{}).(*requestSpan); ok && s.request == r {
+		return s.span
+	}
+	return nil
+}
+
+// resourceIDKey returns the attribute that holds the id path parameter of
+// operation.
+func resourceIDKey(operation string) (attribute.Key, bool) {
+	switch {
+	case strings.HasPrefix(operation, "DataAsset."), strings.HasPrefix(operation, "ACL."):
+		return DataAssetIDKey, true
+	case strings.HasPrefix(operation, "DataModel."):
+		return DataModelIDKey, true
+	case strings.HasPrefix(operation, "ComputeRequest."):
+		return ComputeRequestIDKey, true
+	}
+	return "", false
+}
+
+// startSpan starts a span for a step of an SDK operation that does not
+// send a request itself, such as signing the login message. It is a no-op
+// when tracing is off.
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	tracer, ok := ctx.Value(tracerKey{}).(trace.Tracer)
+	if !ok {
+		tracer = noop.Tracer{}
+	}
+	return tracer.Start(ctx, name)
+}
+
+// endSpan ends span, recording err when it is not nil.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
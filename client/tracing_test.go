@@ -0,0 +1,159 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/Gateway-DAO/gateway-go-sdk/gatewaytest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTracerProvider() (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	return sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)), exporter
+}
+
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attributes := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes {
+		attributes[kv.Key] = kv.Value
+	}
+	return attributes
+}
+
+func TestTracing_SpanPerOperation(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"roles": ["view"]}`))
+	}))
+	defer server.Close()
+
+	provider, exporter := newTracerProvider()
+	sdk, err := gateway.New(
+		gateway.WithAPIKey("test-key"),
+		gateway.WithBaseURL(server.URL),
+		gateway.WithTracerProvider(provider),
+	)
+	require.NoError(t, err)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	_, err = sdk.ACL.AddCtx(ctx, 5, []gateway.ACLRequest{{Address: "0x1"}})
+	parent.End()
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	span := spans[0]
+
+	assert.Equal(t, "ACL.Add", span.Name)
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+	assert.Equal(t, codes.Unset, span.Status.Code)
+
+	attributes := spanAttributes(span)
+	assert.Equal(t, gateway.AssignACLItemsToDataAsset, attributes["http.route"].AsString())
+	assert.Equal(t, http.MethodPost, attributes["http.request.method"].AsString())
+	assert.Equal(t, int64(http.StatusOK), attributes["http.response.status_code"].AsInt64())
+	assert.Equal(t, "5", attributes[gateway.DataAssetIDKey].AsString())
+
+	expected := "00-" + span.SpanContext.TraceID().String() + "-" + span.SpanContext.SpanID().String() + "-01"
+	assert.Equal(t, expected, traceparent)
+}
+
+func TestTracing_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "data model not found"}`))
+	}))
+	defer server.Close()
+
+	provider, exporter := newTracerProvider()
+	sdk, err := gateway.New(
+		gateway.WithAPIKey("test-key"),
+		gateway.WithBaseURL(server.URL),
+		gateway.WithTracerProvider(provider),
+	)
+	require.NoError(t, err)
+
+	_, err = sdk.DataModel.GetById(9)
+	assert.ErrorIs(t, err, gateway.ErrNotFound)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "DataModel.GetById", spans[0].Name)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+
+	attributes := spanAttributes(spans[0])
+	assert.Equal(t, int64(http.StatusNotFound), attributes["http.response.status_code"].AsInt64())
+	assert.Equal(t, "9", attributes[gateway.DataModelIDKey].AsString())
+}
+
+func TestTracing_WalletLoginIsChildOfOperation(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	wallet := gateway.WalletDetails{PrivateKey: testEthereumKey, WalletType: gateway.Ethereum}
+	_, err := srv.RegisterWallet("alice", wallet)
+	require.NoError(t, err)
+
+	provider, exporter := newTracerProvider()
+	sdk, err := gateway.New(
+		gateway.WithWallet(wallet.PrivateKey, wallet.WalletType),
+		gateway.WithBaseURL(srv.URL),
+		gateway.WithTracerProvider(provider),
+	)
+	require.NoError(t, err)
+
+	_, err = sdk.Account.GetMe()
+	require.NoError(t, err)
+
+	byName := make(map[string]tracetest.SpanStub)
+	for _, span := range exporter.GetSpans() {
+		byName[span.Name] = span
+	}
+	require.Len(t, byName, 5)
+
+	operation := byName["Accounts.GetMe"]
+	issue := byName["Auth.IssueJWT"]
+	assert.False(t, operation.Parent.IsValid())
+	assert.Equal(t, operation.SpanContext.SpanID(), issue.Parent.SpanID())
+	for _, name := range []string{"Auth.GetMessage", "Wallet.SignMessage", "Auth.Login"} {
+		assert.Equal(t, issue.SpanContext.SpanID(), byName[name].Parent.SpanID(), name)
+		assert.Equal(t, operation.SpanContext.TraceID(), byName[name].SpanContext.TraceID(), name)
+	}
+}
+
+func TestTracing_OffByDefault(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	provider, exporter := newTracerProvider()
+	sdk, err := gateway.New(gateway.WithAPIKey("test-key"), gateway.WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	_, err = sdk.Account.GetMeCtx(ctx)
+	parent.End()
+	require.NoError(t, err)
+
+	assert.Empty(t, traceparent)
+	assert.Len(t, exporter.GetSpans(), 1)
+}
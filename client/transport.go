@@ -0,0 +1,163 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// TransportConfig tunes the *http.Transport the SDK sends requests with.
+// Zero fields keep the defaults of the transport being tuned.
+type TransportConfig struct {
+	// Proxy is the URL of the proxy every request goes through, e.g.
+	// "http://proxy.internal:3128". Empty uses the HTTP_PROXY, HTTPS_PROXY
+	// and NO_PROXY environment variables.
+	Proxy string
+	// TLSConfig is the base TLS configuration; it is cloned, never changed.
+	TLSConfig *tls.Config
+	// ClientCertificates are presented to servers asking for mutual TLS.
+	ClientCertificates []tls.Certificate
+	// RootCAs replaces the system roots for verifying the server.
+	RootCAs *x509.CertPool
+
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
+}
+
+func (c *TransportConfig) validate() error {
+	if c.Proxy != "" {
+		proxy, err := url.Parse(c.Proxy)
+		if err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		switch proxy.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return fmt.Errorf("invalid proxy URL %q: unsupported scheme %q", c.Proxy, proxy.Scheme)
+		}
+		if proxy.Host == "" {
+			return fmt.Errorf("invalid proxy URL %q: missing host", c.Proxy)
+		}
+	}
+
+	if c.MaxIdleConns < 0 || c.MaxIdleConnsPerHost < 0 || c.MaxConnsPerHost < 0 || c.IdleConnTimeout < 0 {
+		return errors.New("invalid connection pool limits: must not be negative")
+	}
+
+	return nil
+}
+
+// apply tunes transport according to c.
+func (c *TransportConfig) apply(transport *http.Transport) {
+	if c.Proxy != "" {
+		proxy, _ := url.Parse(c.Proxy)
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if c.TLSConfig != nil || len(c.ClientCertificates) > 0 || c.RootCAs != nil {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if c.TLSConfig != nil {
+			tlsConfig = c.TLSConfig.Clone()
+		} else if transport.TLSClientConfig != nil {
+			tlsConfig = transport.TLSClientConfig.Clone()
+		}
+		tlsConfig.Certificates = append(tlsConfig.Certificates, c.ClientCertificates...)
+		if c.RootCAs != nil {
+			tlsConfig.RootCAs = c.RootCAs
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	if c.MaxIdleConns > 0 {
+		transport.MaxIdleConns = c.MaxIdleConns
+	}
+	if c.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = c.MaxIdleConnsPerHost
+	}
+	if c.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = c.MaxConnsPerHost
+	}
+	if c.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = c.IdleConnTimeout
+	}
+}
+
+// usesCustomHTTP reports whether config asks for anything but the default
+// HTTP client.
+func (config SDKConfig) usesCustomHTTP() bool {
+	return config.HTTPClient != nil || config.RoundTripper != nil || config.Transport != nil
+}
+
+// newHTTPClient builds the HTTP client described by config. The caller's
+// client and transport are copied, never changed, so they can be shared
+// across SDK instances.
+func newHTTPClient(config SDKConfig) (*http.Client, error) {
+	httpClient := &http.Client{}
+	if config.HTTPClient != nil {
+		clientCopy := *config.HTTPClient
+		httpClient = &clientCopy
+	}
+
+	if config.RoundTripper != nil {
+		if config.Transport != nil {
+			return nil, errors.New("RoundTripper and Transport cannot be combined; tune the RoundTripper directly")
+		}
+		httpClient.Transport = config.RoundTripper
+		return httpClient, nil
+	}
+
+	if config.Transport == nil {
+		return httpClient, nil
+	}
+
+	if err := config.Transport.validate(); err != nil {
+		return nil, err
+	}
+
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	transport, ok := base.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("Transport settings need an *http.Transport, the HTTP client uses %T", base)
+	}
+
+	transport = transport.Clone()
+	config.Transport.apply(transport)
+	httpClient.Transport = transport
+
+	return httpClient, nil
+}
+
+// LoadClientCertificate reads a PEM encoded certificate and key pair for
+// mutual TLS.
+func LoadClientCertificate(certFile string, keyFile string) (tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("loading client certificate: %w", err)
+	}
+	return cert, nil
+}
+
+// LoadRootCAs reads PEM encoded CA certificates into a pool.
+func LoadRootCAs(pemFiles ...string) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	for _, file := range pemFiles {
+		pem, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("loading root CAs: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("loading root CAs: no certificates found in %s", file)
+		}
+	}
+	return pool, nil
+}
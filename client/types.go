@@ -1,16 +1,10 @@
 package client
 
-import "github.com/go-resty/resty/v2"
-
 type WalletSignMessageType struct {
 	Signature  string
 	SigningKey string
 }
 
-type Config struct {
-	Client *resty.Client
-}
-
 type Error struct {
 	Error string
 }
@@ -0,0 +1,236 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ProgressFunc reports how many bytes of file content have been sent.
+// total is -1 when the size of the content is not known.
+type ProgressFunc func(transferred int64, total int64)
+
+type UploadOptions struct {
+	// Size is the length of the file content in bytes, or 0 when unknown.
+	Size           int64
+	ACL            *[]ACLRequest
+	ExpirationDate *time.Time
+	Progress       ProgressFunc
+}
+
+type progressReader struct {
+	reader      io.Reader
+	transferred int64
+	total       int64
+	progress    ProgressFunc
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.reader.Read(b)
+	if n > 0 {
+		p.transferred += int64(n)
+		p.progress(p.transferred, p.total)
+	}
+	return n, err
+}
+
+func uploadFormData(aclList *[]ACLRequest, expirationDate *time.Time) (map[string]string, error) {
+	formData := make(map[string]string)
+
+	if aclList != nil {
+		aclJSON, err := json.Marshal(aclList)
+		if err != nil {
+			return nil, err
+		}
+		formData["acl"] = string(aclJSON)
+	}
+
+	if expirationDate != nil {
+		formData["expiration_date"] = toRFC3339(*expirationDate)
+	}
+
+	return formData, nil
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+type uploadStream struct {
+	body        io.Reader
+	contentType string
+	// length is the size of the whole multipart body, or -1 when unknown.
+	length int64
+	// rewind resets the stream so the upload can be sent again. It is nil
+	// when the content cannot be replayed.
+	rewind func() error
+	// sent counts the bytes of file content read by the last attempt.
+	sent atomic.Int64
+}
+
+func (s *uploadStream) Read(p []byte) (int, error) {
+	return s.body.Read(p)
+}
+
+// Close lets the stream be the body of the outgoing *http.Request, where the
+// retry hook finds it. The caller owns the file content.
+func (s *uploadStream) Close() error {
+	return nil
+}
+
+// failedReader fails every read with err, such as an upload whose content
+// could not be rewound.
+type failedReader struct {
+	err error
+}
+
+func (r failedReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+// newUploadStream lays out the multipart upload body around content without
+// reading it into memory. Only the form fields and part headers are buffered;
+// the file content is read as the request body is sent.
+func newUploadStream(fileName string, content io.Reader, options UploadOptions) (*uploadStream, error) {
+	formData, err := uploadFormData(options.ACL, options.ExpirationDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	keys := make([]string, 0, len(formData))
+	for key := range formData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := writer.WriteField(key, formData[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	seeker, seekable := content.(io.Seeker)
+	var offset int64
+	if seekable {
+		if offset, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			seekable = false
+		}
+	}
+
+	reader := bufio.NewReaderSize(content, 512)
+	head, err := reader.Peek(512)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="data"; filename="%s"`, quoteEscaper.Replace(fileName)))
+	header.Set("Content-Type", http.DetectContentType(head))
+	if _, err := writer.CreatePart(header); err != nil {
+		return nil, err
+	}
+
+	prefix := append([]byte(nil), buf.Bytes()...)
+	buf.Reset()
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	suffix := append([]byte(nil), buf.Bytes()...)
+
+	total := options.Size
+	if total <= 0 {
+		total = -1
+	}
+
+	length := int64(-1)
+	if total > 0 {
+		length = int64(len(prefix)) + total + int64(len(suffix))
+	}
+
+	stream := &uploadStream{
+		contentType: writer.FormDataContentType(),
+		length:      length,
+	}
+
+	assemble := func(content io.Reader) io.Reader {
+		content = &progressReader{reader: content, total: total, progress: func(transferred int64, total int64) {
+			stream.sent.Store(transferred)
+			if options.Progress != nil {
+				options.Progress(transferred, total)
+			}
+		}}
+		stream.sent.Store(0)
+		return io.MultiReader(bytes.NewReader(prefix), content, bytes.NewReader(suffix))
+	}
+	stream.body = assemble(reader)
+
+	if seekable {
+		stream.rewind = func() error {
+			if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+				return err
+			}
+			stream.body = assemble(content)
+			return nil
+		}
+	}
+
+	return stream, nil
+}
+
+// uploadRequest returns a request on client that sends stream as its body.
+// resty reads io.Reader bodies fully into memory, so the request is made on
+// a copy of client whose pre-request hook makes the stream the body of the
+// outgoing request. The copy shares the middlewares, hooks and transport of
+// client, which is left untouched.
+func uploadRequest(client *resty.Client, ctx context.Context, stream *uploadStream) *resty.Request {
+	upload := client.Clone().SetPreRequestHook(func(_ *resty.Client, req *http.Request) error {
+		req.Body = stream
+		req.GetBody = nil
+		req.ContentLength = stream.length
+		return nil
+	})
+	return upload.R().SetContext(ctx).SetHeader("Content-Type", stream.contentType)
+}
+
+// sentUploadStream returns the upload stream attempt sent as its body.
+func sentUploadStream(attempt *resty.Request) (*uploadStream, bool) {
+	if attempt.RawRequest == nil {
+		return nil, false
+	}
+	stream, ok := attempt.RawRequest.Body.(*uploadStream)
+	return stream, ok
+}
+
+// openUploadFile opens path for streaming and fills in options.Size from the
+// file when the caller did not set it.
+func openUploadFile(path string, options UploadOptions) (*os.File, UploadOptions, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, options, err
+	}
+
+	if options.Size == 0 {
+		info, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, options, err
+		}
+		options.Size = info.Size()
+	}
+
+	return file, options, nil
+}
@@ -0,0 +1,210 @@
+package client_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type receivedUpload struct {
+	method   string
+	path     string
+	fields   map[string]string
+	fileName string
+	content  []byte
+	length   int64
+}
+
+func newUploadServer(t *testing.T, received chan<- receivedUpload, afterFirstChunk func()) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upload := receivedUpload{method: r.Method, path: r.URL.Path, fields: map[string]string{}, length: r.ContentLength}
+
+		reader, err := r.MultipartReader()
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			if part.FormName() != "data" {
+				value, _ := io.ReadAll(part)
+				upload.fields[part.FormName()] = string(value)
+				continue
+			}
+
+			upload.fileName = part.FileName()
+			if afterFirstChunk != nil {
+				chunk := make([]byte, 1024)
+				_, err := io.ReadFull(part, chunk)
+				assert.NoError(t, err)
+				upload.content = append(upload.content, chunk...)
+				afterFirstChunk()
+			}
+			rest, err := io.ReadAll(part)
+			assert.NoError(t, err)
+			upload.content = append(upload.content, rest...)
+		}
+
+		received <- upload
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": 7, "name": upload.fileName})
+	}))
+}
+
+func TestUploadFileReader(t *testing.T) {
+	received := make(chan receivedUpload, 1)
+	server := newUploadServer(t, received, nil)
+	defer server.Close()
+
+	dataAssetImpl := gateway.NewDataAssetImpl(gateway.Config{Client: resty.New().SetBaseURL(server.URL)})
+
+	content := bytes.Repeat([]byte("gateway"), 10000)
+	expirationDate := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	aclList := []gateway.ACLRequest{
+		{Address: "did:gatewayid:test", Roles: []gateway.TypesAccessLevel{gateway.RoleView}},
+	}
+
+	var lastTransferred, lastTotal int64
+	result, err := dataAssetImpl.UploadFileReader("export.txt", bytes.NewReader(content), gateway.UploadOptions{
+		Size:           int64(len(content)),
+		ACL:            &aclList,
+		ExpirationDate: &expirationDate,
+		Progress: func(transferred int64, total int64) {
+			assert.GreaterOrEqual(t, transferred, lastTransferred)
+			lastTransferred, lastTotal = transferred, total
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, result.Id)
+	assert.Equal(t, int64(len(content)), lastTransferred)
+	assert.Equal(t, int64(len(content)), lastTotal)
+
+	upload := <-received
+	assert.Equal(t, http.MethodPost, upload.method)
+	assert.Equal(t, gateway.CreateANewDataAsset, upload.path)
+	assert.Equal(t, "export.txt", upload.fileName)
+	assert.Equal(t, content, upload.content)
+	assert.Equal(t, "2030-01-02T03:04:05Z", upload.fields["expiration_date"])
+	assert.JSONEq(t, `[{"address": "did:gatewayid:test", "roles": ["view"]}]`, upload.fields["acl"])
+}
+
+func TestUploadFileReaderStreams(t *testing.T) {
+	for name, newDataAssets := range map[string]func(url string) gateway.DataAsset{
+		"sdk": func(url string) gateway.DataAsset {
+			return gateway.NewSDK(gateway.SDKConfig{ApiKey: "test-api-key", URL: url}).DataAssets
+		},
+		"config": func(url string) gateway.DataAsset {
+			client := resty.New().SetBaseURL(url).SetPreRequestHook(func(*resty.Client, *http.Request) error { return nil })
+			return gateway.NewDataAssetImpl(gateway.Config{Client: client})
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			firstChunkReceived := make(chan struct{})
+			received := make(chan receivedUpload, 1)
+			server := newUploadServer(t, received, func() { close(firstChunkReceived) })
+			defer server.Close()
+
+			dataAssets := newDataAssets(server.URL)
+
+			pipeReader, pipeWriter := io.Pipe()
+			go func() {
+				pipeWriter.Write(bytes.Repeat([]byte("a"), 4096))
+				select {
+				case <-firstChunkReceived:
+					pipeWriter.Write([]byte("tail"))
+					pipeWriter.Close()
+				case <-time.After(5 * time.Second):
+					pipeWriter.CloseWithError(io.ErrUnexpectedEOF)
+				}
+			}()
+
+			var lastTotal int64
+			_, err := dataAssets.UploadFileReader("stream.bin", pipeReader, gateway.UploadOptions{
+				Progress: func(transferred int64, total int64) { lastTotal = total },
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, int64(-1), lastTotal)
+
+			upload := <-received
+			assert.Len(t, upload.content, 4100)
+			assert.Equal(t, "tail", string(upload.content[4096:]))
+		})
+	}
+}
+
+func TestUploadFileReaderSendsLength(t *testing.T) {
+	received := make(chan receivedUpload, 1)
+	server := newUploadServer(t, received, nil)
+	defer server.Close()
+
+	sdk := gateway.NewSDK(gateway.SDKConfig{ApiKey: "test-api-key", URL: server.URL})
+
+	_, err := sdk.DataAssets.UploadFileReader("sized.bin", io.MultiReader(bytes.NewReader([]byte("sized"))), gateway.UploadOptions{Size: 5})
+	assert.NoError(t, err)
+
+	upload := <-received
+	assert.Equal(t, "sized", string(upload.content))
+	assert.Greater(t, upload.length, int64(5), "the whole multipart body is announced")
+
+	_, err = sdk.DataAssets.UploadFileReader("unsized.bin", io.MultiReader(bytes.NewReader([]byte("unsized"))), gateway.UploadOptions{})
+	assert.NoError(t, err)
+
+	upload = <-received
+	assert.Equal(t, "unsized", string(upload.content))
+	assert.Equal(t, int64(-1), upload.length)
+}
+
+func TestUpdateFilePath(t *testing.T) {
+	received := make(chan receivedUpload, 1)
+	server := newUploadServer(t, received, nil)
+	defer server.Close()
+
+	dataAssetImpl := gateway.NewDataAssetImpl(gateway.Config{Client: resty.New().SetBaseURL(server.URL)})
+
+	path := filepath.Join(t.TempDir(), "report.csv")
+	assert.NoError(t, os.WriteFile(path, []byte("name,age\nalice,30\n"), 0o600))
+
+	var lastTotal int64
+	result, err := dataAssetImpl.UpdateFilePath("7", path, gateway.UploadOptions{
+		Progress: func(transferred int64, total int64) { lastTotal = total },
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, result.Id)
+	assert.Equal(t, int64(18), lastTotal)
+
+	upload := <-received
+	assert.Equal(t, http.MethodPut, upload.method)
+	assert.Equal(t, "/data-assets/7", upload.path)
+	assert.Equal(t, "report.csv", upload.fileName)
+	assert.Equal(t, "name,age\nalice,30\n", string(upload.content))
+	assert.Empty(t, upload.fields)
+}
+
+func TestUploadFilePathMissingFile(t *testing.T) {
+	dataAssetImpl := gateway.NewDataAssetImpl(gateway.Config{Client: resty.New()})
+
+	_, err := dataAssetImpl.UploadFilePath(filepath.Join(t.TempDir(), "missing"), gateway.UploadOptions{})
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNoVerifier is returned by logins whose wallet address or chain no
+// registered Verifier handles.
+var ErrNoVerifier = errors.New("no verifier for the wallet")
+
+// Verifier checks the signatures the wallets of one chain sign in with.
+type Verifier interface {
+	// ValidateAddress reports whether address is a wallet address of the
+	// chain.
+	ValidateAddress(address string) bool
+	// VerifyMessage checks signature of message and reports whether it was
+	// signed by walletAddress.
+	VerifyMessage(signature string, message, walletAddress string) (bool, error)
+}
+
+// EthereumVerifier checks Ethereum personal_sign signatures.
+type EthereumVerifier struct{}
+
+func (EthereumVerifier) ValidateAddress(address string) bool {
+	return ValidateEtherumWallet(address)
+}
+
+func (EthereumVerifier) VerifyMessage(signature string, message, walletAddress string) (bool, error) {
+	return VerifyEtherumMessage(signature, message, walletAddress)
+}
+
+// SolanaVerifier checks base58 ED25519 signatures of Solana wallets.
+type SolanaVerifier struct{}
+
+func (SolanaVerifier) ValidateAddress(address string) bool {
+	return ValidateSolanaWallet(address)
+}
+
+func (SolanaVerifier) VerifyMessage(signature string, message, walletAddress string) (bool, error) {
+	return VerifySolanaMessage(message, signature, walletAddress)
+}
+
+func (SuiVerifier) ValidateAddress(address string) bool {
+	return ValidateSuiWallet(address)
+}
+
+// VerifierRegistry holds a Verifier per chain. Logins without a chain use
+// the first Verifier, in the order they were registered, that validates the
+// wallet address. It is safe for concurrent use.
+type VerifierRegistry struct {
+	mu        sync.RWMutex
+	chains    []WalletTypeEnum
+	verifiers map[WalletTypeEnum]Verifier
+}
+
+// NewVerifierRegistry returns an empty registry.
+func NewVerifierRegistry() *VerifierRegistry {
+	return &VerifierRegistry{verifiers: map[WalletTypeEnum]Verifier{}}
+}
+
+// DefaultVerifierRegistry returns a registry of the verifiers of Ethereum,
+// Sui and Solana, tried in that order. The Sui verifier does not accept
+// zkLogin signatures; register a SuiVerifier with a ZkLoginConfig for them.
+func DefaultVerifierRegistry() *VerifierRegistry {
+	registry := NewVerifierRegistry()
+	registry.Register(Ethereum, EthereumVerifier{})
+	registry.Register(Sui, SuiVerifier{})
+	registry.Register(Solana, SolanaVerifier{})
+	return registry
+}
+
+// Register sets the Verifier of chain. A chain registered again keeps its
+// place in the order.
+func (r *VerifierRegistry) Register(chain WalletTypeEnum, verifier Verifier) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.verifiers[chain]; !ok {
+		r.chains = append(r.chains, chain)
+	}
+	r.verifiers[chain] = verifier
+}
+
+// Lookup returns the Verifier of chain.
+func (r *VerifierRegistry) Lookup(chain WalletTypeEnum) (Verifier, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	verifier, ok := r.verifiers[chain]
+	return verifier, ok
+}
+
+// Detect returns the first chain, in the order of registration, whose
+// Verifier validates address.
+func (r *VerifierRegistry) Detect(address string) (WalletTypeEnum, Verifier, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, chain := range r.chains {
+		if verifier := r.verifiers[chain]; verifier.ValidateAddress(address) {
+			return chain, verifier, true
+		}
+	}
+	return "", nil, false
+}
+
+// Chains returns the registered chains in order.
+func (r *VerifierRegistry) Chains() []WalletTypeEnum {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]WalletTypeEnum(nil), r.chains...)
+}
+
+// verifiers returns the registry of config, or the default one when it is
+// nil.
+func (config Config) verifiers() *VerifierRegistry {
+	if config.Verifiers == nil {
+		return DefaultVerifierRegistry()
+	}
+	return config.Verifiers
+}
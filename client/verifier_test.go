@@ -0,0 +1,226 @@
+package client_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/Gateway-DAO/gateway-go-sdk/gatewaytest"
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testEthereumAddress = "0x125b968F9ac42F33b0e1f1FBEbeE016Ca24A7116"
+	testSolanaAddress   = "AqzrrxaBCXRsq2BaY32djAp38B42asRRahbsYvD5uvSF"
+)
+
+// newLoginServer answers every login with a token and counts them.
+func newLoginServer(t *testing.T) (*httptest.Server, *int32) {
+	t.Helper()
+	var logins int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&logins, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"token": "test-token"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &logins
+}
+
+func newVerifyingAuth(server *httptest.Server, registry *gateway.VerifierRegistry, strict bool) *gateway.AuthImpl {
+	return gateway.NewAuthImpl(gateway.Config{
+		Client:             resty.New().SetBaseURL(server.URL),
+		Verifiers:          registry,
+		StrictVerification: strict,
+	})
+}
+
+func signSui(t *testing.T, message string) gateway.WalletSignMessageType {
+	t.Helper()
+	wallet, err := gateway.NewWalletService(testSuiKey, gateway.Sui)
+	require.NoError(t, err)
+	signed, err := wallet.SignMessage(message)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestValidateSuiWallet(t *testing.T) {
+	signed := signSui(t, "test message")
+	assert.True(t, gateway.ValidateSuiWallet(signed.SigningKey))
+	assert.False(t, gateway.ValidateSuiWallet(testEthereumAddress))
+	assert.False(t, gateway.ValidateSuiWallet(testSolanaAddress))
+}
+
+func TestDefaultVerifierRegistry_Detect(t *testing.T) {
+	registry := gateway.DefaultVerifierRegistry()
+	assert.Equal(t, []gateway.WalletTypeEnum{gateway.Ethereum, gateway.Sui, gateway.Solana}, registry.Chains())
+
+	for address, expected := range map[string]gateway.WalletTypeEnum{
+		testEthereumAddress:                   gateway.Ethereum,
+		signSui(t, "test message").SigningKey: gateway.Sui,
+		testSolanaAddress:                     gateway.Solana,
+	} {
+		chain, _, ok := registry.Detect(address)
+		require.True(t, ok, address)
+		assert.Equal(t, expected, chain, address)
+	}
+
+	_, _, ok := registry.Detect("mock-signing-key")
+	assert.False(t, ok)
+}
+
+type fixedVerifier struct {
+	valid bool
+}
+
+func (v fixedVerifier) ValidateAddress(address string) bool { return address == "fixed" }
+
+func (v fixedVerifier) VerifyMessage(signature string, message, walletAddress string) (bool, error) {
+	return v.valid, nil
+}
+
+func TestVerifierRegistry_RegisterKeepsOrder(t *testing.T) {
+	registry := gateway.NewVerifierRegistry()
+	registry.Register("fixed", fixedVerifier{valid: false})
+	registry.Register(gateway.Ethereum, gateway.EthereumVerifier{})
+	registry.Register("fixed", fixedVerifier{valid: true})
+
+	assert.Equal(t, []gateway.WalletTypeEnum{"fixed", gateway.Ethereum}, registry.Chains())
+	verifier, ok := registry.Lookup("fixed")
+	require.True(t, ok)
+	assert.Equal(t, fixedVerifier{valid: true}, verifier)
+
+	_, ok = registry.Lookup(gateway.Solana)
+	assert.False(t, ok)
+}
+
+func TestLogin_VerifiesSuiSignatures(t *testing.T) {
+	server, logins := newLoginServer(t)
+	auth := newVerifyingAuth(server, nil, false)
+	signed := signSui(t, "test message")
+
+	token, err := auth.Login("test message", signed.Signature, signed.SigningKey)
+	require.NoError(t, err)
+	assert.Equal(t, "test-token", token)
+
+	_, err = auth.Login("another message", signed.Signature, signed.SigningKey)
+	assert.ErrorContains(t, err, "sui signature verification failed")
+	assert.Equal(t, int32(1), atomic.LoadInt32(logins))
+}
+
+func TestLoginWithChain(t *testing.T) {
+	server, logins := newLoginServer(t)
+	auth := newVerifyingAuth(server, nil, false)
+	signed := signSui(t, "test message")
+
+	token, err := auth.LoginWithChain(gateway.Sui, "test message", signed.Signature, signed.SigningKey)
+	require.NoError(t, err)
+	assert.Equal(t, "test-token", token)
+
+	_, err = auth.LoginWithChain(gateway.Solana, "test message", signed.Signature, signed.SigningKey)
+	assert.ErrorContains(t, err, "solana signature verification failed")
+
+	_, err = auth.LoginWithChain("bitcoin", "test message", signed.Signature, signed.SigningKey)
+	assert.ErrorIs(t, err, gateway.ErrNoVerifier)
+	assert.Equal(t, int32(1), atomic.LoadInt32(logins))
+}
+
+func TestLogin_StrictVerification(t *testing.T) {
+	server, logins := newLoginServer(t)
+
+	_, err := newVerifyingAuth(server, nil, false).Login("test message", "mock-signature", "mock-signing-key")
+	require.NoError(t, err, "unknown wallets are left to the API")
+	assert.Equal(t, int32(1), atomic.LoadInt32(logins))
+
+	_, err = newVerifyingAuth(server, nil, true).Login("test message", "mock-signature", "mock-signing-key")
+	assert.ErrorIs(t, err, gateway.ErrNoVerifier)
+	assert.Equal(t, int32(1), atomic.LoadInt32(logins))
+
+	registry := gateway.NewVerifierRegistry()
+	registry.Register("fixed", fixedVerifier{valid: true})
+	_, err = newVerifyingAuth(server, registry, true).Login("test message", "mock-signature", "fixed")
+	require.NoError(t, err)
+
+	_, err = newVerifyingAuth(server, registry, true).Login("test message", "mock-signature", testEthereumAddress)
+	assert.ErrorIs(t, err, gateway.ErrNoVerifier, "only the registered verifiers are used")
+}
+
+func TestWithVerifier(t *testing.T) {
+	server, logins := newLoginServer(t)
+
+	sdk, err := gateway.New(
+		gateway.WithAPIKey("test-key"),
+		gateway.WithBaseURL(server.URL),
+		gateway.WithVerifier("fixed", fixedVerifier{valid: false}),
+		gateway.WithStrictVerification(),
+	)
+	require.NoError(t, err)
+
+	_, err = sdk.Auth.Login("test message", "mock-signature", "fixed")
+	assert.ErrorContains(t, err, "invalid fixed signature")
+
+	_, err = sdk.Auth.Login("test message", "mock-signature", "mock-signing-key")
+	assert.ErrorIs(t, err, gateway.ErrNoVerifier)
+
+	signed := signSui(t, "test message")
+	_, err = sdk.Auth.Login("test message", signed.Signature, signed.SigningKey)
+	require.NoError(t, err, "the default verifiers are kept")
+	assert.Equal(t, int32(1), atomic.LoadInt32(logins))
+
+	_, err = gateway.New(gateway.WithAPIKey("test-key"), gateway.WithVerifier(gateway.Sui, nil))
+	assert.Error(t, err)
+}
+
+func TestSuiWallet_SignsIn(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	wallet := gateway.WalletDetails{PrivateKey: testSuiKey, WalletType: gateway.Sui}
+	_, err := srv.RegisterWallet("sui-user", wallet)
+	require.NoError(t, err)
+
+	sdk, err := gateway.New(
+		gateway.WithWallet(wallet.PrivateKey, wallet.WalletType),
+		gateway.WithBaseURL(srv.URL),
+	)
+	require.NoError(t, err)
+
+	me, err := sdk.Account.GetMe()
+	require.NoError(t, err)
+	assert.Equal(t, "sui-user", me.Username)
+}
+
+func TestWalletSignIn_UsesConfiguredVerifiers(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	wallet := gateway.WalletDetails{PrivateKey: testSuiKey, WalletType: gateway.Sui}
+	_, err := srv.RegisterWallet("sui-user", wallet)
+	require.NoError(t, err)
+
+	sdk, err := gateway.New(
+		gateway.WithWallet(wallet.PrivateKey, wallet.WalletType),
+		gateway.WithBaseURL(srv.URL),
+		gateway.WithVerifier(gateway.Sui, fixedVerifier{valid: false}),
+	)
+	require.NoError(t, err)
+
+	_, err = sdk.Account.GetMe()
+	assert.ErrorContains(t, err, "invalid sui signature")
+
+	registry := gateway.NewVerifierRegistry()
+	registry.Register(gateway.Ethereum, gateway.EthereumVerifier{})
+	sdk, err = gateway.New(
+		gateway.WithWallet(wallet.PrivateKey, wallet.WalletType),
+		gateway.WithBaseURL(srv.URL),
+		gateway.WithVerifiers(registry),
+	)
+	require.NoError(t, err)
+
+	_, err = sdk.Account.GetMe()
+	assert.ErrorIs(t, err, gateway.ErrNoVerifier)
+}
@@ -1,12 +1,14 @@
 package client
 
 import (
-	"errors"
+	"context"
 )
 
 type WalletInterface interface {
 	Add(address string) (MyAccountResponse, error)
+	AddCtx(ctx context.Context, address string) (MyAccountResponse, error)
 	Remove(address string) (MyAccountResponse, error)
+	RemoveCtx(ctx context.Context, address string) (MyAccountResponse, error)
 }
 
 type WalletImpl struct {
@@ -20,34 +22,42 @@ func NewWalletImpl(config Config) *WalletImpl {
 }
 
 func (u *WalletImpl) Add(address string) (MyAccountResponse, error) {
+	return u.AddCtx(context.Background(), address)
+}
+
+func (u *WalletImpl) AddCtx(ctx context.Context, address string) (MyAccountResponse, error) {
 	var myAccount MyAccountResponse
 	var error Error
 
-	res, err := u.Config.Client.R().SetBody(map[string]interface{}{"address": address}).SetResult(&myAccount).SetError(&error).Post(AddWallet)
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "Wallet.Add")).SetBody(map[string]interface{}{"address": address}).SetResult(&myAccount).SetError(&error).Post(AddWallet)
 
 	if err != nil {
 		return myAccount, err
 	}
 
 	if res.IsError() {
-		return myAccount, errors.New(error.Error)
+		return myAccount, newAPIError(res, AddWallet, error)
 	}
 
 	return myAccount, nil
 }
 
 func (u *WalletImpl) Remove(address string) (MyAccountResponse, error) {
+	return u.RemoveCtx(context.Background(), address)
+}
+
+func (u *WalletImpl) RemoveCtx(ctx context.Context, address string) (MyAccountResponse, error) {
 	var myAccount MyAccountResponse
 	var error Error
 
-	res, err := u.Config.Client.R().SetBody(map[string]interface{}{"address": address}).SetResult(&myAccount).SetError(&error).Delete(RemoveWallet)
+	res, err := u.Config.Client.R().SetContext(withOperation(ctx, "Wallet.Remove")).SetPathParam("address", address).SetBody(map[string]interface{}{"address": address}).SetResult(&myAccount).SetError(&error).Delete(RemoveWallet)
 
 	if err != nil {
 		return myAccount, err
 	}
 
 	if res.IsError() {
-		return myAccount, errors.New(error.Error)
+		return myAccount, newAPIError(res, RemoveWallet, error)
 	}
 
 	return myAccount, nil
@@ -2,6 +2,8 @@ package client
 
 import (
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 )
@@ -25,22 +27,40 @@ type WalletService struct {
 }
 
 type MiddlewareParams struct {
-	Client *resty.Client
-	Wallet WalletService
+	Client      *resty.Client
+	Wallet      WalletService
+	TokenLeeway time.Duration
+	// Metrics counts the tokens issued for the wallet; nil drops them.
+	Metrics Metrics
+	// Logger receives the sign-in events of the wallet; nil drops them.
+	Logger *slog.Logger
+	// Verifiers check the signatures of the wallet before they are sent;
+	// nil uses DefaultVerifierRegistry.
+	Verifiers *VerifierRegistry
+	// StrictVerification rejects wallets no Verifier handles instead of
+	// leaving them to the API.
+	StrictVerification bool
 }
 
+// NewWalletService loads walletPrivateKey as a key of walletType. It
+// returns an error when the type is unsupported or the key is malformed.
 func NewWalletService(walletPrivateKey string, walletType WalletTypeEnum) (*WalletService, error) {
 	var wallet Wallet
+	var err error
 
 	switch walletType {
 	case Ethereum:
-		wallet = NewEtherumService(walletPrivateKey)
+		wallet, err = ParseEtherumService(walletPrivateKey)
 	case Solana:
-		wallet = NewSolanaService(walletPrivateKey)
+		wallet, err = ParseSolanaService(walletPrivateKey)
 	case Sui:
-		wallet = NewSuiService(walletPrivateKey)
+		wallet, err = ParseSuiService(walletPrivateKey)
 	default:
-		return nil, fmt.Errorf("unsupported wallet type")
+		return nil, fmt.Errorf("unsupported wallet type %q", walletType)
+	}
+
+	if err != nil {
+		return nil, err
 	}
 
 	return &WalletService{
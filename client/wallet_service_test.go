@@ -5,6 +5,7 @@ import (
 
 	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewWalletService_Ethereum(t *testing.T) {
@@ -56,3 +57,25 @@ func TestWalletService_SignMessage_Solana(t *testing.T) {
 	assert.NotEmpty(t, signature.Signature, "Signature should not be empty")
 	assert.NotEmpty(t, signature.SigningKey, "SigningKey should not be empty")
 }
+
+func TestNewWalletService_InvalidKey(t *testing.T) {
+	for _, walletType := range []gateway.WalletTypeEnum{gateway.Ethereum, gateway.Solana, gateway.Sui} {
+		assert.NotPanics(t, func() {
+			service, err := gateway.NewWalletService("invalid-private-key", walletType)
+			assert.Nil(t, service)
+			assert.Error(t, err)
+		}, string(walletType))
+	}
+}
+
+func TestNewWalletService_Sui(t *testing.T) {
+	service, err := gateway.NewWalletService("suiprivkey1qqqsyqcyq5rqwzqfpg9scrgwpugpzysnzs23v9ccrydpk8qarc0jqa4ffsr", gateway.Sui)
+	require.NoError(t, err)
+
+	signed, err := service.SignMessage("test message")
+	require.NoError(t, err)
+
+	valid, err := gateway.VerifySuiMessage(signed.Signature, "test message", signed.SigningKey)
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
@@ -72,7 +72,7 @@ func TestWalletImpl(t *testing.T) {
 		httpmock.Reset()
 
 		fixture := `{"username": "testuser", "wallets": []}`
-		httpmock.RegisterResponder("DELETE", gateway.RemoveWallet, func(req *http.Request) (*http.Response, error) {
+		httpmock.RegisterResponder("DELETE", "/accounts/me/wallets/0xTestAddress", func(req *http.Request) (*http.Response, error) {
 			resp := httpmock.NewStringResponse(200, fixture)
 			resp.Header.Set("Content-Type", "application/json")
 			return resp, nil
@@ -88,7 +88,7 @@ func TestWalletImpl(t *testing.T) {
 		httpmock.Reset()
 
 		errorResponse := `{"error": "Failed to remove wallet"}`
-		httpmock.RegisterResponder("DELETE", gateway.RemoveWallet, func(req *http.Request) (*http.Response, error) {
+		httpmock.RegisterResponder("DELETE", "/accounts/me/wallets/0xTestAddress", func(req *http.Request) (*http.Response, error) {
 			resp := httpmock.NewStringResponse(400, errorResponse)
 			resp.Header.Set("Content-Type", "application/json")
 			return resp, nil
@@ -103,7 +103,7 @@ func TestWalletImpl(t *testing.T) {
 	t.Run("TestRemoveWalletHttpRequestError", func(t *testing.T) {
 		httpmock.Reset()
 
-		httpmock.RegisterResponder("DELETE", gateway.RemoveWallet, func(req *http.Request) (*http.Response, error) {
+		httpmock.RegisterResponder("DELETE", "/accounts/me/wallets/0xTestAddress", func(req *http.Request) (*http.Response, error) {
 			return nil, errors.New("client-side error")
 		})
 
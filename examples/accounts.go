@@ -42,7 +42,10 @@ func ExampleGetRefreshToken(sdk *client.SDK) {
 }
 
 func RunAccounts() {
-	sdk := client.NewSDK(client.SDKConfig{WalletDetails: client.WalletDetails{PrivateKey: "", WalletType: client.Ethereum}})
+	sdk, err := client.New(client.WithWallet("your-private-key", client.Ethereum))
+	if err != nil {
+		log.Fatalf("Failed to create SDK: %v", err)
+	}
 
 	ExampleLogin(sdk)
 	ExampleGetMessage(sdk)
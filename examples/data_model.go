@@ -64,7 +64,10 @@ func ExampleUpdateDataModel(sdk *client.SDK) {
 }
 
 func RunDataModels() {
-	sdk := client.NewSDK(client.SDKConfig{WalletDetails: client.WalletDetails{PrivateKey: "", WalletType: client.Ethereum}})
+	sdk, err := client.New(client.WithWallet("your-private-key", client.Ethereum))
+	if err != nil {
+		log.Fatalf("Failed to create SDK: %v", err)
+	}
 
 	ExampleCreateDataModel(sdk)
 	ExampleUpdateDataModel(sdk)
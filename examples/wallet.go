@@ -11,7 +11,7 @@ func ExampleAddWallet(sdk *client.SDK) {
 
 	address := "0xYourEthereumAddress"
 
-	myAccount, err := sdk.Account.Wallet.Add(address)
+	myAccount, err := sdk.Account.Wallets().Add(address)
 	if err != nil {
 		log.Fatalf("Failed to add wallet: %v", err)
 	}
@@ -23,7 +23,7 @@ func ExampleRemoveWallet(sdk *client.SDK) {
 
 	address := "0xYourEthereumAddress"
 
-	myAccount, err := sdk.Account.Wallet.Remove(address)
+	myAccount, err := sdk.Account.Wallets().Remove(address)
 	if err != nil {
 		log.Fatalf("Failed to remove wallet: %v", err)
 	}
@@ -32,7 +32,10 @@ func ExampleRemoveWallet(sdk *client.SDK) {
 }
 
 func RunWallet() {
-	sdk := client.NewSDK(client.SDKConfig{WalletDetails: client.WalletDetails{PrivateKey: "", WalletType: client.Ethereum}})
+	sdk, err := client.New(client.WithWallet("your-private-key", client.Ethereum))
+	if err != nil {
+		log.Fatalf("Failed to create SDK: %v", err)
+	}
 
 	ExampleAddWallet(sdk)
 	ExampleRemoveWallet(sdk)
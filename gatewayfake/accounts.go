@@ -0,0 +1,91 @@
+package gatewayfake
+
+import (
+	"context"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+)
+
+// Accounts is a fake gateway.Accounts.
+type Accounts struct {
+	backend *Backend
+	did     string
+	wallet  *Wallet
+}
+
+var _ gateway.Accounts = (*Accounts)(nil)
+
+func NewAccounts(backend *Backend, did string) *Accounts {
+	return &Accounts{backend: backend, did: did, wallet: NewWallet(backend, did)}
+}
+
+func (f *Accounts) Wallets() gateway.WalletInterface {
+	return f.wallet
+}
+
+func (f *Accounts) Create(accountDetails gateway.AccountCreateRequest) (string, error) {
+	return f.CreateCtx(context.Background(), accountDetails)
+}
+
+func (f *Accounts) CreateCtx(ctx context.Context, accountDetails gateway.AccountCreateRequest) (string, error) {
+	if err := f.backend.begin(ctx, "Accounts.Create"); err != nil {
+		return "", err
+	}
+	return f.backend.store.SignUp(accountDetails)
+}
+
+func (f *Accounts) GetMe() (gateway.MyAccountResponse, error) {
+	return f.GetMeCtx(context.Background())
+}
+
+func (f *Accounts) GetMeCtx(ctx context.Context) (gateway.MyAccountResponse, error) {
+	if err := f.backend.begin(ctx, "Accounts.GetMe"); err != nil {
+		return gateway.MyAccountResponse{}, err
+	}
+	return f.backend.store.Me(f.did)
+}
+
+func (f *Accounts) UpdateMe(updateDetails gateway.AccountUpdateRequest) (gateway.MyAccountResponse, error) {
+	return f.UpdateMeCtx(context.Background(), updateDetails)
+}
+
+func (f *Accounts) UpdateMeCtx(ctx context.Context, updateDetails gateway.AccountUpdateRequest) (gateway.MyAccountResponse, error) {
+	if err := f.backend.begin(ctx, "Accounts.UpdateMe"); err != nil {
+		return gateway.MyAccountResponse{}, err
+	}
+	return f.backend.store.UpdateMe(f.did, updateDetails)
+}
+
+// Wallet is a fake gateway.WalletInterface.
+type Wallet struct {
+	backend *Backend
+	did     string
+}
+
+var _ gateway.WalletInterface = (*Wallet)(nil)
+
+func NewWallet(backend *Backend, did string) *Wallet {
+	return &Wallet{backend: backend, did: did}
+}
+
+func (f *Wallet) Add(address string) (gateway.MyAccountResponse, error) {
+	return f.AddCtx(context.Background(), address)
+}
+
+func (f *Wallet) AddCtx(ctx context.Context, address string) (gateway.MyAccountResponse, error) {
+	if err := f.backend.begin(ctx, "Wallet.Add"); err != nil {
+		return gateway.MyAccountResponse{}, err
+	}
+	return f.backend.store.AddWallet(f.did, address)
+}
+
+func (f *Wallet) Remove(address string) (gateway.MyAccountResponse, error) {
+	return f.RemoveCtx(context.Background(), address)
+}
+
+func (f *Wallet) RemoveCtx(ctx context.Context, address string) (gateway.MyAccountResponse, error) {
+	if err := f.backend.begin(ctx, "Wallet.Remove"); err != nil {
+		return gateway.MyAccountResponse{}, err
+	}
+	return f.backend.store.RemoveWallet(f.did, address)
+}
@@ -0,0 +1,54 @@
+package gatewayfake
+
+import (
+	"context"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+)
+
+// ACL is a fake gateway.ACL. Changing the ACL of a data asset takes the
+// share role.
+type ACL struct {
+	backend *Backend
+	did     string
+}
+
+var _ gateway.ACL = (*ACL)(nil)
+
+func NewACL(backend *Backend, did string) *ACL {
+	return &ACL{backend: backend, did: did}
+}
+
+func (f *ACL) Add(id int64, aclList []gateway.ACLRequest) (gateway.PublicACL, error) {
+	return f.AddCtx(context.Background(), id, aclList)
+}
+
+func (f *ACL) AddCtx(ctx context.Context, id int64, aclList []gateway.ACLRequest) (gateway.PublicACL, error) {
+	if err := f.backend.begin(ctx, "ACL.Add"); err != nil {
+		return gateway.PublicACL{}, err
+	}
+	return f.backend.store.AddACL(f.did, int(id), aclList)
+}
+
+func (f *ACL) Update(id int64, aclList []gateway.ACLRequest) (gateway.PublicACL, error) {
+	return f.UpdateCtx(context.Background(), id, aclList)
+}
+
+func (f *ACL) UpdateCtx(ctx context.Context, id int64, aclList []gateway.ACLRequest) (gateway.PublicACL, error) {
+	if err := f.backend.begin(ctx, "ACL.Update"); err != nil {
+		return gateway.PublicACL{}, err
+	}
+	return f.backend.store.UpdateACL(f.did, int(id), aclList)
+}
+
+func (f *ACL) Delete(id int64, aclList []gateway.ACLRequest) (string, error) {
+	return f.DeleteCtx(context.Background(), id, aclList)
+}
+
+func (f *ACL) DeleteCtx(ctx context.Context, id int64, aclList []gateway.ACLRequest) (string, error) {
+	if err := f.backend.begin(ctx, "ACL.Delete"); err != nil {
+		return "", err
+	}
+	message, err := f.backend.store.DeleteACL(f.did, int(id), aclList)
+	return message.Message, err
+}
@@ -0,0 +1,76 @@
+package gatewayfake
+
+import (
+	"context"
+	"fmt"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+)
+
+// Auth is a fake gateway.Auth. Sign-in messages are single use and
+// signatures are verified like the API does.
+type Auth struct {
+	backend *Backend
+	did     string
+}
+
+var _ gateway.Auth = (*Auth)(nil)
+
+func NewAuth(backend *Backend, did string) *Auth {
+	return &Auth{backend: backend, did: did}
+}
+
+func (f *Auth) Login(message string, signature string, wallet_address string) (string, error) {
+	return f.LoginCtx(context.Background(), message, signature, wallet_address)
+}
+
+func (f *Auth) LoginCtx(ctx context.Context, message string, signature string, wallet_address string) (string, error) {
+	if err := f.backend.begin(ctx, "Auth.Login"); err != nil {
+		return "", err
+	}
+	return f.backend.store.Login(gateway.AuthRequest{Message: message, Signature: signature, WalletAddress: wallet_address})
+}
+
+func (f *Auth) LoginWithChain(chain gateway.WalletTypeEnum, message string, signature string, wallet_address string) (string, error) {
+	return f.LoginWithChainCtx(context.Background(), chain, message, signature, wallet_address)
+}
+
+// LoginWithChainCtx checks the signature with the verifier of chain before
+// signing in, like gateway.AuthImpl, so a signature from another chain's
+// wallet is rejected even when the backend would accept it.
+func (f *Auth) LoginWithChainCtx(ctx context.Context, chain gateway.WalletTypeEnum, message string, signature string, wallet_address string) (string, error) {
+	verifier, ok := gateway.DefaultVerifierRegistry().Lookup(chain)
+	if !ok {
+		return "", fmt.Errorf("%w chain %q", gateway.ErrNoVerifier, chain)
+	}
+	isValid, err := verifier.VerifyMessage(signature, message, wallet_address)
+	if err != nil {
+		return "", fmt.Errorf("%s signature verification failed: %v", chain, err)
+	}
+	if !isValid {
+		return "", fmt.Errorf("invalid %s signature", chain)
+	}
+	return f.LoginCtx(ctx, message, signature, wallet_address)
+}
+
+func (f *Auth) GetMessage() (string, error) {
+	return f.GetMessageCtx(context.Background())
+}
+
+func (f *Auth) GetMessageCtx(ctx context.Context) (string, error) {
+	if err := f.backend.begin(ctx, "Auth.GetMessage"); err != nil {
+		return "", err
+	}
+	return f.backend.store.Message(), nil
+}
+
+func (f *Auth) GetRefreshToken() (string, error) {
+	return f.GetRefreshTokenCtx(context.Background())
+}
+
+func (f *Auth) GetRefreshTokenCtx(ctx context.Context) (string, error) {
+	if err := f.backend.begin(ctx, "Auth.GetRefreshToken"); err != nil {
+		return "", err
+	}
+	return f.backend.store.IssueToken(f.did)
+}
@@ -0,0 +1,114 @@
+package gatewayfake
+
+import (
+	"context"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/Gateway-DAO/gateway-go-sdk/internal/memstore"
+)
+
+// ComputeRequest is a fake gateway.ComputeRequest. Accounts accept compute
+// requests with their own data assets of the requested data model, and only
+// the creator can start the computation.
+type ComputeRequest struct {
+	backend *Backend
+	did     string
+}
+
+var _ gateway.ComputeRequest = (*ComputeRequest)(nil)
+
+func NewComputeRequest(backend *Backend, did string) *ComputeRequest {
+	return &ComputeRequest{backend: backend, did: did}
+}
+
+func (f *ComputeRequest) Create(computeRequestInput gateway.ComputeRequestCreateRequest) (gateway.ComputeRequestResponse, error) {
+	return f.CreateCtx(context.Background(), computeRequestInput)
+}
+
+func (f *ComputeRequest) CreateCtx(ctx context.Context, computeRequestInput gateway.ComputeRequestCreateRequest) (gateway.ComputeRequestResponse, error) {
+	if err := f.backend.begin(ctx, "ComputeRequest.Create"); err != nil {
+		return gateway.ComputeRequestResponse{}, err
+	}
+	return f.backend.store.CreateComputeRequest(f.did, computeRequestInput)
+}
+
+func (f *ComputeRequest) Get(id int64) (gateway.ComputeRequestResponse, error) {
+	return f.GetCtx(context.Background(), id)
+}
+
+func (f *ComputeRequest) GetCtx(ctx context.Context, id int64) (gateway.ComputeRequestResponse, error) {
+	if err := f.backend.begin(ctx, "ComputeRequest.Get"); err != nil {
+		return gateway.ComputeRequestResponse{}, err
+	}
+	return f.backend.store.ComputeRequest(f.did, int(id))
+}
+
+func (f *ComputeRequest) GetMy(page int, page_size int) (gateway.HelperPaginatedResponse[[]gateway.ComputeRequestResponse], error) {
+	return f.GetMyCtx(context.Background(), page, page_size)
+}
+
+func (f *ComputeRequest) GetMyCtx(ctx context.Context, page int, page_size int) (gateway.HelperPaginatedResponse[[]gateway.ComputeRequestResponse], error) {
+	if err := f.backend.begin(ctx, "ComputeRequest.GetMy"); err != nil {
+		return gateway.HelperPaginatedResponse[[]gateway.ComputeRequestResponse]{}, err
+	}
+
+	requests, err := f.backend.store.MyComputeRequests(f.did)
+	if err != nil {
+		return gateway.HelperPaginatedResponse[[]gateway.ComputeRequestResponse]{}, err
+	}
+	return memstore.Paginate(requests, page, page_size, gateway.GetComputeRequests), nil
+}
+
+func (f *ComputeRequest) GetReceived(page int, page_size int) (gateway.HelperPaginatedResponse[[]gateway.ComputeRequestReceivedResponse], error) {
+	return f.GetReceivedCtx(context.Background(), page, page_size)
+}
+
+func (f *ComputeRequest) GetReceivedCtx(ctx context.Context, page int, page_size int) (gateway.HelperPaginatedResponse[[]gateway.ComputeRequestReceivedResponse], error) {
+	if err := f.backend.begin(ctx, "ComputeRequest.GetReceived"); err != nil {
+		return gateway.HelperPaginatedResponse[[]gateway.ComputeRequestReceivedResponse]{}, err
+	}
+
+	requests, err := f.backend.store.ReceivedComputeRequests(f.did)
+	if err != nil {
+		return gateway.HelperPaginatedResponse[[]gateway.ComputeRequestReceivedResponse]{}, err
+	}
+	return memstore.Paginate(requests, page, page_size, gateway.GetComputeRequestsReceived), nil
+}
+
+func (f *ComputeRequest) AllMy(pageSize int) *gateway.PageIterator[gateway.ComputeRequestResponse] {
+	return f.AllMyCtx(context.Background(), pageSize)
+}
+
+func (f *ComputeRequest) AllMyCtx(ctx context.Context, pageSize int) *gateway.PageIterator[gateway.ComputeRequestResponse] {
+	return gateway.NewPageIterator(ctx, pageSize, f.GetMyCtx)
+}
+
+func (f *ComputeRequest) AllReceived(pageSize int) *gateway.PageIterator[gateway.ComputeRequestReceivedResponse] {
+	return f.AllReceivedCtx(context.Background(), pageSize)
+}
+
+func (f *ComputeRequest) AllReceivedCtx(ctx context.Context, pageSize int) *gateway.PageIterator[gateway.ComputeRequestReceivedResponse] {
+	return gateway.NewPageIterator(ctx, pageSize, f.GetReceivedCtx)
+}
+
+func (f *ComputeRequest) Accept(id int64, dataAssetId int) (gateway.ComputeRequestResponse, error) {
+	return f.AcceptCtx(context.Background(), id, dataAssetId)
+}
+
+func (f *ComputeRequest) AcceptCtx(ctx context.Context, id int64, dataAssetId int) (gateway.ComputeRequestResponse, error) {
+	if err := f.backend.begin(ctx, "ComputeRequest.Accept"); err != nil {
+		return gateway.ComputeRequestResponse{}, err
+	}
+	return f.backend.store.AcceptComputeRequest(f.did, int(id), dataAssetId)
+}
+
+func (f *ComputeRequest) StartComputingProcess(id int64) (gateway.ComputingProcessResponse, error) {
+	return f.StartComputingProcessCtx(context.Background(), id)
+}
+
+func (f *ComputeRequest) StartComputingProcessCtx(ctx context.Context, id int64) (gateway.ComputingProcessResponse, error) {
+	if err := f.backend.begin(ctx, "ComputeRequest.StartComputingProcess"); err != nil {
+		return gateway.ComputingProcessResponse{}, err
+	}
+	return f.backend.store.StartComputeRequest(f.did, int(id))
+}
@@ -0,0 +1,352 @@
+package gatewayfake
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/Gateway-DAO/gateway-go-sdk/internal/memstore"
+)
+
+// DataAsset is a fake gateway.DataAsset. Reading and downloading a data
+// asset takes the view role, updating it the update role, deleting it the
+// delete role and sharing it the share role.
+type DataAsset struct {
+	backend *Backend
+	did     string
+}
+
+var _ gateway.DataAsset = (*DataAsset)(nil)
+
+func NewDataAsset(backend *Backend, did string) *DataAsset {
+	return &DataAsset{backend: backend, did: did}
+}
+
+// parseID parses the string ids some data asset methods take; the API
+// rejects the ones that are not numbers.
+func parseID(id string) (int, error) {
+	parsed, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, &gateway.APIError{StatusCode: http.StatusBadRequest, Message: "invalid id"}
+	}
+	return parsed, nil
+}
+
+func newUpload(fileName string, fileContent []byte, aclList *[]gateway.ACLRequest, expirationDate *time.Time) memstore.Upload {
+	upload := memstore.Upload{File: memstore.File{Name: fileName, Content: fileContent}}
+	if aclList != nil {
+		upload.ACL = *aclList
+	}
+	if expirationDate != nil {
+		expiration := expirationDate.Format(time.RFC3339)
+		upload.ExpirationDate = &expiration
+	}
+	return upload
+}
+
+// readUpload reads content the way a streamed upload sends it, reporting
+// progress to options.Progress.
+func readUpload(fileName string, content io.Reader, options gateway.UploadOptions) (memstore.Upload, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return memstore.Upload{}, err
+	}
+
+	if options.Progress != nil && len(data) > 0 {
+		total := options.Size
+		if total <= 0 {
+			total = -1
+		}
+		options.Progress(int64(len(data)), total)
+	}
+
+	return newUpload(fileName, data, options.ACL, options.ExpirationDate), nil
+}
+
+func readUploadPath(path string, options gateway.UploadOptions) (memstore.Upload, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return memstore.Upload{}, err
+	}
+	defer file.Close()
+
+	return readUpload(filepath.Base(path), file, options)
+}
+
+// downloadMetadata describes file the way the client reads it from the
+// download response headers, with the media type stripped of parameters.
+func downloadMetadata(file memstore.File) gateway.DownloadMetadata {
+	metadata := gateway.DownloadMetadata{FileName: file.Name, FileType: file.Type, Size: int64(len(file.Content))}
+	if mediaType, _, err := mime.ParseMediaType(file.Type); err == nil {
+		metadata.FileType = mediaType
+	}
+	return metadata
+}
+
+func (f *DataAsset) Upload(dataAssetInput gateway.CreateDataAssetRequest) (gateway.DataAssetIDRequestAndResponse, error) {
+	return f.UploadCtx(context.Background(), dataAssetInput)
+}
+
+func (f *DataAsset) UploadCtx(ctx context.Context, dataAssetInput gateway.CreateDataAssetRequest) (gateway.DataAssetIDRequestAndResponse, error) {
+	if err := f.backend.begin(ctx, "DataAsset.Upload"); err != nil {
+		return gateway.DataAssetIDRequestAndResponse{}, err
+	}
+	return f.backend.store.CreateAsset(f.did, dataAssetInput)
+}
+
+func (f *DataAsset) UploadFile(fileName string, fileContent []byte, aclList *[]gateway.ACLRequest, expirationDate *time.Time) (gateway.DataAssetIDRequestAndResponse, error) {
+	return f.UploadFileCtx(context.Background(), fileName, fileContent, aclList, expirationDate)
+}
+
+func (f *DataAsset) UploadFileCtx(ctx context.Context, fileName string, fileContent []byte, aclList *[]gateway.ACLRequest, expirationDate *time.Time) (gateway.DataAssetIDRequestAndResponse, error) {
+	if err := f.backend.begin(ctx, "DataAsset.UploadFile"); err != nil {
+		return gateway.DataAssetIDRequestAndResponse{}, err
+	}
+	return f.backend.store.UploadFile(f.did, newUpload(fileName, fileContent, aclList, expirationDate))
+}
+
+func (f *DataAsset) UploadFileReader(fileName string, content io.Reader, options gateway.UploadOptions) (gateway.DataAssetIDRequestAndResponse, error) {
+	return f.UploadFileReaderCtx(context.Background(), fileName, content, options)
+}
+
+func (f *DataAsset) UploadFileReaderCtx(ctx context.Context, fileName string, content io.Reader, options gateway.UploadOptions) (gateway.DataAssetIDRequestAndResponse, error) {
+	if err := f.backend.begin(ctx, "DataAsset.UploadFileReader"); err != nil {
+		return gateway.DataAssetIDRequestAndResponse{}, err
+	}
+
+	upload, err := readUpload(fileName, content, options)
+	if err != nil {
+		return gateway.DataAssetIDRequestAndResponse{}, err
+	}
+	return f.backend.store.UploadFile(f.did, upload)
+}
+
+func (f *DataAsset) UploadFilePath(path string, options gateway.UploadOptions) (gateway.DataAssetIDRequestAndResponse, error) {
+	return f.UploadFilePathCtx(context.Background(), path, options)
+}
+
+func (f *DataAsset) UploadFilePathCtx(ctx context.Context, path string, options gateway.UploadOptions) (gateway.DataAssetIDRequestAndResponse, error) {
+	if err := f.backend.begin(ctx, "DataAsset.UploadFilePath"); err != nil {
+		return gateway.DataAssetIDRequestAndResponse{}, err
+	}
+
+	upload, err := readUploadPath(path, options)
+	if err != nil {
+		return gateway.DataAssetIDRequestAndResponse{}, err
+	}
+	return f.backend.store.UploadFile(f.did, upload)
+}
+
+func (f *DataAsset) GetCreatedByMe(page int, page_size int) (gateway.HelperPaginatedResponse[[]gateway.PublicDataAsset], error) {
+	return f.GetCreatedByMeCtx(context.Background(), page, page_size)
+}
+
+func (f *DataAsset) GetCreatedByMeCtx(ctx context.Context, page int, page_size int) (gateway.HelperPaginatedResponse[[]gateway.PublicDataAsset], error) {
+	if err := f.backend.begin(ctx, "DataAsset.GetCreatedByMe"); err != nil {
+		return gateway.HelperPaginatedResponse[[]gateway.PublicDataAsset]{}, err
+	}
+
+	assets, err := f.backend.store.CreatedAssets(f.did)
+	if err != nil {
+		return gateway.HelperPaginatedResponse[[]gateway.PublicDataAsset]{}, err
+	}
+	return memstore.Paginate(assets, page, page_size, gateway.GetCreatedDataAssets), nil
+}
+
+func (f *DataAsset) GetReceivedByMe(page int, page_size int) (gateway.HelperPaginatedResponse[[]gateway.PublicDataAsset], error) {
+	return f.GetReceivedByMeCtx(context.Background(), page, page_size)
+}
+
+func (f *DataAsset) GetReceivedByMeCtx(ctx context.Context, page int, page_size int) (gateway.HelperPaginatedResponse[[]gateway.PublicDataAsset], error) {
+	if err := f.backend.begin(ctx, "DataAsset.GetReceivedByMe"); err != nil {
+		return gateway.HelperPaginatedResponse[[]gateway.PublicDataAsset]{}, err
+	}
+
+	assets, err := f.backend.store.ReceivedAssets(f.did)
+	if err != nil {
+		return gateway.HelperPaginatedResponse[[]gateway.PublicDataAsset]{}, err
+	}
+	return memstore.Paginate(assets, page, page_size, gateway.GetReceivedDataAssets), nil
+}
+
+func (f *DataAsset) AllCreatedByMe(pageSize int) *gateway.PageIterator[gateway.PublicDataAsset] {
+	return f.AllCreatedByMeCtx(context.Background(), pageSize)
+}
+
+func (f *DataAsset) AllCreatedByMeCtx(ctx context.Context, pageSize int) *gateway.PageIterator[gateway.PublicDataAsset] {
+	return gateway.NewPageIterator(ctx, pageSize, f.GetCreatedByMeCtx)
+}
+
+func (f *DataAsset) AllReceivedByMe(pageSize int) *gateway.PageIterator[gateway.PublicDataAsset] {
+	return f.AllReceivedByMeCtx(context.Background(), pageSize)
+}
+
+func (f *DataAsset) AllReceivedByMeCtx(ctx context.Context, pageSize int) *gateway.PageIterator[gateway.PublicDataAsset] {
+	return gateway.NewPageIterator(ctx, pageSize, f.GetReceivedByMeCtx)
+}
+
+func (f *DataAsset) Get(id int64) (gateway.PublicDataAsset, error) {
+	return f.GetCtx(context.Background(), id)
+}
+
+func (f *DataAsset) GetCtx(ctx context.Context, id int64) (gateway.PublicDataAsset, error) {
+	if err := f.backend.begin(ctx, "DataAsset.Get"); err != nil {
+		return gateway.PublicDataAsset{}, err
+	}
+	return f.backend.store.Asset(f.did, int(id))
+}
+
+func (f *DataAsset) UpdateAsset(id string, dataAssetInput gateway.UpdateDataAssetRequest) (gateway.PublicDataAsset, error) {
+	return f.UpdateAssetCtx(context.Background(), id, dataAssetInput)
+}
+
+func (f *DataAsset) UpdateAssetCtx(ctx context.Context, id string, dataAssetInput gateway.UpdateDataAssetRequest) (gateway.PublicDataAsset, error) {
+	if err := f.backend.begin(ctx, "DataAsset.UpdateAsset"); err != nil {
+		return gateway.PublicDataAsset{}, err
+	}
+
+	assetID, err := parseID(id)
+	if err != nil {
+		return gateway.PublicDataAsset{}, err
+	}
+	return f.backend.store.UpdateAsset(f.did, assetID, dataAssetInput)
+}
+
+func (f *DataAsset) UpdateFile(id string, fileName string, fileContent []byte, aclList *[]gateway.ACLRequest, expirationDate *time.Time) (gateway.PublicDataAsset, error) {
+	return f.UpdateFileCtx(context.Background(), id, fileName, fileContent, aclList, expirationDate)
+}
+
+func (f *DataAsset) UpdateFileCtx(ctx context.Context, id string, fileName string, fileContent []byte, aclList *[]gateway.ACLRequest, expirationDate *time.Time) (gateway.PublicDataAsset, error) {
+	if err := f.backend.begin(ctx, "DataAsset.UpdateFile"); err != nil {
+		return gateway.PublicDataAsset{}, err
+	}
+
+	assetID, err := parseID(id)
+	if err != nil {
+		return gateway.PublicDataAsset{}, err
+	}
+	return f.backend.store.UpdateFile(f.did, assetID, newUpload(fileName, fileContent, aclList, expirationDate))
+}
+
+func (f *DataAsset) UpdateFileReader(id string, fileName string, content io.Reader, options gateway.UploadOptions) (gateway.PublicDataAsset, error) {
+	return f.UpdateFileReaderCtx(context.Background(), id, fileName, content, options)
+}
+
+func (f *DataAsset) UpdateFileReaderCtx(ctx context.Context, id string, fileName string, content io.Reader, options gateway.UploadOptions) (gateway.PublicDataAsset, error) {
+	if err := f.backend.begin(ctx, "DataAsset.UpdateFileReader"); err != nil {
+		return gateway.PublicDataAsset{}, err
+	}
+
+	assetID, err := parseID(id)
+	if err != nil {
+		return gateway.PublicDataAsset{}, err
+	}
+	upload, err := readUpload(fileName, content, options)
+	if err != nil {
+		return gateway.PublicDataAsset{}, err
+	}
+	return f.backend.store.UpdateFile(f.did, assetID, upload)
+}
+
+func (f *DataAsset) UpdateFilePath(id string, path string, options gateway.UploadOptions) (gateway.PublicDataAsset, error) {
+	return f.UpdateFilePathCtx(context.Background(), id, path, options)
+}
+
+func (f *DataAsset) UpdateFilePathCtx(ctx context.Context, id string, path string, options gateway.UploadOptions) (gateway.PublicDataAsset, error) {
+	if err := f.backend.begin(ctx, "DataAsset.UpdateFilePath"); err != nil {
+		return gateway.PublicDataAsset{}, err
+	}
+
+	assetID, err := parseID(id)
+	if err != nil {
+		return gateway.PublicDataAsset{}, err
+	}
+	upload, err := readUploadPath(path, options)
+	if err != nil {
+		return gateway.PublicDataAsset{}, err
+	}
+	return f.backend.store.UpdateFile(f.did, assetID, upload)
+}
+
+func (f *DataAsset) DeleteAsset(id int64) (gateway.MessageResponse, error) {
+	return f.DeleteAssetCtx(context.Background(), id)
+}
+
+func (f *DataAsset) DeleteAssetCtx(ctx context.Context, id int64) (gateway.MessageResponse, error) {
+	if err := f.backend.begin(ctx, "DataAsset.DeleteAsset"); err != nil {
+		return gateway.MessageResponse{}, err
+	}
+	return f.backend.store.DeleteAsset(f.did, int(id))
+}
+
+func (f *DataAsset) Download(id int64) (*gateway.FileResponse, error) {
+	return f.DownloadCtx(context.Background(), id)
+}
+
+func (f *DataAsset) DownloadCtx(ctx context.Context, id int64) (*gateway.FileResponse, error) {
+	if err := f.backend.begin(ctx, "DataAsset.Download"); err != nil {
+		return nil, err
+	}
+
+	file, err := f.backend.store.Download(f.did, int(id))
+	if err != nil {
+		return nil, err
+	}
+	metadata := downloadMetadata(file)
+	return &gateway.FileResponse{FileName: metadata.FileName, FileContent: file.Content, FileType: metadata.FileType}, nil
+}
+
+func (f *DataAsset) DownloadStream(id int64) (io.ReadCloser, gateway.DownloadMetadata, error) {
+	return f.DownloadStreamCtx(context.Background(), id)
+}
+
+func (f *DataAsset) DownloadStreamCtx(ctx context.Context, id int64) (io.ReadCloser, gateway.DownloadMetadata, error) {
+	if err := f.backend.begin(ctx, "DataAsset.DownloadStream"); err != nil {
+		return nil, gateway.DownloadMetadata{}, err
+	}
+
+	file, err := f.backend.store.Download(f.did, int(id))
+	if err != nil {
+		return nil, gateway.DownloadMetadata{}, err
+	}
+
+	metadata := downloadMetadata(file)
+	return io.NopCloser(bytes.NewReader(file.Content)), metadata, nil
+}
+
+func (f *DataAsset) DownloadTo(id int64, w io.Writer) (gateway.DownloadMetadata, error) {
+	return f.DownloadToCtx(context.Background(), id, w)
+}
+
+func (f *DataAsset) DownloadToCtx(ctx context.Context, id int64, w io.Writer) (gateway.DownloadMetadata, error) {
+	if err := f.backend.begin(ctx, "DataAsset.DownloadTo"); err != nil {
+		return gateway.DownloadMetadata{}, err
+	}
+
+	file, err := f.backend.store.Download(f.did, int(id))
+	if err != nil {
+		return gateway.DownloadMetadata{}, err
+	}
+
+	metadata := downloadMetadata(file)
+	_, err = w.Write(file.Content)
+	return metadata, err
+}
+
+func (f *DataAsset) Share(id int64, shareDetails []gateway.ShareDataAssetRequest) ([]gateway.PublicACL, error) {
+	return f.ShareCtx(context.Background(), id, shareDetails)
+}
+
+func (f *DataAsset) ShareCtx(ctx context.Context, id int64, shareDetails []gateway.ShareDataAssetRequest) ([]gateway.PublicACL, error) {
+	if err := f.backend.begin(ctx, "DataAsset.Share"); err != nil {
+		return nil, err
+	}
+	return f.backend.store.Share(f.did, int(id), shareDetails)
+}
@@ -0,0 +1,131 @@
+package gatewayfake_test
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/Gateway-DAO/gateway-go-sdk/gatewayfake"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadAndDownload(t *testing.T) {
+	backend := gatewayfake.NewBackend()
+	sdk, did := newAccount(t, backend, "alice", aliceWallet)
+
+	var progress int64
+	created, err := sdk.DataAssets.UploadFileReader("notes.txt", strings.NewReader("hello gateway"), gateway.UploadOptions{
+		Progress: func(transferred int64, total int64) { progress = transferred },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), progress)
+	id := int64(created.Id)
+
+	asset, err := sdk.DataAssets.Get(id)
+	require.NoError(t, err)
+	assert.Equal(t, did, asset.CreatedBy)
+	assert.Equal(t, 13, asset.Size)
+
+	var buf bytes.Buffer
+	metadata, err := sdk.DataAssets.DownloadTo(id, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello gateway", buf.String())
+	assert.Equal(t, "notes.txt", metadata.FileName)
+	assert.Equal(t, "text/plain", metadata.FileType)
+
+	_, err = sdk.DataAssets.UpdateFile(strconv.Itoa(created.Id), "notes.txt", []byte("updated"), nil, nil)
+	require.NoError(t, err)
+
+	file, err := sdk.DataAssets.Download(id)
+	require.NoError(t, err)
+	assert.Equal(t, "updated", string(file.FileContent))
+
+	_, err = sdk.DataAssets.UpdateAsset("not-a-number", gateway.UpdateDataAssetRequest{})
+	assert.ErrorIs(t, err, gateway.ErrBadRequest)
+
+	_, err = sdk.DataAssets.DeleteAsset(id)
+	require.NoError(t, err)
+	_, err = sdk.DataAssets.Get(id)
+	assert.ErrorIs(t, err, gateway.ErrNotFound)
+}
+
+func TestACLRoles(t *testing.T) {
+	backend := gatewayfake.NewBackend()
+	alice, _ := newAccount(t, backend, "alice", aliceWallet)
+	bob, _ := newAccount(t, backend, "bob", bobWallet)
+
+	created, err := alice.DataAssets.Upload(gateway.CreateDataAssetRequest{Name: "claim"})
+	require.NoError(t, err)
+	id := int64(created.Id)
+	textID := strconv.Itoa(created.Id)
+
+	_, err = bob.DataAssets.Get(id)
+	assert.ErrorIs(t, err, gateway.ErrNotFound, "assets without access are hidden")
+
+	_, err = alice.DataAssets.Share(id, []gateway.ShareDataAssetRequest{{Addresses: []string{bobWallet}}})
+	require.NoError(t, err)
+
+	_, err = bob.DataAssets.Get(id)
+	require.NoError(t, err)
+	_, err = bob.DataAssets.UpdateAsset(textID, gateway.UpdateDataAssetRequest{})
+	assert.ErrorIs(t, err, gateway.ErrForbidden)
+	_, err = bob.DataAssets.DeleteAsset(id)
+	assert.ErrorIs(t, err, gateway.ErrForbidden)
+	_, err = bob.ACL.Add(id, []gateway.ACLRequest{{Address: bobWallet, Roles: []gateway.TypesAccessLevel{gateway.RoleShare}}})
+	assert.ErrorIs(t, err, gateway.ErrForbidden)
+
+	received, err := bob.DataAssets.GetReceivedByMe(1, 10)
+	require.NoError(t, err)
+	require.Len(t, received.Data, 1)
+	assert.Equal(t, created.Id, received.Data[0].Id)
+
+	_, err = alice.ACL.Update(id, []gateway.ACLRequest{{Address: bobWallet, Roles: []gateway.TypesAccessLevel{gateway.RoleView, gateway.RoleUpdate, gateway.RoleDelete}}})
+	require.NoError(t, err)
+
+	name := "renamed"
+	updated, err := bob.DataAssets.UpdateAsset(textID, gateway.UpdateDataAssetRequest{Name: &name})
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", updated.Name)
+
+	_, err = alice.ACL.Delete(id, []gateway.ACLRequest{{Address: bobWallet}})
+	require.NoError(t, err)
+	_, err = bob.DataAssets.DeleteAsset(id)
+	assert.ErrorIs(t, err, gateway.ErrNotFound)
+}
+
+func TestComputeRequest(t *testing.T) {
+	backend := gatewayfake.NewBackend()
+	alice, _ := newAccount(t, backend, "alice", aliceWallet)
+	bob, _ := newAccount(t, backend, "bob", bobWallet)
+
+	model, err := alice.DataModel.Create(gateway.DataModelCreateRequest{Title: "score", Schema: map[string]interface{}{}})
+	require.NoError(t, err)
+
+	claim := map[string]interface{}{"score": 40.0}
+	asset, err := bob.DataAssets.Upload(gateway.CreateDataAssetRequest{Name: "score", DataModelId: &model.Id, Claim: &claim})
+	require.NoError(t, err)
+
+	param := 2
+	request, err := alice.ComputeRequest.Create(gateway.ComputeRequestCreateRequest{
+		Title:                 "double",
+		ComputeFieldName:      "score",
+		ComputeOperation:      gateway.ComputeOperationMultiply,
+		ComputeOperationParam: &param,
+		DataModelId:           model.Id,
+	})
+	require.NoError(t, err)
+
+	_, err = bob.ComputeRequest.Accept(int64(*request.Id), asset.Id)
+	require.NoError(t, err)
+
+	_, err = bob.ComputeRequest.StartComputingProcess(int64(*request.Id))
+	assert.ErrorIs(t, err, gateway.ErrForbidden)
+
+	process, err := alice.ComputeRequest.StartComputingProcess(int64(*request.Id))
+	require.NoError(t, err)
+	assert.Equal(t, "80", *process.ComputeResult)
+}
@@ -0,0 +1,102 @@
+package gatewayfake
+
+import (
+	"context"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/Gateway-DAO/gateway-go-sdk/internal/memstore"
+)
+
+// DataModel is a fake gateway.DataModel. Only the creator of a data model
+// can update it.
+type DataModel struct {
+	backend *Backend
+	did     string
+}
+
+var _ gateway.DataModel = (*DataModel)(nil)
+
+func NewDataModel(backend *Backend, did string) *DataModel {
+	return &DataModel{backend: backend, did: did}
+}
+
+func (f *DataModel) GetAll(page int, page_size int) (gateway.HelperPaginatedResponse[[]gateway.DataModelResponse], error) {
+	return f.GetAllCtx(context.Background(), page, page_size)
+}
+
+func (f *DataModel) GetAllCtx(ctx context.Context, page int, page_size int) (gateway.HelperPaginatedResponse[[]gateway.DataModelResponse], error) {
+	if err := f.backend.begin(ctx, "DataModel.GetAll"); err != nil {
+		return gateway.HelperPaginatedResponse[[]gateway.DataModelResponse]{}, err
+	}
+
+	models, err := f.backend.store.DataModels(f.did)
+	if err != nil {
+		return gateway.HelperPaginatedResponse[[]gateway.DataModelResponse]{}, err
+	}
+	return memstore.Paginate(models, page, page_size, gateway.GetDataModels), nil
+}
+
+func (f *DataModel) Create(dataModelInput gateway.DataModelCreateRequest) (gateway.DataModelResponse, error) {
+	return f.CreateCtx(context.Background(), dataModelInput)
+}
+
+func (f *DataModel) CreateCtx(ctx context.Context, dataModelInput gateway.DataModelCreateRequest) (gateway.DataModelResponse, error) {
+	if err := f.backend.begin(ctx, "DataModel.Create"); err != nil {
+		return gateway.DataModelResponse{}, err
+	}
+	return f.backend.store.CreateDataModel(f.did, dataModelInput)
+}
+
+func (f *DataModel) GetMy(page int, page_size int) (gateway.HelperPaginatedResponse[[]gateway.DataModelResponse], error) {
+	return f.GetMyCtx(context.Background(), page, page_size)
+}
+
+func (f *DataModel) GetMyCtx(ctx context.Context, page int, page_size int) (gateway.HelperPaginatedResponse[[]gateway.DataModelResponse], error) {
+	if err := f.backend.begin(ctx, "DataModel.GetMy"); err != nil {
+		return gateway.HelperPaginatedResponse[[]gateway.DataModelResponse]{}, err
+	}
+
+	models, err := f.backend.store.MyDataModels(f.did)
+	if err != nil {
+		return gateway.HelperPaginatedResponse[[]gateway.DataModelResponse]{}, err
+	}
+	return memstore.Paginate(models, page, page_size, gateway.GetDataModelsByUser), nil
+}
+
+func (f *DataModel) All(pageSize int) *gateway.PageIterator[gateway.DataModelResponse] {
+	return f.AllCtx(context.Background(), pageSize)
+}
+
+func (f *DataModel) AllCtx(ctx context.Context, pageSize int) *gateway.PageIterator[gateway.DataModelResponse] {
+	return gateway.NewPageIterator(ctx, pageSize, f.GetAllCtx)
+}
+
+func (f *DataModel) AllMy(pageSize int) *gateway.PageIterator[gateway.DataModelResponse] {
+	return f.AllMyCtx(context.Background(), pageSize)
+}
+
+func (f *DataModel) AllMyCtx(ctx context.Context, pageSize int) *gateway.PageIterator[gateway.DataModelResponse] {
+	return gateway.NewPageIterator(ctx, pageSize, f.GetMyCtx)
+}
+
+func (f *DataModel) GetById(id int64) (gateway.DataModelResponse, error) {
+	return f.GetByIdCtx(context.Background(), id)
+}
+
+func (f *DataModel) GetByIdCtx(ctx context.Context, id int64) (gateway.DataModelResponse, error) {
+	if err := f.backend.begin(ctx, "DataModel.GetById"); err != nil {
+		return gateway.DataModelResponse{}, err
+	}
+	return f.backend.store.DataModel(f.did, int(id))
+}
+
+func (f *DataModel) Update(id int64, dataModelInput gateway.DataModelUpdateRequest) (gateway.DataModelResponse, error) {
+	return f.UpdateCtx(context.Background(), id, dataModelInput)
+}
+
+func (f *DataModel) UpdateCtx(ctx context.Context, id int64, dataModelInput gateway.DataModelUpdateRequest) (gateway.DataModelResponse, error) {
+	if err := f.backend.begin(ctx, "DataModel.Update"); err != nil {
+		return gateway.DataModelResponse{}, err
+	}
+	return f.backend.store.UpdateDataModel(f.did, int(id), dataModelInput)
+}
@@ -0,0 +1,110 @@
+// Package gatewayfake provides in-memory implementations of the client
+// interfaces for unit tests that should not go through HTTP at all.
+//
+// Every fake acts as one account of a shared Backend, which keeps accounts,
+// data assets, ACLs, data models and compute requests with the same rules as
+// the gatewaytest server: data asset owners hold every role, other accounts
+// need the view, update, delete or share role granted to their DID or one of
+// their wallets, and assets they cannot see at all are reported as missing.
+//
+//	backend := gatewayfake.NewBackend()
+//	alice, _ := backend.CreateAccount("alice", aliceWallet)
+//	sdk := gatewayfake.NewSDK(backend, alice)
+//
+//	backend.FailNext("DataAsset.Get", &client.APIError{StatusCode: 503})
+//
+// Errors are *client.APIError values carrying the status the API would
+// answer with, so errors.Is works with the client sentinel errors.
+package gatewayfake
+
+import (
+	"context"
+	"sync"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/Gateway-DAO/gateway-go-sdk/internal/memstore"
+)
+
+// Backend is the state shared by the fakes of one or more accounts. It is
+// safe for concurrent use.
+type Backend struct {
+	store *memstore.Store
+
+	mu       sync.Mutex
+	scripted map[string][]error
+	always   map[string]error
+}
+
+func NewBackend() *Backend {
+	return &Backend{
+		store:    memstore.New(),
+		scripted: make(map[string][]error),
+		always:   make(map[string]error),
+	}
+}
+
+// CreateAccount adds an account owning walletAddress and returns its DID.
+func (b *Backend) CreateAccount(username string, walletAddress string) (string, error) {
+	return b.store.CreateAccount(username, walletAddress)
+}
+
+// FailNext makes the next calls of operation return errs, one per call, in
+// order. A nil entry lets that call through. Operations are named after the
+// interface and the method without its Ctx suffix, e.g. "DataAsset.Get",
+// "ACL.Add" or "Wallet.Remove".
+func (b *Backend) FailNext(operation string, errs ...error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.scripted[operation] = append(b.scripted[operation], errs...)
+}
+
+// FailAlways makes every call of operation return err once the errors
+// scripted with FailNext are used up. A nil err stops the failures.
+func (b *Backend) FailAlways(operation string, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		delete(b.always, operation)
+		return
+	}
+	b.always[operation] = err
+}
+
+// ClearFailures drops every scripted error.
+func (b *Backend) ClearFailures() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.scripted = make(map[string][]error)
+	b.always = make(map[string]error)
+}
+
+// begin returns the error a call of operation must fail with: the context
+// error first, then the scripted one.
+func (b *Backend) begin(ctx context.Context, operation string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if queue := b.scripted[operation]; len(queue) > 0 {
+		b.scripted[operation] = queue[1:]
+		return queue[0]
+	}
+	return b.always[operation]
+}
+
+// NewSDK returns an SDK whose services are fakes acting as the account with
+// did on backend. An empty did acts signed out.
+func NewSDK(backend *Backend, did string) *gateway.SDK {
+	return &gateway.SDK{
+		DataAssets:     NewDataAsset(backend, did),
+		DataModel:      NewDataModel(backend, did),
+		Account:        NewAccounts(backend, did),
+		ACL:            NewACL(backend, did),
+		Auth:           NewAuth(backend, did),
+		ComputeRequest: NewComputeRequest(backend, did),
+	}
+}
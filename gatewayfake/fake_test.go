@@ -0,0 +1,201 @@
+package gatewayfake_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/Gateway-DAO/gateway-go-sdk/gatewayfake"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	aliceWallet = "0x26bDbA4D3B8a2A4B8e2D2a0d8E5e3d5D6e2A8B4f"
+	bobWallet   = "0x0000000000000000000000000000000000000002"
+)
+
+func newAccount(t *testing.T, backend *gatewayfake.Backend, username string, walletAddress string) (*gateway.SDK, string) {
+	did, err := backend.CreateAccount(username, walletAddress)
+	require.NoError(t, err)
+	return gatewayfake.NewSDK(backend, did), did
+}
+
+func TestNewSDK(t *testing.T) {
+	backend := gatewayfake.NewBackend()
+	sdk, did := newAccount(t, backend, "alice", aliceWallet)
+
+	me, err := sdk.Account.GetMe()
+	require.NoError(t, err)
+	assert.Equal(t, did, me.Did)
+	assert.Equal(t, "alice", me.Username)
+
+	me, err = sdk.Account.Wallets().Add(bobWallet)
+	require.NoError(t, err)
+	assert.Len(t, me.WalletAddresses, 2)
+
+	_, err = sdk.Account.Wallets().Remove(aliceWallet)
+	require.NoError(t, err)
+	_, err = sdk.Account.Wallets().Remove(bobWallet)
+	assert.ErrorIs(t, err, gateway.ErrBadRequest)
+}
+
+func TestSignedOut(t *testing.T) {
+	sdk := gatewayfake.NewSDK(gatewayfake.NewBackend(), "")
+
+	_, err := sdk.Account.GetMe()
+	assert.ErrorIs(t, err, gateway.ErrUnauthorized)
+
+	_, err = sdk.DataModel.GetAll(1, 10)
+	assert.ErrorIs(t, err, gateway.ErrUnauthorized)
+}
+
+func TestLogin(t *testing.T) {
+	backend := gatewayfake.NewBackend()
+	wallet, err := gateway.NewWalletService("edb0ba5a63c5f9e4f4394560907794fca750704b355413bc04baab896254036a", gateway.Ethereum)
+	require.NoError(t, err)
+
+	sdk := gatewayfake.NewSDK(backend, "")
+
+	message, err := sdk.Auth.GetMessage()
+	require.NoError(t, err)
+	signed, err := wallet.SignMessage(message)
+	require.NoError(t, err)
+
+	_, err = sdk.Account.Create(gateway.AccountCreateRequest{
+		Username:      "alice",
+		WalletAddress: signed.SigningKey,
+		Message:       message,
+		Signature:     signed.Signature,
+	})
+	require.NoError(t, err)
+
+	_, err = sdk.Auth.Login(message, signed.Signature, signed.SigningKey)
+	assert.ErrorIs(t, err, gateway.ErrUnauthorized, "messages are single use")
+
+	message, err = sdk.Auth.GetMessage()
+	require.NoError(t, err)
+	signed, err = wallet.SignMessage(message)
+	require.NoError(t, err)
+
+	token, err := sdk.Auth.Login(message, signed.Signature, signed.SigningKey)
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+}
+
+func TestLoginWithChain(t *testing.T) {
+	backend := gatewayfake.NewBackend()
+	wallet, err := gateway.NewWalletService("edb0ba5a63c5f9e4f4394560907794fca750704b355413bc04baab896254036a", gateway.Ethereum)
+	require.NoError(t, err)
+
+	sdk := gatewayfake.NewSDK(backend, "")
+	message, err := sdk.Auth.GetMessage()
+	require.NoError(t, err)
+	signed, err := wallet.SignMessage(message)
+	require.NoError(t, err)
+	_, err = backend.CreateAccount("alice", signed.SigningKey)
+	require.NoError(t, err)
+
+	_, err = sdk.Auth.LoginWithChain("dogecoin", message, signed.Signature, signed.SigningKey)
+	assert.ErrorIs(t, err, gateway.ErrNoVerifier)
+
+	_, err = sdk.Auth.LoginWithChain(gateway.Solana, message, signed.Signature, signed.SigningKey)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, gateway.ErrNoVerifier)
+
+	token, err := sdk.Auth.LoginWithChain(gateway.Ethereum, message, signed.Signature, signed.SigningKey)
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+}
+
+func TestFailNext(t *testing.T) {
+	backend := gatewayfake.NewBackend()
+	sdk, _ := newAccount(t, backend, "alice", aliceWallet)
+
+	unavailable := &gateway.APIError{StatusCode: http.StatusServiceUnavailable}
+	backend.FailNext("Accounts.GetMe", unavailable, nil, errors.New("boom"))
+
+	_, err := sdk.Account.GetMe()
+	assert.ErrorIs(t, err, gateway.ErrServerError)
+
+	_, err = sdk.Account.GetMe()
+	assert.NoError(t, err)
+
+	_, err = sdk.Account.GetMe()
+	assert.EqualError(t, err, "boom")
+
+	_, err = sdk.Account.GetMe()
+	assert.NoError(t, err)
+
+	_, err = sdk.Account.UpdateMe(gateway.AccountUpdateRequest{})
+	assert.NoError(t, err, "other operations are not affected")
+}
+
+func TestFailAlways(t *testing.T) {
+	backend := gatewayfake.NewBackend()
+	sdk, _ := newAccount(t, backend, "alice", aliceWallet)
+
+	backend.FailNext("Wallet.Add", nil)
+	backend.FailAlways("Wallet.Add", gateway.ErrRateLimited)
+
+	_, err := sdk.Account.Wallets().Add(bobWallet)
+	require.NoError(t, err, "scripted errors come first")
+
+	_, err = sdk.Account.Wallets().Remove(bobWallet)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = sdk.Account.Wallets().Add(bobWallet)
+		assert.ErrorIs(t, err, gateway.ErrRateLimited)
+	}
+
+	backend.ClearFailures()
+	_, err = sdk.Account.Wallets().Add(bobWallet)
+	assert.NoError(t, err)
+}
+
+func TestCanceledContext(t *testing.T) {
+	backend := gatewayfake.NewBackend()
+	sdk, _ := newAccount(t, backend, "alice", aliceWallet)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := sdk.DataAssets.UploadCtx(ctx, gateway.CreateDataAssetRequest{Name: "claim"})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	page, err := sdk.DataAssets.GetCreatedByMe(1, 10)
+	require.NoError(t, err)
+	assert.Empty(t, page.Data)
+}
+
+func TestConcurrentUse(t *testing.T) {
+	backend := gatewayfake.NewBackend()
+	sdk, _ := newAccount(t, backend, "alice", aliceWallet)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			created, err := sdk.DataAssets.Upload(gateway.CreateDataAssetRequest{Name: "claim"})
+			if assert.NoError(t, err) {
+				_, err = sdk.DataAssets.Get(int64(created.Id))
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	count := 0
+	it := sdk.DataAssets.AllCreatedByMe(7)
+	for it.Next() {
+		count++
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, 20, count)
+}
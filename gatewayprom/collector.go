@@ -0,0 +1,154 @@
+// Package gatewayprom exposes the client metrics to Prometheus.
+//
+//	collector := gatewayprom.NewCollector("")
+//	prometheus.MustRegister(collector)
+//
+//	sdk, err := client.New(client.WithAPIKey(key), client.WithMetrics(collector))
+//
+// Requests are labelled with the SDK operation, such as "DataAsset.Share",
+// and the response status, which is "none" when no response was received.
+package gatewayprom
+
+import (
+	"strconv"
+	"time"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultNamespace prefixes the metric names when NewCollector is given no
+// namespace.
+const DefaultNamespace = "gateway_sdk"
+
+// Collector records client metrics and collects them for Prometheus. It
+// implements both gateway.Metrics and prometheus.Collector.
+type Collector struct {
+	requests         *prometheus.CounterVec
+	duration         *prometheus.HistogramVec
+	errors           *prometheus.CounterVec
+	jwtIssued        prometheus.Counter
+	tokenRefreshes   prometheus.Counter
+	signatureFailure prometheus.Counter
+	uploaded         *prometheus.CounterVec
+	downloaded       *prometheus.CounterVec
+}
+
+var (
+	_ gateway.Metrics      = (*Collector)(nil)
+	_ prometheus.Collector = (*Collector)(nil)
+)
+
+// NewCollector returns a collector whose metric names start with namespace,
+// or DefaultNamespace when it is empty.
+func NewCollector(namespace string) *Collector {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Requests sent to the Gateway API.",
+		}, []string{"operation", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of the requests sent to the Gateway API, retries included.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "status"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "request_errors_total",
+			Help:      "Requests that failed or were answered with an error status.",
+		}, []string{"operation", "status"}),
+		jwtIssued: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "jwt_issued_total",
+			Help:      "Tokens issued by signing in with a wallet.",
+		}),
+		tokenRefreshes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "token_refreshes_total",
+			Help:      "Tokens exchanged for new ones.",
+		}),
+		signatureFailure: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "signature_failures_total",
+			Help:      "Login messages that could not be signed or whose signature did not verify.",
+		}),
+		uploaded: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "uploaded_bytes_total",
+			Help:      "File content sent to the Gateway API.",
+		}, []string{"operation"}),
+		downloaded: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "downloaded_bytes_total",
+			Help:      "File content received from the Gateway API.",
+		}, []string{"operation"}),
+	}
+}
+
+func (c *Collector) ObserveRequest(operation string, status int, duration time.Duration, err error) {
+	label := statusLabel(status)
+	c.requests.WithLabelValues(operation, label).Inc()
+	c.duration.WithLabelValues(operation, label).Observe(duration.Seconds())
+	if err != nil {
+		c.errors.WithLabelValues(operation, label).Inc()
+	}
+}
+
+func (c *Collector) JWTIssued() {
+	c.jwtIssued.Inc()
+}
+
+func (c *Collector) TokenRefreshed() {
+	c.tokenRefreshes.Inc()
+}
+
+func (c *Collector) SignatureFailed() {
+	c.signatureFailure.Inc()
+}
+
+func (c *Collector) BytesUploaded(operation string, n int64) {
+	c.uploaded.WithLabelValues(operation).Add(float64(n))
+}
+
+func (c *Collector) BytesDownloaded(operation string, n int64) {
+	c.downloaded.WithLabelValues(operation).Add(float64(n))
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range c.collectors() {
+		collector.Describe(ch)
+	}
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range c.collectors() {
+		collector.Collect(ch)
+	}
+}
+
+func (c *Collector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		c.requests,
+		c.duration,
+		c.errors,
+		c.jwtIssued,
+		c.tokenRefreshes,
+		c.signatureFailure,
+		c.uploaded,
+		c.downloaded,
+	}
+}
+
+func statusLabel(status int) string {
+	if status == 0 {
+		return "none"
+	}
+	return strconv.Itoa(status)
+}
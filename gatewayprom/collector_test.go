@@ -0,0 +1,120 @@
+package gatewayprom_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/Gateway-DAO/gateway-go-sdk/gatewayprom"
+	"github.com/Gateway-DAO/gateway-go-sdk/gatewaytest"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	collector := gatewayprom.NewCollector("")
+
+	collector.ObserveRequest("DataAsset.Get", 200, 20*time.Millisecond, nil)
+	collector.ObserveRequest("DataAsset.Get", 404, 10*time.Millisecond, errors.New("not found"))
+	collector.ObserveRequest("DataAsset.Get", 0, time.Second, errors.New("connection refused"))
+	collector.JWTIssued()
+	collector.TokenRefreshed()
+	collector.SignatureFailed()
+	collector.BytesUploaded("DataAsset.UploadFile", 13)
+	collector.BytesDownloaded("DataAsset.Download", 7)
+
+	err := testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP gateway_sdk_requests_total Requests sent to the Gateway API.
+# TYPE gateway_sdk_requests_total counter
+gateway_sdk_requests_total{operation="DataAsset.Get",status="200"} 1
+gateway_sdk_requests_total{operation="DataAsset.Get",status="404"} 1
+gateway_sdk_requests_total{operation="DataAsset.Get",status="none"} 1
+# HELP gateway_sdk_request_errors_total Requests that failed or were answered with an error status.
+# TYPE gateway_sdk_request_errors_total counter
+gateway_sdk_request_errors_total{operation="DataAsset.Get",status="404"} 1
+gateway_sdk_request_errors_total{operation="DataAsset.Get",status="none"} 1
+# HELP gateway_sdk_jwt_issued_total Tokens issued by signing in with a wallet.
+# TYPE gateway_sdk_jwt_issued_total counter
+gateway_sdk_jwt_issued_total 1
+# HELP gateway_sdk_token_refreshes_total Tokens exchanged for new ones.
+# TYPE gateway_sdk_token_refreshes_total counter
+gateway_sdk_token_refreshes_total 1
+# HELP gateway_sdk_signature_failures_total Login messages that could not be signed or whose signature did not verify.
+# TYPE gateway_sdk_signature_failures_total counter
+gateway_sdk_signature_failures_total 1
+# HELP gateway_sdk_uploaded_bytes_total File content sent to the Gateway API.
+# TYPE gateway_sdk_uploaded_bytes_total counter
+gateway_sdk_uploaded_bytes_total{operation="DataAsset.UploadFile"} 13
+# HELP gateway_sdk_downloaded_bytes_total File content received from the Gateway API.
+# TYPE gateway_sdk_downloaded_bytes_total counter
+gateway_sdk_downloaded_bytes_total{operation="DataAsset.Download"} 7
+`),
+		"gateway_sdk_requests_total",
+		"gateway_sdk_request_errors_total",
+		"gateway_sdk_jwt_issued_total",
+		"gateway_sdk_token_refreshes_total",
+		"gateway_sdk_signature_failures_total",
+		"gateway_sdk_uploaded_bytes_total",
+		"gateway_sdk_downloaded_bytes_total",
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 3, testutil.CollectAndCount(collector, "gateway_sdk_request_duration_seconds"))
+}
+
+func TestCollector_WithSDK(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	wallet := gateway.WalletDetails{
+		PrivateKey: "edb0ba5a63c5f9e4f4394560907794fca750704b355413bc04baab896254036a",
+		WalletType: gateway.Ethereum,
+	}
+	_, err := srv.RegisterWallet("alice", wallet)
+	require.NoError(t, err)
+
+	collector := gatewayprom.NewCollector("myapp")
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(collector))
+
+	sdk, err := gateway.New(
+		gateway.WithWallet(wallet.PrivateKey, wallet.WalletType),
+		gateway.WithBaseURL(srv.URL),
+		gateway.WithMetrics(collector),
+	)
+	require.NoError(t, err)
+
+	created, err := sdk.DataAssets.UploadFile("notes.txt", []byte("hello gateway"), nil, nil)
+	require.NoError(t, err)
+	_, err = sdk.DataAssets.Download(int64(created.Id))
+	require.NoError(t, err)
+
+	err = testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP myapp_jwt_issued_total Tokens issued by signing in with a wallet.
+# TYPE myapp_jwt_issued_total counter
+myapp_jwt_issued_total 1
+# HELP myapp_requests_total Requests sent to the Gateway API.
+# TYPE myapp_requests_total counter
+myapp_requests_total{operation="Auth.GetMessage",status="200"} 1
+myapp_requests_total{operation="Auth.Login",status="200"} 1
+myapp_requests_total{operation="DataAsset.Download",status="200"} 1
+myapp_requests_total{operation="DataAsset.UploadFile",status="200"} 1
+# HELP myapp_uploaded_bytes_total File content sent to the Gateway API.
+# TYPE myapp_uploaded_bytes_total counter
+myapp_uploaded_bytes_total{operation="DataAsset.UploadFile"} 13
+# HELP myapp_downloaded_bytes_total File content received from the Gateway API.
+# TYPE myapp_downloaded_bytes_total counter
+myapp_downloaded_bytes_total{operation="DataAsset.Download"} 13
+`),
+		"myapp_jwt_issued_total",
+		"myapp_requests_total",
+		"myapp_uploaded_bytes_total",
+		"myapp_downloaded_bytes_total",
+	)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,107 @@
+package gatewaytest
+
+import (
+	"fmt"
+	"net/http"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+)
+
+// CreateAccount adds an account owning walletAddress and returns its DID.
+func (s *Server) CreateAccount(username string, walletAddress string) (string, error) {
+	did, err := s.store.CreateAccount(username, walletAddress)
+	if err != nil {
+		return "", fmt.Errorf("gatewaytest: %w", err)
+	}
+	return did, nil
+}
+
+// RegisterWallet adds an account owning the wallet an SDK configured with
+// details signs in with, and returns its DID.
+func (s *Server) RegisterWallet(username string, details gateway.WalletDetails) (string, error) {
+	wallet, err := gateway.NewWalletService(details.PrivateKey, details.WalletType)
+	if err != nil {
+		return "", fmt.Errorf("gatewaytest: %w", err)
+	}
+
+	signature, err := wallet.SignMessage("gatewaytest")
+	if err != nil {
+		return "", fmt.Errorf("gatewaytest: %w", err)
+	}
+
+	return s.CreateAccount(username, signature.SigningKey)
+}
+
+// IssueToken returns a token for the account with did, usable as
+// SDKConfig.ApiKey.
+func (s *Server) IssueToken(did string) (string, error) {
+	token, err := s.store.IssueToken(did)
+	if err != nil {
+		return "", fmt.Errorf("gatewaytest: %w", err)
+	}
+	return token, nil
+}
+
+func (s *Server) handleMessage(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, gateway.MessageResponse{Message: s.store.Message()})
+}
+
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	var req gateway.AuthRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	token, err := s.store.Login(req)
+	respond(w, gateway.TokenResponse{Token: token}, err)
+}
+
+func (s *Server) handleRefreshToken(w http.ResponseWriter, r *http.Request, did string) {
+	token, err := s.store.IssueToken(did)
+	respond(w, gateway.TokenResponse{Token: token}, err)
+}
+
+func (s *Server) handleCreateAccount(w http.ResponseWriter, r *http.Request) {
+	var req gateway.AccountCreateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	token, err := s.store.SignUp(req)
+	respond(w, gateway.TokenResponse{Token: token}, err)
+}
+
+func (s *Server) handleGetMe(w http.ResponseWriter, r *http.Request, did string) {
+	me, err := s.store.Me(did)
+	respond(w, me, err)
+}
+
+func (s *Server) handleUpdateMe(w http.ResponseWriter, r *http.Request, did string) {
+	var req gateway.AccountUpdateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	me, err := s.store.UpdateMe(did, req)
+	respond(w, me, err)
+}
+
+func (s *Server) handleGetAccount(w http.ResponseWriter, r *http.Request, did string) {
+	found, err := s.store.Account(r.PathValue("did"))
+	respond(w, found, err)
+}
+
+func (s *Server) handleAddWallet(w http.ResponseWriter, r *http.Request, did string) {
+	var req gateway.WalletCreateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	me, err := s.store.AddWallet(did, req.Address)
+	respond(w, me, err)
+}
+
+func (s *Server) handleRemoveWallet(w http.ResponseWriter, r *http.Request, did string) {
+	me, err := s.store.RemoveWallet(did, r.PathValue("address"))
+	respond(w, me, err)
+}
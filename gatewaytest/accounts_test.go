@@ -0,0 +1,194 @@
+package gatewaytest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/Gateway-DAO/gateway-go-sdk/gatewaytest"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	ethereumWallet = gateway.WalletDetails{
+		PrivateKey: "edb0ba5a63c5f9e4f4394560907794fca750704b355413bc04baab896254036a",
+		WalletType: gateway.Ethereum,
+	}
+	solanaWallet = gateway.WalletDetails{
+		PrivateKey: "T8HMDTLmyQgY6VjvLdEwSSZsexAtiFvfiKBzEsT3ajNQg7jJgnTBK2qDSShz98ND3ihtrwrQcUWokdQr4ozPQt3",
+		WalletType: gateway.Solana,
+	}
+)
+
+// newAccountSDK registers a new account on srv and returns an SDK that
+// authenticates as it with an API key.
+func newAccountSDK(t *testing.T, srv *gatewaytest.Server, username string, walletAddress string) (*gateway.SDK, string) {
+	did, err := srv.CreateAccount(username, walletAddress)
+	require.NoError(t, err)
+
+	token, err := srv.IssueToken(did)
+	require.NoError(t, err)
+
+	return gateway.NewSDK(gateway.SDKConfig{URL: srv.URL, ApiKey: token}), did
+}
+
+func TestWalletLogin(t *testing.T) {
+	for _, wallet := range []gateway.WalletDetails{ethereumWallet, solanaWallet} {
+		t.Run(string(wallet.WalletType), func(t *testing.T) {
+			srv := gatewaytest.NewServer()
+			defer srv.Close()
+
+			did, err := srv.RegisterWallet("alice", wallet)
+			require.NoError(t, err)
+
+			sdk := gateway.NewSDK(gateway.SDKConfig{URL: srv.URL, WalletDetails: wallet})
+
+			me, err := sdk.Account.GetMe()
+			require.NoError(t, err)
+			assert.Equal(t, did, me.Did)
+			assert.Equal(t, "alice", me.Username)
+			assert.Equal(t, string(wallet.WalletType), me.WalletAddresses[0].Chain)
+		})
+	}
+}
+
+func TestWalletLogin_UnknownAccount(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	sdk := gateway.NewSDK(gateway.SDKConfig{URL: srv.URL, WalletDetails: ethereumWallet})
+
+	_, err := sdk.Account.GetMe()
+
+	assert.ErrorIs(t, err, gateway.ErrNotFound)
+}
+
+func TestLogin_RejectsInvalidSignature(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	_, err := srv.RegisterWallet("alice", ethereumWallet)
+	require.NoError(t, err)
+
+	auth := gateway.NewAuthImpl(gateway.Config{Client: resty.New().SetBaseURL(srv.URL)})
+	message, err := auth.GetMessage()
+	require.NoError(t, err)
+
+	wallet, err := gateway.NewWalletService(ethereumWallet.PrivateKey, ethereumWallet.WalletType)
+	require.NoError(t, err)
+	signature, err := wallet.SignMessage("a different message")
+	require.NoError(t, err)
+
+	body, _ := json.Marshal(gateway.AuthRequest{Message: message, Signature: signature.Signature, WalletAddress: signature.SigningKey})
+	res, err := http.Post(srv.URL+gateway.AuthenticateAccount, "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	res.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
+
+func TestLogin_MessageIsSingleUse(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	_, err := srv.RegisterWallet("alice", ethereumWallet)
+	require.NoError(t, err)
+
+	auth := gateway.NewAuthImpl(gateway.Config{Client: resty.New().SetBaseURL(srv.URL)})
+	message, err := auth.GetMessage()
+	require.NoError(t, err)
+
+	wallet, err := gateway.NewWalletService(ethereumWallet.PrivateKey, ethereumWallet.WalletType)
+	require.NoError(t, err)
+	signature, err := wallet.SignMessage(message)
+	require.NoError(t, err)
+
+	_, err = auth.Login(message, signature.Signature, signature.SigningKey)
+	assert.NoError(t, err)
+
+	_, err = auth.Login(message, signature.Signature, signature.SigningKey)
+	assert.ErrorIs(t, err, gateway.ErrUnauthorized)
+}
+
+func TestCreateAccount(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	client := resty.New().SetBaseURL(srv.URL)
+	auth := gateway.NewAuthImpl(gateway.Config{Client: client})
+	message, err := auth.GetMessage()
+	require.NoError(t, err)
+
+	wallet, err := gateway.NewWalletService(solanaWallet.PrivateKey, solanaWallet.WalletType)
+	require.NoError(t, err)
+	signature, err := wallet.SignMessage(message)
+	require.NoError(t, err)
+
+	token, err := gateway.NewAccountsImpl(gateway.Config{Client: client}).Create(gateway.AccountCreateRequest{
+		Username:      "bob",
+		Message:       message,
+		Signature:     signature.Signature,
+		WalletAddress: signature.SigningKey,
+	})
+	require.NoError(t, err)
+
+	sdk := gateway.NewSDK(gateway.SDKConfig{URL: srv.URL, ApiKey: token})
+	me, err := sdk.Account.GetMe()
+	require.NoError(t, err)
+	assert.Equal(t, "bob", me.Username)
+}
+
+func TestAccountManagement(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	sdk, did := newAccountSDK(t, srv, "alice", "0x26bDbA4D3B8a2A4B8e2D2a0d8E5e3d5D6e2A8B4f")
+
+	username := "alice2"
+	me, err := sdk.Account.UpdateMe(gateway.AccountUpdateRequest{Username: &username})
+	require.NoError(t, err)
+	assert.Equal(t, "alice2", me.Username)
+
+	me, err = sdk.Account.Wallets().Add("0x0000000000000000000000000000000000000001")
+	require.NoError(t, err)
+	assert.Len(t, me.WalletAddresses, 2)
+
+	me, err = sdk.Account.Wallets().Remove("0x0000000000000000000000000000000000000001")
+	require.NoError(t, err)
+	assert.Len(t, me.WalletAddresses, 1)
+	assert.Equal(t, did, me.Did)
+
+	_, err = sdk.Account.Wallets().Remove("0x26bDbA4D3B8a2A4B8e2D2a0d8E5e3d5D6e2A8B4f")
+	assert.ErrorIs(t, err, gateway.ErrBadRequest)
+}
+
+func TestRefreshToken(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	sdk, _ := newAccountSDK(t, srv, "alice", "0x26bDbA4D3B8a2A4B8e2D2a0d8E5e3d5D6e2A8B4f")
+
+	token, err := sdk.Auth.GetRefreshToken()
+	require.NoError(t, err)
+
+	refreshed := gateway.NewSDK(gateway.SDKConfig{URL: srv.URL, ApiKey: token})
+	me, err := refreshed.Account.GetMe()
+	require.NoError(t, err)
+	assert.Equal(t, "alice", me.Username)
+}
+
+func TestRejectsInvalidToken(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	sdk := gateway.NewSDK(gateway.SDKConfig{URL: srv.URL, ApiKey: "not-a-token"})
+
+	_, err := sdk.Account.GetMe()
+
+	assert.ErrorIs(t, err, gateway.ErrUnauthorized)
+}
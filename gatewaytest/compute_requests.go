@@ -0,0 +1,62 @@
+package gatewaytest
+
+import (
+	"net/http"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+)
+
+func (s *Server) handleCreateComputeRequest(w http.ResponseWriter, r *http.Request, did string) {
+	var req gateway.ComputeRequestCreateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	created, err := s.store.CreateComputeRequest(did, req)
+	respond(w, created, err)
+}
+
+func (s *Server) handleGetComputeRequest(w http.ResponseWriter, r *http.Request, did string) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	found, err := s.store.ComputeRequest(did, id)
+	respond(w, found, err)
+}
+
+func (s *Server) handleMyComputeRequests(w http.ResponseWriter, r *http.Request, did string) {
+	requests, err := s.store.MyComputeRequests(did)
+	respondPage(w, r, requests, err)
+}
+
+func (s *Server) handleReceivedComputeRequests(w http.ResponseWriter, r *http.Request, did string) {
+	requests, err := s.store.ReceivedComputeRequests(did)
+	respondPage(w, r, requests, err)
+}
+
+func (s *Server) handleAcceptComputeRequest(w http.ResponseWriter, r *http.Request, did string) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req gateway.ComputeRequestAcceptRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	accepted, err := s.store.AcceptComputeRequest(did, id, req.DataAssetId)
+	respond(w, accepted, err)
+}
+
+func (s *Server) handleStartComputeRequest(w http.ResponseWriter, r *http.Request, did string) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	process, err := s.store.StartComputeRequest(did, id)
+	respond(w, process, err)
+}
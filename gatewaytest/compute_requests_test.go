@@ -0,0 +1,120 @@
+package gatewaytest_test
+
+import (
+	"testing"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/Gateway-DAO/gateway-go-sdk/gatewaytest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataModels(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	alice, _ := newAccountSDK(t, srv, "alice", aliceWallet)
+	bob, _ := newAccountSDK(t, srv, "bob", bobWallet)
+
+	model, err := alice.DataModel.Create(gateway.DataModelCreateRequest{
+		Title:  "Person",
+		Schema: map[string]interface{}{"type": "object"},
+	})
+	require.NoError(t, err)
+
+	title := "Human"
+	updated, err := alice.DataModel.Update(int64(model.Id), gateway.DataModelUpdateRequest{Title: &title})
+	require.NoError(t, err)
+	assert.Equal(t, "Human", updated.Title)
+
+	_, err = bob.DataModel.Update(int64(model.Id), gateway.DataModelUpdateRequest{Title: &title})
+	assert.ErrorIs(t, err, gateway.ErrForbidden)
+
+	all, err := bob.DataModel.GetAll(1, 10)
+	require.NoError(t, err)
+	assert.Len(t, all.Data, 1)
+
+	mine, err := bob.DataModel.GetMy(1, 10)
+	require.NoError(t, err)
+	assert.Empty(t, mine.Data)
+
+	_, err = bob.DataModel.GetById(int64(model.Id + 100))
+	assert.ErrorIs(t, err, gateway.ErrNotFound)
+}
+
+func TestComputeRequestFlow(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	requester, _ := newAccountSDK(t, srv, "requester", aliceWallet)
+	owner, ownerDID := newAccountSDK(t, srv, "owner", bobWallet)
+
+	model, err := requester.DataModel.Create(gateway.DataModelCreateRequest{
+		Title:  "Salary",
+		Schema: map[string]interface{}{"type": "object"},
+	})
+	require.NoError(t, err)
+
+	var assetIDs []int
+	for _, amount := range []float64{100, 250} {
+		claim := map[string]interface{}{"amount": amount}
+		created, err := owner.DataAssets.Upload(gateway.CreateDataAssetRequest{Name: "salary", Claim: &claim, DataModelId: &model.Id})
+		require.NoError(t, err)
+		assetIDs = append(assetIDs, created.Id)
+	}
+
+	param := 50
+	request, err := requester.ComputeRequest.Create(gateway.ComputeRequestCreateRequest{
+		Title:                 "Total salary",
+		ComputeFieldName:      "amount",
+		ComputeOperation:      gateway.ComputeOperationAdd,
+		ComputeOperationParam: &param,
+		DataModelId:           model.Id,
+	})
+	require.NoError(t, err)
+	id := int64(*request.Id)
+
+	received, err := owner.ComputeRequest.GetReceived(1, 10)
+	require.NoError(t, err)
+	require.Len(t, received.Data, 1)
+	assert.Equal(t, assetIDs, *received.Data[0].DataAssetsIds)
+
+	for _, assetID := range assetIDs {
+		_, err := owner.ComputeRequest.Accept(id, assetID)
+		require.NoError(t, err)
+	}
+
+	_, err = requester.ComputeRequest.Accept(id, assetIDs[0])
+	assert.ErrorIs(t, err, gateway.ErrBadRequest)
+
+	accepted, err := requester.ComputeRequest.Get(id)
+	require.NoError(t, err)
+	require.Len(t, *accepted.AcceptedDataAssets, 2)
+	assert.Equal(t, ownerDID, *(*accepted.AcceptedDataAssets)[0].AcceptedBy)
+
+	_, err = owner.ComputeRequest.StartComputingProcess(id)
+	assert.ErrorIs(t, err, gateway.ErrForbidden)
+
+	process, err := requester.ComputeRequest.StartComputingProcess(id)
+	require.NoError(t, err)
+	assert.Equal(t, "400", *process.ComputeResult)
+	assert.Equal(t, "completed", *process.ComputeStatus)
+}
+
+func TestComputeRequest_InvalidOperation(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	sdk, _ := newAccountSDK(t, srv, "alice", aliceWallet)
+
+	model, err := sdk.DataModel.Create(gateway.DataModelCreateRequest{Title: "Model", Schema: map[string]interface{}{}})
+	require.NoError(t, err)
+
+	_, err = sdk.ComputeRequest.Create(gateway.ComputeRequestCreateRequest{
+		ComputeFieldName: "amount",
+		ComputeOperation: "median",
+		DataModelId:      model.Id,
+	})
+	assert.ErrorIs(t, err, gateway.ErrBadRequest)
+}
@@ -0,0 +1,204 @@
+package gatewaytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/Gateway-DAO/gateway-go-sdk/internal/memstore"
+)
+
+const maxUploadMemory = 32 << 20
+
+func isMultipart(r *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return mediaType == "multipart/form-data"
+}
+
+// readUpload reads the file, ACL and expiration date of a multipart upload.
+func readUpload(r *http.Request) (memstore.Upload, error) {
+	var upload memstore.Upload
+
+	if err := r.ParseMultipartForm(maxUploadMemory); err != nil {
+		return upload, fmt.Errorf("invalid multipart body: %w", err)
+	}
+
+	file, header, err := r.FormFile("data")
+	if err != nil {
+		return upload, fmt.Errorf("missing data file: %w", err)
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return upload, err
+	}
+
+	if value := r.FormValue("acl"); value != "" {
+		if err := json.Unmarshal([]byte(value), &upload.ACL); err != nil {
+			return upload, fmt.Errorf("invalid acl: %w", err)
+		}
+	}
+	if value := r.FormValue("expiration_date"); value != "" {
+		upload.ExpirationDate = &value
+	}
+
+	upload.Name = header.Filename
+	upload.Type = header.Header.Get("Content-Type")
+	upload.Content = content
+
+	return upload, nil
+}
+
+func (s *Server) handleCreateAsset(w http.ResponseWriter, r *http.Request, did string) {
+	var created gateway.DataAssetIDRequestAndResponse
+	var err error
+
+	if isMultipart(r) {
+		upload, readErr := readUpload(r)
+		if readErr != nil {
+			writeError(w, http.StatusBadRequest, readErr.Error())
+			return
+		}
+		created, err = s.store.UploadFile(did, upload)
+	} else {
+		var req gateway.CreateDataAssetRequest
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+		created, err = s.store.CreateAsset(did, req)
+	}
+
+	respond(w, created, err)
+}
+
+func (s *Server) handleGetAsset(w http.ResponseWriter, r *http.Request, did string) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	asset, err := s.store.Asset(did, id)
+	respond(w, asset, err)
+}
+
+func (s *Server) handleUpdateAsset(w http.ResponseWriter, r *http.Request, did string) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var asset gateway.PublicDataAsset
+	var err error
+
+	if isMultipart(r) {
+		upload, readErr := readUpload(r)
+		if readErr != nil {
+			writeError(w, http.StatusBadRequest, readErr.Error())
+			return
+		}
+		asset, err = s.store.UpdateFile(did, id, upload)
+	} else {
+		var req gateway.UpdateDataAssetRequest
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+		asset, err = s.store.UpdateAsset(did, id, req)
+	}
+
+	respond(w, asset, err)
+}
+
+func (s *Server) handleDeleteAsset(w http.ResponseWriter, r *http.Request, did string) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	message, err := s.store.DeleteAsset(did, id)
+	respond(w, message, err)
+}
+
+func (s *Server) handleDownloadAsset(w http.ResponseWriter, r *http.Request, did string) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	file, err := s.store.Download(did, id)
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", file.Type)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.Name}))
+	w.Write(file.Content)
+}
+
+func (s *Server) handleShareAsset(w http.ResponseWriter, r *http.Request, did string) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req []gateway.ShareDataAssetRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	shared, err := s.store.Share(did, id, req)
+	respond(w, shared, err)
+}
+
+// changeACL decodes the ACL list of the request and hands it to change.
+func (s *Server) changeACL(w http.ResponseWriter, r *http.Request, change func(id int, acl []gateway.ACLRequest) (interface{}, error)) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req []gateway.ACLRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	result, err := change(id, req)
+	respond(w, result, err)
+}
+
+func (s *Server) handleAddACL(w http.ResponseWriter, r *http.Request, did string) {
+	s.changeACL(w, r, func(id int, acl []gateway.ACLRequest) (interface{}, error) {
+		return s.store.AddACL(did, id, acl)
+	})
+}
+
+func (s *Server) handleUpdateACL(w http.ResponseWriter, r *http.Request, did string) {
+	s.changeACL(w, r, func(id int, acl []gateway.ACLRequest) (interface{}, error) {
+		return s.store.UpdateACL(did, id, acl)
+	})
+}
+
+func (s *Server) handleDeleteACL(w http.ResponseWriter, r *http.Request, did string) {
+	s.changeACL(w, r, func(id int, acl []gateway.ACLRequest) (interface{}, error) {
+		return s.store.DeleteACL(did, id, acl)
+	})
+}
+
+func (s *Server) handleCreatedAssets(w http.ResponseWriter, r *http.Request, did string) {
+	assets, err := s.store.CreatedAssets(did)
+	respondPage(w, r, assets, err)
+}
+
+func (s *Server) handleReceivedAssets(w http.ResponseWriter, r *http.Request, did string) {
+	assets, err := s.store.ReceivedAssets(did)
+	respondPage(w, r, assets, err)
+}
+
+func (s *Server) handleAccessibleAssets(w http.ResponseWriter, r *http.Request, did string) {
+	assets, err := s.store.AccessibleAssets(did)
+	respondPage(w, r, assets, err)
+}
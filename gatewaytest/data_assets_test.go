@@ -0,0 +1,170 @@
+package gatewaytest_test
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/Gateway-DAO/gateway-go-sdk/gatewaytest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	aliceWallet = "0x26bDbA4D3B8a2A4B8e2D2a0d8E5e3d5D6e2A8B4f"
+	bobWallet   = "0x0000000000000000000000000000000000000002"
+)
+
+func TestUploadAndDownload(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	sdk, did := newAccountSDK(t, srv, "alice", aliceWallet)
+
+	created, err := sdk.DataAssets.UploadFile("notes.txt", []byte("hello gateway"), nil, nil)
+	require.NoError(t, err)
+	id := int64(created.Id)
+
+	asset, err := sdk.DataAssets.Get(id)
+	require.NoError(t, err)
+	assert.Equal(t, "notes.txt", asset.Name)
+	assert.Equal(t, did, asset.CreatedBy)
+	assert.Equal(t, 13, asset.Size)
+
+	var buf bytes.Buffer
+	metadata, err := sdk.DataAssets.DownloadTo(id, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello gateway", buf.String())
+	assert.Equal(t, "notes.txt", metadata.FileName)
+	assert.Equal(t, "text/plain", metadata.FileType)
+
+	_, err = sdk.DataAssets.UpdateFileReader(strconv.Itoa(created.Id), "notes.txt", strings.NewReader("updated"), gateway.UploadOptions{})
+	require.NoError(t, err)
+
+	file, err := sdk.DataAssets.Download(id)
+	require.NoError(t, err)
+	assert.Equal(t, "updated", string(file.FileContent))
+
+	_, err = sdk.DataAssets.DeleteAsset(id)
+	require.NoError(t, err)
+
+	_, err = sdk.DataAssets.Get(id)
+	assert.ErrorIs(t, err, gateway.ErrNotFound)
+}
+
+func TestClaimAsset(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	sdk, _ := newAccountSDK(t, srv, "alice", aliceWallet)
+
+	claim := map[string]interface{}{"age": 30.0}
+	created, err := sdk.DataAssets.Upload(gateway.CreateDataAssetRequest{Name: "profile", Claim: &claim})
+	require.NoError(t, err)
+
+	name := "renamed"
+	asset, err := sdk.DataAssets.UpdateAsset(strconv.Itoa(created.Id), gateway.UpdateDataAssetRequest{Name: &name})
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", asset.Name)
+
+	file, err := sdk.DataAssets.Download(int64(created.Id))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"age": 30}`, string(file.FileContent))
+}
+
+func TestACL(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	alice, _ := newAccountSDK(t, srv, "alice", aliceWallet)
+	bob, bobDID := newAccountSDK(t, srv, "bob", bobWallet)
+
+	created, err := alice.DataAssets.UploadFile("secret.txt", []byte("secret"), nil, nil)
+	require.NoError(t, err)
+	id := int64(created.Id)
+
+	_, err = bob.DataAssets.Get(id)
+	assert.ErrorIs(t, err, gateway.ErrNotFound)
+
+	shared, err := alice.DataAssets.Share(id, []gateway.ShareDataAssetRequest{{Addresses: []string{bobWallet}}})
+	require.NoError(t, err)
+	require.Len(t, shared, 1)
+	assert.Equal(t, bobDID, *shared[0].Did)
+
+	_, err = bob.DataAssets.Get(id)
+	assert.NoError(t, err)
+
+	_, err = bob.DataAssets.UpdateFile(strconv.Itoa(created.Id), "secret.txt", []byte("changed"), nil, nil)
+	assert.ErrorIs(t, err, gateway.ErrForbidden)
+
+	acl, err := alice.ACL.Add(id, []gateway.ACLRequest{{Address: bobDID, Roles: []gateway.TypesAccessLevel{gateway.RoleUpdate}}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"update"}, acl.Roles)
+
+	_, err = bob.DataAssets.UpdateFile(strconv.Itoa(created.Id), "secret.txt", []byte("changed"), nil, nil)
+	assert.NoError(t, err)
+
+	received, err := bob.DataAssets.GetReceivedByMe(1, 10)
+	require.NoError(t, err)
+	assert.Len(t, received.Data, 1)
+
+	_, err = alice.ACL.Delete(id, []gateway.ACLRequest{{Address: bobWallet}, {Address: bobDID}})
+	require.NoError(t, err)
+
+	_, err = bob.DataAssets.Get(id)
+	assert.ErrorIs(t, err, gateway.ErrNotFound)
+}
+
+func TestUploadWithACLAndExpiration(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	alice, _ := newAccountSDK(t, srv, "alice", aliceWallet)
+	bob, _ := newAccountSDK(t, srv, "bob", bobWallet)
+
+	acl := []gateway.ACLRequest{{Address: bobWallet, Roles: []gateway.TypesAccessLevel{gateway.RoleView}}}
+	expired := time.Now().Add(-time.Hour)
+
+	live, err := alice.DataAssets.UploadFile("live.txt", []byte("live"), &acl, nil)
+	require.NoError(t, err)
+	gone, err := alice.DataAssets.UploadFile("gone.txt", []byte("gone"), &acl, &expired)
+	require.NoError(t, err)
+
+	_, err = bob.DataAssets.Get(int64(live.Id))
+	assert.NoError(t, err)
+
+	_, err = alice.DataAssets.Get(int64(gone.Id))
+	assert.ErrorIs(t, err, gateway.ErrNotFound)
+}
+
+func TestCreatedByMePagination(t *testing.T) {
+	srv := gatewaytest.NewServer()
+	defer srv.Close()
+
+	sdk, _ := newAccountSDK(t, srv, "alice", aliceWallet)
+
+	for i := 0; i < 5; i++ {
+		_, err := sdk.DataAssets.UploadFile("file"+strconv.Itoa(i), []byte("content"), nil, nil)
+		require.NoError(t, err)
+	}
+
+	page, err := sdk.DataAssets.GetCreatedByMe(2, 2)
+	require.NoError(t, err)
+	assert.Len(t, page.Data, 2)
+	assert.Equal(t, gateway.HelperMeta{CurrentPage: 2, ItemsPerPage: 2, TotalItems: 5, TotalPages: 3}, page.Meta)
+	assert.Equal(t, "/data-assets/created?page=3&page_size=2", page.Links.Next)
+
+	it := sdk.DataAssets.AllCreatedByMe(2)
+	defer it.Close()
+
+	var names []string
+	for it.Next() {
+		names = append(names, it.Item().Name)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"file0", "file1", "file2", "file3", "file4"}, names)
+}
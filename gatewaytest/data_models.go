@@ -0,0 +1,62 @@
+package gatewaytest
+
+import (
+	"net/http"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+)
+
+func (s *Server) handleDataModels(w http.ResponseWriter, r *http.Request, did string) {
+	models, err := s.store.DataModels(did)
+	respondPage(w, r, models, err)
+}
+
+func (s *Server) handleMyDataModels(w http.ResponseWriter, r *http.Request, did string) {
+	models, err := s.store.MyDataModels(did)
+	respondPage(w, r, models, err)
+}
+
+func (s *Server) handleGetDataModel(w http.ResponseWriter, r *http.Request, did string) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	model, err := s.store.DataModel(did, id)
+	respond(w, model, err)
+}
+
+func (s *Server) handleCreateDataModel(w http.ResponseWriter, r *http.Request, did string) {
+	var req gateway.DataModelCreateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	model, err := s.store.CreateDataModel(did, req)
+	respond(w, model, err)
+}
+
+func (s *Server) handleUpdateDataModel(w http.ResponseWriter, r *http.Request, did string) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req gateway.DataModelUpdateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	model, err := s.store.UpdateDataModel(did, id, req)
+	respond(w, model, err)
+}
+
+func (s *Server) handleDataModelAssets(w http.ResponseWriter, r *http.Request, did string) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	assets, err := s.store.ModelAssets(did, id)
+	respondPage(w, r, assets, err)
+}
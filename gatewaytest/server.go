@@ -0,0 +1,186 @@
+// Package gatewaytest provides an in-process stand-in of the Gateway API for
+// integration tests.
+//
+// A Server keeps accounts, data assets, ACLs, data models and compute
+// requests in memory and serves the routes of the client package over a real
+// HTTP listener, so an SDK pointed at it works end to end without network
+// access:
+//
+//	srv := gatewaytest.NewServer()
+//	defer srv.Close()
+//
+//	srv.RegisterWallet("alice", wallet)
+//	sdk := client.NewSDK(client.SDKConfig{URL: srv.URL, WalletDetails: wallet})
+//
+// Sign-in messages are verified with the same signature checks the SDK uses
+// for Ethereum, Solana and Sui wallets.
+package gatewaytest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/Gateway-DAO/gateway-go-sdk/internal/memstore"
+)
+
+const (
+	DefaultTokenTTL   = memstore.DefaultTokenTTL
+	DefaultMessageTTL = memstore.DefaultMessageTTL
+)
+
+// Server is an httptest.Server that implements the Gateway API in memory.
+// It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	store *memstore.Store
+}
+
+// NewServer starts a Server with empty state. Close it when done.
+func NewServer() *Server {
+	s := NewUnstartedServer()
+	s.Start()
+	return s
+}
+
+// NewUnstartedServer returns a Server that is not listening yet, so its
+// underlying httptest.Server can be configured before calling Start or
+// StartTLS.
+func NewUnstartedServer() *Server {
+	s := &Server{store: memstore.New()}
+	s.Server = httptest.NewUnstartedServer(s.routes())
+
+	return s
+}
+
+// SetTokenTTL changes the lifetime of the tokens issued from now on.
+func (s *Server) SetTokenTTL(ttl time.Duration) {
+	s.store.SetTokenTTL(ttl)
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+gateway.GenerateSignMessage, s.handleMessage)
+	mux.HandleFunc("POST "+gateway.AuthenticateAccount, s.handleLogin)
+	mux.HandleFunc("GET "+gateway.RefreshToken, s.authenticated(s.handleRefreshToken))
+
+	mux.HandleFunc("POST "+gateway.CreateAccount, s.handleCreateAccount)
+	mux.HandleFunc("GET "+gateway.GetMyAccount, s.authenticated(s.handleGetMe))
+	mux.HandleFunc("PATCH "+gateway.UpdateAccount, s.authenticated(s.handleUpdateMe))
+	mux.HandleFunc("GET "+gateway.GetAccount, s.authenticated(s.handleGetAccount))
+	mux.HandleFunc("POST "+gateway.AddWallet, s.authenticated(s.handleAddWallet))
+	mux.HandleFunc("DELETE "+gateway.RemoveWallet, s.authenticated(s.handleRemoveWallet))
+
+	mux.HandleFunc("POST "+gateway.CreateANewDataAsset, s.authenticated(s.handleCreateAsset))
+	mux.HandleFunc("GET "+gateway.GetCreatedDataAssets, s.authenticated(s.handleCreatedAssets))
+	mux.HandleFunc("GET "+gateway.GetReceivedDataAssets, s.authenticated(s.handleReceivedAssets))
+	mux.HandleFunc("GET "+gateway.GetAllDataAssetsThatTheAuthenticatedUserHasAccessTo, s.authenticated(s.handleAccessibleAssets))
+	mux.HandleFunc("GET "+gateway.GetDataAssetByID, s.authenticated(s.handleGetAsset))
+	mux.HandleFunc("PUT "+gateway.UpdateDataAssetByID, s.authenticated(s.handleUpdateAsset))
+	mux.HandleFunc("DELETE "+gateway.DeleteDataAssetByID, s.authenticated(s.handleDeleteAsset))
+	mux.HandleFunc("GET "+gateway.DownloadDataAssetByID, s.authenticated(s.handleDownloadAsset))
+	mux.HandleFunc("POST "+gateway.ShareDataAssetByID, s.authenticated(s.handleShareAsset))
+	mux.HandleFunc("POST "+gateway.AssignACLItemsToDataAsset, s.authenticated(s.handleAddACL))
+	mux.HandleFunc("PUT "+gateway.UpdateACLItemsToDataAsset, s.authenticated(s.handleUpdateACL))
+	mux.HandleFunc("DELETE "+gateway.DeleteAssignedRoleByACL, s.authenticated(s.handleDeleteACL))
+
+	mux.HandleFunc("GET "+gateway.GetDataModels, s.authenticated(s.handleDataModels))
+	mux.HandleFunc("POST "+gateway.CreateDataModel, s.authenticated(s.handleCreateDataModel))
+	mux.HandleFunc("GET "+gateway.GetDataModelsByUser, s.authenticated(s.handleMyDataModels))
+	mux.HandleFunc("GET "+gateway.GetDataModelByID, s.authenticated(s.handleGetDataModel))
+	mux.HandleFunc("PUT "+gateway.UpdateDataModel, s.authenticated(s.handleUpdateDataModel))
+	mux.HandleFunc("GET "+gateway.GetDataAssetsByDataModelID, s.authenticated(s.handleDataModelAssets))
+
+	mux.HandleFunc("POST "+gateway.CreateComputeRequest, s.authenticated(s.handleCreateComputeRequest))
+	mux.HandleFunc("GET "+gateway.GetComputeRequests, s.authenticated(s.handleMyComputeRequests))
+	mux.HandleFunc("GET "+gateway.GetComputeRequestsReceived, s.authenticated(s.handleReceivedComputeRequests))
+	mux.HandleFunc("GET "+gateway.GetComputeRequest, s.authenticated(s.handleGetComputeRequest))
+	mux.HandleFunc("POST "+gateway.AcceptComputeRequest, s.authenticated(s.handleAcceptComputeRequest))
+	mux.HandleFunc("POST "+gateway.CreateComputingProcess, s.authenticated(s.handleStartComputeRequest))
+
+	return mux
+}
+
+// authenticated resolves the token of the request to the DID of its
+// account. Like the SDK, it takes the token either bare or with the Bearer
+// scheme.
+func (s *Server) authenticated(next func(w http.ResponseWriter, r *http.Request, did string)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+		did, err := s.store.Authenticate(token)
+		if err != nil {
+			writeStoreError(w, err)
+			return
+		}
+
+		next(w, r, did)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// writeStoreError answers with the status the store picked for err.
+func writeStoreError(w http.ResponseWriter, err error) {
+	var apiErr *gateway.APIError
+	if errors.As(err, &apiErr) {
+		writeError(w, apiErr.StatusCode, apiErr.Message)
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err.Error())
+}
+
+// respond writes v, or the error of the store call that produced it.
+func respond(w http.ResponseWriter, v interface{}, err error) {
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, v)
+}
+
+// respondPage writes the page of items selected by the page and page_size
+// query parameters, with links to the neighbouring pages of the request
+// path.
+func respondPage[T any](w http.ResponseWriter, r *http.Request, items []T, err error) {
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	writeJSON(w, http.StatusOK, memstore.Paginate(items, page, pageSize, r.URL.Path))
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func pathID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid id")
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,370 @@
+package memstore
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type account struct {
+	did               string
+	username          string
+	usernameUpdatedAt string
+	profilePicture    *string
+	wallets           []gateway.ModelWalletAddress
+	createdAt         string
+	updatedAt         string
+}
+
+func (a *account) myAccount(storageSize int) gateway.MyAccountResponse {
+	return gateway.MyAccountResponse{
+		Did:               a.did,
+		Username:          a.username,
+		UsernameUpdatedAt: a.usernameUpdatedAt,
+		ProfilePicture:    a.profilePicture,
+		WalletAddresses:   append([]gateway.ModelWalletAddress{}, a.wallets...),
+		StorageSize:       storageSize,
+		CreatedAt:         a.createdAt,
+		UpdatedAt:         a.updatedAt,
+	}
+}
+
+// normalizeAddress makes hex addresses case-insensitive; Solana addresses
+// are base58 and stay as they are.
+func normalizeAddress(address string) string {
+	if strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X") {
+		return strings.ToLower(address)
+	}
+	return address
+}
+
+func walletChain(address string) (gateway.WalletTypeEnum, bool) {
+	chain, _, ok := gateway.DefaultVerifierRegistry().Detect(address)
+	return chain, ok
+}
+
+func verifySignature(message string, signature string, address string) error {
+	_, verifier, ok := gateway.DefaultVerifierRegistry().Detect(address)
+	if !ok {
+		return errors.New("unsupported wallet address")
+	}
+
+	valid, err := verifier.VerifyMessage(signature, message, address)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// caller returns the account acting with did.
+func (s *Store) caller(did string) (*account, error) {
+	found, ok := s.accounts[did]
+	if !ok {
+		return nil, apiError(http.StatusUnauthorized, "account no longer exists")
+	}
+	return found, nil
+}
+
+// CreateAccount adds an account owning walletAddress without a sign-in
+// round trip and returns its DID.
+func (s *Store) CreateAccount(username string, walletAddress string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	created, err := s.createAccount(username, walletAddress)
+	if err != nil {
+		return "", err
+	}
+	return created.did, nil
+}
+
+// IssueToken returns a token for the account with did.
+func (s *Store) IssueToken(did string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.caller(did); err != nil {
+		return "", apiError(http.StatusNotFound, "account %q not found", did)
+	}
+	return s.issueToken(did)
+}
+
+// Authenticate returns the DID of the account token was issued to.
+func (s *Store) Authenticate(token string) (string, error) {
+	if token == "" {
+		return "", apiError(http.StatusUnauthorized, "missing token")
+	}
+
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return s.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return "", apiError(http.StatusUnauthorized, "invalid token: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.caller(claims.Subject); err != nil {
+		return "", err
+	}
+	return claims.Subject, nil
+}
+
+// Message returns a sign-in message that can be used once. Messages are
+// forgotten when they are used and, if never used, once they have expired.
+func (s *Store) Message() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for message, expiresAt := range s.messages {
+		if now.After(expiresAt) {
+			delete(s.messages, message)
+		}
+	}
+
+	message := "Sign in to Gateway with nonce " + randomHex(16)
+	s.messages[message] = now.Add(DefaultMessageTTL)
+	return message
+}
+
+// Login checks a signed sign-in message and returns a token for the
+// account owning the wallet.
+func (s *Store) Login(req gateway.AuthRequest) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkSignedMessage(req.Message, req.Signature, req.WalletAddress); err != nil {
+		return "", err
+	}
+
+	did, ok := s.wallets[normalizeAddress(req.WalletAddress)]
+	if !ok {
+		return "", apiError(http.StatusNotFound, "no account for wallet")
+	}
+	return s.issueToken(did)
+}
+
+// SignUp checks a signed sign-in message, creates the account and returns
+// a token for it.
+func (s *Store) SignUp(req gateway.AccountCreateRequest) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkSignedMessage(req.Message, req.Signature, req.WalletAddress); err != nil {
+		return "", err
+	}
+
+	created, err := s.createAccount(req.Username, req.WalletAddress)
+	if err != nil {
+		return "", err
+	}
+	return s.issueToken(created.did)
+}
+
+// checkSignedMessage accepts a sign-in message once, while it has not
+// expired, and verifies its signature.
+func (s *Store) checkSignedMessage(message string, signature string, address string) error {
+	expiresAt, ok := s.messages[message]
+	delete(s.messages, message)
+	if !ok || time.Now().After(expiresAt) {
+		return apiError(http.StatusUnauthorized, "unknown or expired message")
+	}
+
+	if err := verifySignature(message, signature, address); err != nil {
+		return apiError(http.StatusUnauthorized, "%v", err)
+	}
+	return nil
+}
+
+func (s *Store) createAccount(username string, walletAddress string) (*account, error) {
+	if username == "" {
+		return nil, badRequest("username is required")
+	}
+
+	chain, ok := walletChain(walletAddress)
+	if !ok {
+		return nil, badRequest("unsupported wallet address")
+	}
+
+	if _, taken := s.wallets[normalizeAddress(walletAddress)]; taken {
+		return nil, apiError(http.StatusConflict, "wallet already belongs to an account")
+	}
+	for _, existing := range s.accounts {
+		if existing.username == username {
+			return nil, apiError(http.StatusConflict, "username already taken")
+		}
+	}
+
+	now := timestamp()
+	created := &account{
+		did:               "did:gatewayid:" + randomHex(16),
+		username:          username,
+		usernameUpdatedAt: now,
+		createdAt:         now,
+		updatedAt:         now,
+	}
+	created.wallets = []gateway.ModelWalletAddress{s.newWallet(walletAddress, chain)}
+
+	s.accounts[created.did] = created
+	s.wallets[normalizeAddress(walletAddress)] = created.did
+
+	return created, nil
+}
+
+func (s *Store) newWallet(address string, chain gateway.WalletTypeEnum) gateway.ModelWalletAddress {
+	return gateway.ModelWalletAddress{
+		Id:        s.nextID(),
+		Address:   address,
+		Chain:     string(chain),
+		CreatedAt: timestamp(),
+	}
+}
+
+func (s *Store) issueToken(did string) (string, error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   did,
+		ID:        randomHex(8),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(s.tokenTTL)),
+	})
+
+	signed, err := token.SignedString(s.secret)
+	if err != nil {
+		return "", apiError(http.StatusInternalServerError, "%v", err)
+	}
+	return signed, nil
+}
+
+func (s *Store) storageSize(did string) int {
+	size := 0
+	for _, asset := range s.assets {
+		if asset.owner == did {
+			size += asset.Size
+		}
+	}
+	return size
+}
+
+// Me returns the account acting with did.
+func (s *Store) Me(did string) (gateway.MyAccountResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	caller, err := s.caller(did)
+	if err != nil {
+		return gateway.MyAccountResponse{}, err
+	}
+	return caller.myAccount(s.storageSize(did)), nil
+}
+
+// UpdateMe changes the username or profile picture of the account acting
+// with did.
+func (s *Store) UpdateMe(did string, req gateway.AccountUpdateRequest) (gateway.MyAccountResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	caller, err := s.caller(did)
+	if err != nil {
+		return gateway.MyAccountResponse{}, err
+	}
+
+	now := timestamp()
+	if req.Username != nil && *req.Username != caller.username {
+		for _, existing := range s.accounts {
+			if existing.username == *req.Username {
+				return gateway.MyAccountResponse{}, apiError(http.StatusConflict, "username already taken")
+			}
+		}
+		caller.username = *req.Username
+		caller.usernameUpdatedAt = now
+	}
+	if req.ProfilePicture != nil {
+		caller.profilePicture = req.ProfilePicture
+	}
+	caller.updatedAt = now
+
+	return caller.myAccount(s.storageSize(did)), nil
+}
+
+// Account returns the public profile of the account with did.
+func (s *Store) Account(did string) (gateway.PublicAccountResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found, ok := s.accounts[did]
+	if !ok {
+		return gateway.PublicAccountResponse{}, apiError(http.StatusNotFound, "account not found")
+	}
+
+	return gateway.PublicAccountResponse{
+		Did:            found.did,
+		Username:       found.username,
+		ProfilePicture: found.profilePicture,
+	}, nil
+}
+
+// AddWallet links address to the account acting with did.
+func (s *Store) AddWallet(did string, address string) (gateway.MyAccountResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	caller, err := s.caller(did)
+	if err != nil {
+		return gateway.MyAccountResponse{}, err
+	}
+
+	chain, ok := walletChain(address)
+	if !ok {
+		return gateway.MyAccountResponse{}, badRequest("unsupported wallet address")
+	}
+	if _, taken := s.wallets[normalizeAddress(address)]; taken {
+		return gateway.MyAccountResponse{}, apiError(http.StatusConflict, "wallet already belongs to an account")
+	}
+
+	caller.wallets = append(caller.wallets, s.newWallet(address, chain))
+	caller.updatedAt = timestamp()
+	s.wallets[normalizeAddress(address)] = did
+
+	return caller.myAccount(s.storageSize(did)), nil
+}
+
+// RemoveWallet unlinks address from the account acting with did. The last
+// wallet of an account cannot be removed.
+func (s *Store) RemoveWallet(did string, address string) (gateway.MyAccountResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	caller, err := s.caller(did)
+	if err != nil {
+		return gateway.MyAccountResponse{}, err
+	}
+
+	address = normalizeAddress(address)
+	for i, wallet := range caller.wallets {
+		if normalizeAddress(wallet.Address) != address {
+			continue
+		}
+		if len(caller.wallets) == 1 {
+			return gateway.MyAccountResponse{}, badRequest("cannot remove the last wallet of an account")
+		}
+
+		caller.wallets = append(caller.wallets[:i], caller.wallets[i+1:]...)
+		caller.updatedAt = timestamp()
+		delete(s.wallets, address)
+
+		return caller.myAccount(s.storageSize(did)), nil
+	}
+
+	return gateway.MyAccountResponse{}, apiError(http.StatusNotFound, "wallet not found")
+}
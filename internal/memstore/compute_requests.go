@@ -0,0 +1,324 @@
+package memstore
+
+import (
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+)
+
+var computeOperations = []gateway.TypesComputeOperation{
+	gateway.ComputeOperationAdd,
+	gateway.ComputeOperationSubtract,
+	gateway.ComputeOperationMultiply,
+	gateway.ComputeOperationDivide,
+	gateway.ComputeOperationSum,
+	gateway.ComputeOperationGreaterThan,
+	gateway.ComputeOperationGreaterThanOrEqual,
+	gateway.ComputeOperationLessThan,
+	gateway.ComputeOperationEqual,
+	gateway.ComputeOperationNotEqual,
+	gateway.ComputeOperationExponential,
+	gateway.ComputeOperationRemainder,
+}
+
+type acceptance struct {
+	by      string
+	assetID int
+}
+
+type computeRequest struct {
+	id          int
+	title       string
+	description string
+	field       string
+	operation   gateway.TypesComputeOperation
+	param       *int
+	createdBy   string
+	dataModelID int
+	createdAt   string
+	updatedAt   string
+	accepted    []acceptance
+}
+
+func (c *computeRequest) response() gateway.ComputeRequestResponse {
+	operation := string(c.operation)
+	accepted := []gateway.AcceptedDataAssetResponse{}
+	for _, item := range c.accepted {
+		by, assetID := item.by, item.assetID
+		accepted = append(accepted, gateway.AcceptedDataAssetResponse{AcceptedBy: &by, DataAssetId: &assetID})
+	}
+
+	return gateway.ComputeRequestResponse{
+		Id:                    &c.id,
+		Title:                 &c.title,
+		Description:           &c.description,
+		ComputeFieldName:      &c.field,
+		ComputeOperation:      &operation,
+		ComputeOperationParam: c.param,
+		CreatedBy:             &c.createdBy,
+		DataModelId:           &c.dataModelID,
+		CreatedAt:             &c.createdAt,
+		UpdatedAt:             &c.updatedAt,
+		AcceptedDataAssets:    &accepted,
+	}
+}
+
+func (c *computeRequest) received(assetIDs []int) gateway.ComputeRequestReceivedResponse {
+	res := c.response()
+	return gateway.ComputeRequestReceivedResponse{
+		Id:                    res.Id,
+		Title:                 res.Title,
+		Description:           res.Description,
+		ComputeFieldName:      res.ComputeFieldName,
+		ComputeOperation:      res.ComputeOperation,
+		ComputeOperationParam: res.ComputeOperationParam,
+		CreatedBy:             res.CreatedBy,
+		DataModelId:           res.DataModelId,
+		CreatedAt:             res.CreatedAt,
+		UpdatedAt:             res.UpdatedAt,
+		DataAssetsIds:         &assetIDs,
+	}
+}
+
+// compute applies the operation of c to the sum of the compute field over
+// the accepted data assets, using the operation param as second operand.
+func (c *computeRequest) compute(assets map[int]*dataAsset) (string, error) {
+	total := 0.0
+	for _, item := range c.accepted {
+		asset, ok := assets[item.assetID]
+		if !ok {
+			continue
+		}
+		value, ok := asset.claim[c.field].(float64)
+		if !ok {
+			return "", badRequest("data asset %d has no numeric %q field", asset.Id, c.field)
+		}
+		total += value
+	}
+
+	param := 0.0
+	if c.param != nil {
+		param = float64(*c.param)
+	}
+
+	format := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+
+	switch c.operation {
+	case gateway.ComputeOperationSum:
+		return format(total), nil
+	case gateway.ComputeOperationAdd:
+		return format(total + param), nil
+	case gateway.ComputeOperationSubtract:
+		return format(total - param), nil
+	case gateway.ComputeOperationMultiply:
+		return format(total * param), nil
+	case gateway.ComputeOperationDivide:
+		if param == 0 {
+			return "", badRequest("division by zero")
+		}
+		return format(total / param), nil
+	case gateway.ComputeOperationExponential:
+		return format(math.Pow(total, param)), nil
+	case gateway.ComputeOperationRemainder:
+		if param == 0 {
+			return "", badRequest("division by zero")
+		}
+		return format(math.Mod(total, param)), nil
+	case gateway.ComputeOperationGreaterThan:
+		return strconv.FormatBool(total > param), nil
+	case gateway.ComputeOperationGreaterThanOrEqual:
+		return strconv.FormatBool(total >= param), nil
+	case gateway.ComputeOperationLessThan:
+		return strconv.FormatBool(total < param), nil
+	case gateway.ComputeOperationEqual:
+		return strconv.FormatBool(total == param), nil
+	case gateway.ComputeOperationNotEqual:
+		return strconv.FormatBool(total != param), nil
+	}
+
+	return "", badRequest("unsupported compute operation %q", c.operation)
+}
+
+// ownedModelAssets returns the ids of the assets of did that belong to the
+// data model of c.
+func (s *Store) ownedModelAssets(c *computeRequest, did string) []int {
+	ids := []int{}
+	for _, asset := range s.findAssets(func(asset *dataAsset) bool {
+		return asset.owner == did && asset.DataModelId != nil && *asset.DataModelId == c.dataModelID
+	}) {
+		ids = append(ids, asset.Id)
+	}
+	return ids
+}
+
+func (s *Store) findComputeRequests(keep func(c *computeRequest) bool) []*computeRequest {
+	var found []*computeRequest
+	for _, c := range s.computes {
+		if keep(c) {
+			found = append(found, c)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].id < found[j].id })
+	return found
+}
+
+func (s *Store) lookupComputeRequest(did string, id int) (*computeRequest, error) {
+	if _, err := s.caller(did); err != nil {
+		return nil, err
+	}
+
+	c, ok := s.computes[id]
+	if !ok {
+		return nil, apiError(http.StatusNotFound, "compute request not found")
+	}
+	return c, nil
+}
+
+// CreateComputeRequest stores a compute request created by the account
+// acting with did.
+func (s *Store) CreateComputeRequest(did string, req gateway.ComputeRequestCreateRequest) (gateway.ComputeRequestResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.caller(did); err != nil {
+		return gateway.ComputeRequestResponse{}, err
+	}
+
+	operation, _ := req.ComputeOperation.(string)
+	if operation == "" {
+		if typed, ok := req.ComputeOperation.(gateway.TypesComputeOperation); ok {
+			operation = string(typed)
+		}
+	}
+	if !slices.Contains(computeOperations, gateway.TypesComputeOperation(operation)) {
+		return gateway.ComputeRequestResponse{}, badRequest("unsupported compute operation %v", req.ComputeOperation)
+	}
+	if req.ComputeFieldName == "" {
+		return gateway.ComputeRequestResponse{}, badRequest("compute_field_name is required")
+	}
+	if _, ok := s.models[req.DataModelId]; !ok {
+		return gateway.ComputeRequestResponse{}, badRequest("data model not found")
+	}
+
+	now := timestamp()
+	c := &computeRequest{
+		id:          s.nextID(),
+		title:       req.Title,
+		description: req.Description,
+		field:       req.ComputeFieldName,
+		operation:   gateway.TypesComputeOperation(operation),
+		param:       req.ComputeOperationParam,
+		createdBy:   did,
+		dataModelID: req.DataModelId,
+		createdAt:   now,
+		updatedAt:   now,
+	}
+	s.computes[c.id] = c
+
+	return c.response(), nil
+}
+
+// ComputeRequest returns the compute request with id.
+func (s *Store) ComputeRequest(did string, id int) (gateway.ComputeRequestResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.lookupComputeRequest(did, id)
+	if err != nil {
+		return gateway.ComputeRequestResponse{}, err
+	}
+	return c.response(), nil
+}
+
+// MyComputeRequests lists the compute requests created by the account acting
+// with did.
+func (s *Store) MyComputeRequests(did string) ([]gateway.ComputeRequestResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.caller(did); err != nil {
+		return nil, err
+	}
+
+	requests := []gateway.ComputeRequestResponse{}
+	for _, c := range s.findComputeRequests(func(c *computeRequest) bool { return c.createdBy == did }) {
+		requests = append(requests, c.response())
+	}
+	return requests, nil
+}
+
+// ReceivedComputeRequests lists the compute requests of other accounts over
+// data models the account acting with did holds assets of.
+func (s *Store) ReceivedComputeRequests(did string) ([]gateway.ComputeRequestReceivedResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.caller(did); err != nil {
+		return nil, err
+	}
+
+	requests := []gateway.ComputeRequestReceivedResponse{}
+	for _, c := range s.findComputeRequests(func(c *computeRequest) bool { return c.createdBy != did }) {
+		if ids := s.ownedModelAssets(c, did); len(ids) > 0 {
+			requests = append(requests, c.received(ids))
+		}
+	}
+	return requests, nil
+}
+
+// AcceptComputeRequest contributes a data asset of the account acting with
+// did to a compute request.
+func (s *Store) AcceptComputeRequest(did string, id int, assetID int) (gateway.ComputeRequestResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.lookupComputeRequest(did, id)
+	if err != nil {
+		return gateway.ComputeRequestResponse{}, err
+	}
+
+	if !slices.Contains(s.ownedModelAssets(c, did), assetID) {
+		return gateway.ComputeRequestResponse{}, badRequest("data asset is not yours or does not match the data model")
+	}
+	if slices.ContainsFunc(c.accepted, func(item acceptance) bool { return item.assetID == assetID }) {
+		return gateway.ComputeRequestResponse{}, apiError(http.StatusConflict, "data asset already accepted")
+	}
+
+	c.accepted = append(c.accepted, acceptance{by: did, assetID: assetID})
+	c.updatedAt = timestamp()
+
+	return c.response(), nil
+}
+
+// StartComputeRequest runs a compute request over its accepted data assets;
+// only its creator may.
+func (s *Store) StartComputeRequest(did string, id int) (gateway.ComputingProcessResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.lookupComputeRequest(did, id)
+	if err != nil {
+		return gateway.ComputingProcessResponse{}, err
+	}
+	if c.createdBy != did {
+		return gateway.ComputingProcessResponse{}, apiError(http.StatusForbidden, "only the creator can start a compute request")
+	}
+
+	result, err := c.compute(s.assets)
+	if err != nil {
+		return gateway.ComputingProcessResponse{}, err
+	}
+
+	processID, request, status := s.nextID(), c.id, "completed"
+	return gateway.ComputingProcessResponse{
+		Id:             &processID,
+		ComputeRequest: &request,
+		ComputeResult:  &result,
+		ComputeStatus:  &status,
+		CreatedBy:      &did,
+	}, nil
+}
@@ -0,0 +1,516 @@
+package memstore
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+)
+
+// Upload is a file data asset together with the ACL and expiration date
+// sent alongside it.
+type Upload struct {
+	File
+	ACL            []gateway.ACLRequest
+	ExpirationDate *string
+}
+
+// File is the content of a data asset as it is downloaded.
+type File struct {
+	Name    string
+	Type    string
+	Content []byte
+}
+
+type aclEntry struct {
+	address   string
+	roles     []gateway.TypesAccessLevel
+	createdAt string
+	updatedAt string
+}
+
+type dataAsset struct {
+	gateway.PublicDataAsset
+
+	owner   string
+	claim   map[string]interface{}
+	content []byte
+	acl     []*aclEntry
+}
+
+var allRoles = []gateway.TypesAccessLevel{gateway.RoleView, gateway.RoleUpdate, gateway.RoleDelete, gateway.RoleShare}
+
+// roles returns the access caller has to asset. Owners hold every role;
+// everyone else gets the roles granted to their DID or one of their wallets.
+func (asset *dataAsset) roles(caller *account) []gateway.TypesAccessLevel {
+	if asset.owner == caller.did {
+		return allRoles
+	}
+
+	var roles []gateway.TypesAccessLevel
+	for _, entry := range asset.acl {
+		if !ownsAddress(caller, entry.address) {
+			continue
+		}
+		for _, role := range entry.roles {
+			if !slices.Contains(roles, role) {
+				roles = append(roles, role)
+			}
+		}
+	}
+	return roles
+}
+
+func (asset *dataAsset) expired() bool {
+	if asset.ExpirationDate == nil {
+		return false
+	}
+	expiration, err := time.Parse(time.RFC3339, *asset.ExpirationDate)
+	return err == nil && expiration.Before(time.Now())
+}
+
+func ownsAddress(caller *account, address string) bool {
+	if address == caller.did {
+		return true
+	}
+	for _, wallet := range caller.wallets {
+		if normalizeAddress(wallet.Address) == normalizeAddress(address) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Store) publicACL(entry *aclEntry) gateway.PublicACL {
+	acl := gateway.PublicACL{
+		Address:   entry.address,
+		CreatedAt: &entry.createdAt,
+		UpdatedAt: &entry.updatedAt,
+		Roles:     []string{},
+	}
+	for _, role := range entry.roles {
+		acl.Roles = append(acl.Roles, string(role))
+	}
+
+	did := entry.address
+	if owner, ok := s.wallets[normalizeAddress(entry.address)]; ok {
+		did = owner
+	}
+	if _, ok := s.accounts[did]; ok {
+		acl.Did = &did
+	}
+	if chain, ok := walletChain(entry.address); ok && chain == gateway.Solana {
+		acl.SolanaAddress = entry.address
+	}
+
+	return acl
+}
+
+func (s *Store) publicAsset(asset *dataAsset) gateway.PublicDataAsset {
+	public := asset.PublicDataAsset
+	public.Acl = []gateway.PublicACL{}
+	for _, entry := range asset.acl {
+		public.Acl = append(public.Acl, s.publicACL(entry))
+	}
+	return public
+}
+
+// findAssets returns the live assets matching keep, ordered by id.
+func (s *Store) findAssets(keep func(asset *dataAsset) bool) []gateway.PublicDataAsset {
+	var found []*dataAsset
+	for _, asset := range s.assets {
+		if !asset.expired() && keep(asset) {
+			found = append(found, asset)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Id < found[j].Id })
+
+	public := make([]gateway.PublicDataAsset, 0, len(found))
+	for _, asset := range found {
+		public = append(public, s.publicAsset(asset))
+	}
+	return public
+}
+
+// lookupAsset returns the asset with id when the account acting with did
+// holds role on it. Assets the caller cannot see at all are reported as
+// missing.
+func (s *Store) lookupAsset(did string, id int, role gateway.TypesAccessLevel) (*dataAsset, error) {
+	caller, err := s.caller(did)
+	if err != nil {
+		return nil, err
+	}
+
+	asset, ok := s.assets[id]
+	if !ok || asset.expired() {
+		return nil, apiError(http.StatusNotFound, "data asset not found")
+	}
+
+	roles := asset.roles(caller)
+	if len(roles) == 0 {
+		return nil, apiError(http.StatusNotFound, "data asset not found")
+	}
+	if !slices.Contains(roles, role) {
+		return nil, apiError(http.StatusForbidden, "missing %s access to data asset", role)
+	}
+
+	return asset, nil
+}
+
+// grant merges roles into the ACL entry of address, or replaces them when
+// replace is set.
+func (asset *dataAsset) grant(address string, roles []gateway.TypesAccessLevel, replace bool) *aclEntry {
+	now := timestamp()
+
+	for _, entry := range asset.acl {
+		if normalizeAddress(entry.address) != normalizeAddress(address) {
+			continue
+		}
+		if replace {
+			entry.roles = nil
+		}
+		for _, role := range roles {
+			if !slices.Contains(entry.roles, role) {
+				entry.roles = append(entry.roles, role)
+			}
+		}
+		entry.updatedAt = now
+		return entry
+	}
+
+	entry := &aclEntry{
+		address:   address,
+		roles:     append([]gateway.TypesAccessLevel{}, roles...),
+		createdAt: now,
+		updatedAt: now,
+	}
+	asset.acl = append(asset.acl, entry)
+	return entry
+}
+
+func validRoles(acl []gateway.ACLRequest) error {
+	for _, item := range acl {
+		if item.Address == "" {
+			return badRequest("acl address is required")
+		}
+		for _, role := range item.Roles {
+			if !slices.Contains(allRoles, role) {
+				return badRequest("unknown role %q", role)
+			}
+		}
+	}
+	return nil
+}
+
+// setFile replaces the content of asset with upload.
+func (asset *dataAsset) setFile(upload Upload) error {
+	if err := validRoles(upload.ACL); err != nil {
+		return err
+	}
+	if upload.ExpirationDate != nil {
+		if _, err := time.Parse(time.RFC3339, *upload.ExpirationDate); err != nil {
+			return badRequest("invalid expiration_date: %v", err)
+		}
+		asset.ExpirationDate = upload.ExpirationDate
+	}
+	for _, item := range upload.ACL {
+		asset.grant(item.Address, item.Roles, false)
+	}
+
+	fileType := upload.Type
+	if fileType == "" || fileType == "application/octet-stream" {
+		fileType = http.DetectContentType(upload.Content)
+	}
+
+	asset.Name = upload.Name
+	asset.Type = fileType
+	asset.Size = len(upload.Content)
+	asset.content = append([]byte{}, upload.Content...)
+	asset.claim = nil
+
+	return nil
+}
+
+func (s *Store) newAsset(did string) (*dataAsset, error) {
+	if _, err := s.caller(did); err != nil {
+		return nil, err
+	}
+
+	now := timestamp()
+	asset := &dataAsset{owner: did}
+	asset.CreatedBy = did
+	asset.CreatedAt = &now
+	asset.UpdatedAt = &now
+	return asset, nil
+}
+
+func (s *Store) addAsset(asset *dataAsset) gateway.DataAssetIDRequestAndResponse {
+	asset.Id = s.nextID()
+	asset.Fid = randomHex(16)
+	asset.TransactionId = randomHex(32)
+	s.assets[asset.Id] = asset
+
+	return gateway.DataAssetIDRequestAndResponse{Id: asset.Id}
+}
+
+// CreateAsset stores a claim data asset owned by the account acting with did.
+func (s *Store) CreateAsset(did string, req gateway.CreateDataAssetRequest) (gateway.DataAssetIDRequestAndResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	asset, err := s.newAsset(did)
+	if err != nil {
+		return gateway.DataAssetIDRequestAndResponse{}, err
+	}
+
+	if req.Name == "" {
+		return gateway.DataAssetIDRequestAndResponse{}, badRequest("name is required")
+	}
+	if req.DataModelId != nil {
+		if _, ok := s.models[*req.DataModelId]; !ok {
+			return gateway.DataAssetIDRequestAndResponse{}, badRequest("data model not found")
+		}
+	}
+	if req.Acl != nil {
+		if err := validRoles(*req.Acl); err != nil {
+			return gateway.DataAssetIDRequestAndResponse{}, err
+		}
+		for _, item := range *req.Acl {
+			asset.grant(item.Address, item.Roles, false)
+		}
+	}
+
+	asset.Name = req.Name
+	asset.Type = "claim"
+	asset.DataModelId = req.DataModelId
+	asset.ExpirationDate = req.ExpirationDate
+	asset.Tags = req.Tags
+	if req.Claim != nil {
+		asset.claim = *req.Claim
+	}
+	claim, _ := json.Marshal(asset.claim)
+	asset.Size = len(claim)
+
+	return s.addAsset(asset), nil
+}
+
+// UploadFile stores a file data asset owned by the account acting with did.
+func (s *Store) UploadFile(did string, upload Upload) (gateway.DataAssetIDRequestAndResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	asset, err := s.newAsset(did)
+	if err != nil {
+		return gateway.DataAssetIDRequestAndResponse{}, err
+	}
+	if err := asset.setFile(upload); err != nil {
+		return gateway.DataAssetIDRequestAndResponse{}, err
+	}
+
+	return s.addAsset(asset), nil
+}
+
+// Asset returns the data asset with id; the caller needs view access.
+func (s *Store) Asset(did string, id int) (gateway.PublicDataAsset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	asset, err := s.lookupAsset(did, id, gateway.RoleView)
+	if err != nil {
+		return gateway.PublicDataAsset{}, err
+	}
+	return s.publicAsset(asset), nil
+}
+
+// UpdateAsset changes the name, claim or expiration date of a data asset;
+// the caller needs update access.
+func (s *Store) UpdateAsset(did string, id int, req gateway.UpdateDataAssetRequest) (gateway.PublicDataAsset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	asset, err := s.lookupAsset(did, id, gateway.RoleUpdate)
+	if err != nil {
+		return gateway.PublicDataAsset{}, err
+	}
+
+	if req.Name != nil {
+		asset.Name = *req.Name
+	}
+	if req.Claim != nil {
+		asset.claim = *req.Claim
+	}
+	if req.ExpirationDate != nil {
+		asset.ExpirationDate = req.ExpirationDate
+	}
+
+	now := timestamp()
+	asset.UpdatedAt = &now
+
+	return s.publicAsset(asset), nil
+}
+
+// UpdateFile replaces the content of a data asset; the caller needs update
+// access.
+func (s *Store) UpdateFile(did string, id int, upload Upload) (gateway.PublicDataAsset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	asset, err := s.lookupAsset(did, id, gateway.RoleUpdate)
+	if err != nil {
+		return gateway.PublicDataAsset{}, err
+	}
+	if err := asset.setFile(upload); err != nil {
+		return gateway.PublicDataAsset{}, err
+	}
+
+	now := timestamp()
+	asset.UpdatedAt = &now
+
+	return s.publicAsset(asset), nil
+}
+
+// DeleteAsset removes a data asset; the caller needs delete access.
+func (s *Store) DeleteAsset(did string, id int) (gateway.MessageResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	asset, err := s.lookupAsset(did, id, gateway.RoleDelete)
+	if err != nil {
+		return gateway.MessageResponse{}, err
+	}
+
+	delete(s.assets, asset.Id)
+	return gateway.MessageResponse{Message: "data asset deleted"}, nil
+}
+
+// Download returns the content of a data asset; claims are returned as
+// JSON. The caller needs view access.
+func (s *Store) Download(did string, id int) (File, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	asset, err := s.lookupAsset(did, id, gateway.RoleView)
+	if err != nil {
+		return File{}, err
+	}
+
+	if asset.content == nil {
+		claim, _ := json.Marshal(asset.claim)
+		return File{Name: asset.Name, Type: "application/json", Content: claim}, nil
+	}
+	return File{Name: asset.Name, Type: asset.Type, Content: append([]byte{}, asset.content...)}, nil
+}
+
+// Share grants view access on a data asset to the given addresses; the
+// caller needs share access.
+func (s *Store) Share(did string, id int, req []gateway.ShareDataAssetRequest) ([]gateway.PublicACL, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	asset, err := s.lookupAsset(did, id, gateway.RoleShare)
+	if err != nil {
+		return nil, err
+	}
+
+	shared := []gateway.PublicACL{}
+	for _, item := range req {
+		for _, address := range item.Addresses {
+			entry := asset.grant(address, []gateway.TypesAccessLevel{gateway.RoleView}, false)
+			shared = append(shared, s.publicACL(entry))
+		}
+	}
+	return shared, nil
+}
+
+// AddACL merges roles into the ACL of a data asset; the caller needs share
+// access.
+func (s *Store) AddACL(did string, id int, acl []gateway.ACLRequest) (gateway.PublicACL, error) {
+	return s.changeACL(did, id, acl, false)
+}
+
+// UpdateACL replaces the roles of the listed ACL entries of a data asset;
+// the caller needs share access.
+func (s *Store) UpdateACL(did string, id int, acl []gateway.ACLRequest) (gateway.PublicACL, error) {
+	return s.changeACL(did, id, acl, true)
+}
+
+func (s *Store) changeACL(did string, id int, acl []gateway.ACLRequest, replace bool) (gateway.PublicACL, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	asset, err := s.lookupAsset(did, id, gateway.RoleShare)
+	if err != nil {
+		return gateway.PublicACL{}, err
+	}
+	if len(acl) == 0 {
+		return gateway.PublicACL{}, badRequest("acl list is empty")
+	}
+	if err := validRoles(acl); err != nil {
+		return gateway.PublicACL{}, err
+	}
+
+	var first *aclEntry
+	for _, item := range acl {
+		entry := asset.grant(item.Address, item.Roles, replace)
+		if first == nil {
+			first = entry
+		}
+	}
+	return s.publicACL(first), nil
+}
+
+// DeleteACL removes the entries for the listed addresses from the ACL of a
+// data asset; the caller needs share access.
+func (s *Store) DeleteACL(did string, id int, acl []gateway.ACLRequest) (gateway.MessageResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	asset, err := s.lookupAsset(did, id, gateway.RoleShare)
+	if err != nil {
+		return gateway.MessageResponse{}, err
+	}
+
+	asset.acl = slices.DeleteFunc(asset.acl, func(entry *aclEntry) bool {
+		return slices.ContainsFunc(acl, func(item gateway.ACLRequest) bool {
+			return normalizeAddress(item.Address) == normalizeAddress(entry.address)
+		})
+	})
+	return gateway.MessageResponse{Message: "acl entries deleted"}, nil
+}
+
+// CreatedAssets lists the data assets owned by the account acting with did.
+func (s *Store) CreatedAssets(did string) ([]gateway.PublicDataAsset, error) {
+	return s.callerAssets(did, func(asset *dataAsset, caller *account) bool {
+		return asset.owner == caller.did
+	})
+}
+
+// ReceivedAssets lists the data assets shared with the account acting with
+// did.
+func (s *Store) ReceivedAssets(did string) ([]gateway.PublicDataAsset, error) {
+	return s.callerAssets(did, func(asset *dataAsset, caller *account) bool {
+		return asset.owner != caller.did && len(asset.roles(caller)) > 0
+	})
+}
+
+// AccessibleAssets lists every data asset the account acting with did can
+// see.
+func (s *Store) AccessibleAssets(did string) ([]gateway.PublicDataAsset, error) {
+	return s.callerAssets(did, func(asset *dataAsset, caller *account) bool {
+		return len(asset.roles(caller)) > 0
+	})
+}
+
+func (s *Store) callerAssets(did string, keep func(asset *dataAsset, caller *account) bool) ([]gateway.PublicDataAsset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	caller, err := s.caller(did)
+	if err != nil {
+		return nil, err
+	}
+	return s.findAssets(func(asset *dataAsset) bool { return keep(asset, caller) }), nil
+}
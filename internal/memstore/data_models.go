@@ -0,0 +1,156 @@
+package memstore
+
+import (
+	"net/http"
+	"sort"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+)
+
+type dataModel struct {
+	gateway.DataModelResponse
+
+	owner string
+}
+
+func (s *Store) findModels(keep func(model *dataModel) bool) []gateway.DataModelResponse {
+	found := []gateway.DataModelResponse{}
+	for _, model := range s.models {
+		if keep(model) {
+			found = append(found, model.DataModelResponse)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Id < found[j].Id })
+	return found
+}
+
+func (s *Store) lookupModel(id int) (*dataModel, error) {
+	model, ok := s.models[id]
+	if !ok {
+		return nil, apiError(http.StatusNotFound, "data model not found")
+	}
+	return model, nil
+}
+
+// DataModels lists every data model.
+func (s *Store) DataModels(did string) ([]gateway.DataModelResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.caller(did); err != nil {
+		return nil, err
+	}
+	return s.findModels(func(*dataModel) bool { return true }), nil
+}
+
+// MyDataModels lists the data models created by the account acting with did.
+func (s *Store) MyDataModels(did string) ([]gateway.DataModelResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.caller(did); err != nil {
+		return nil, err
+	}
+	return s.findModels(func(model *dataModel) bool { return model.owner == did }), nil
+}
+
+// DataModel returns the data model with id.
+func (s *Store) DataModel(did string, id int) (gateway.DataModelResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.caller(did); err != nil {
+		return gateway.DataModelResponse{}, err
+	}
+	model, err := s.lookupModel(id)
+	if err != nil {
+		return gateway.DataModelResponse{}, err
+	}
+	return model.DataModelResponse, nil
+}
+
+// CreateDataModel stores a data model created by the account acting with did.
+func (s *Store) CreateDataModel(did string, req gateway.DataModelCreateRequest) (gateway.DataModelResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.caller(did); err != nil {
+		return gateway.DataModelResponse{}, err
+	}
+	if req.Title == "" {
+		return gateway.DataModelResponse{}, badRequest("title is required")
+	}
+	if req.Schema == nil {
+		return gateway.DataModelResponse{}, badRequest("schema is required")
+	}
+
+	now := timestamp()
+	model := &dataModel{
+		DataModelResponse: gateway.DataModelResponse{
+			Id:          s.nextID(),
+			Title:       req.Title,
+			Description: req.Description,
+			Schema:      req.Schema,
+			Tags:        req.Tags,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		},
+		owner: did,
+	}
+	s.models[model.Id] = model
+
+	return model.DataModelResponse, nil
+}
+
+// UpdateDataModel changes a data model; only its creator may.
+func (s *Store) UpdateDataModel(did string, id int, req gateway.DataModelUpdateRequest) (gateway.DataModelResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.caller(did); err != nil {
+		return gateway.DataModelResponse{}, err
+	}
+	model, err := s.lookupModel(id)
+	if err != nil {
+		return gateway.DataModelResponse{}, err
+	}
+	if model.owner != did {
+		return gateway.DataModelResponse{}, apiError(http.StatusForbidden, "only the creator can update a data model")
+	}
+
+	if req.Title != nil {
+		model.Title = *req.Title
+	}
+	if req.Description != nil {
+		model.Description = *req.Description
+	}
+	if req.Tags != nil {
+		model.Tags = req.Tags
+	}
+	if req.Schema != nil {
+		model.Schema = req.Schema
+	}
+	model.UpdatedAt = timestamp()
+
+	return model.DataModelResponse, nil
+}
+
+// ModelAssets lists the data assets of a data model that the account acting
+// with did can see.
+func (s *Store) ModelAssets(did string, id int) ([]gateway.PublicDataAsset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	caller, err := s.caller(did)
+	if err != nil {
+		return nil, err
+	}
+	model, err := s.lookupModel(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.findAssets(func(asset *dataAsset) bool {
+		return asset.DataModelId != nil && *asset.DataModelId == model.Id && len(asset.roles(caller)) > 0
+	}), nil
+}
@@ -0,0 +1,129 @@
+// Package memstore holds the in-memory Gateway state shared by the
+// gatewaytest server and the gatewayfake test doubles, so both enforce the
+// same rules.
+package memstore
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	gateway "github.com/Gateway-DAO/gateway-go-sdk/client"
+)
+
+const (
+	DefaultTokenTTL   = time.Hour
+	DefaultMessageTTL = 5 * time.Minute
+
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+// Store is the state of one stand-in Gateway deployment. Every method is
+// safe for concurrent use; errors are *gateway.APIError values carrying the
+// status the real API would answer with.
+type Store struct {
+	mu       sync.Mutex
+	secret   []byte
+	tokenTTL time.Duration
+	messages map[string]time.Time
+	accounts map[string]*account
+	wallets  map[string]string
+	assets   map[int]*dataAsset
+	models   map[int]*dataModel
+	computes map[int]*computeRequest
+	lastID   int
+}
+
+func New() *Store {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(fmt.Sprintf("memstore: generating token secret: %v", err))
+	}
+
+	return &Store{
+		secret:   secret,
+		tokenTTL: DefaultTokenTTL,
+		messages: make(map[string]time.Time),
+		accounts: make(map[string]*account),
+		wallets:  make(map[string]string),
+		assets:   make(map[int]*dataAsset),
+		models:   make(map[int]*dataModel),
+		computes: make(map[int]*computeRequest),
+	}
+}
+
+// SetTokenTTL changes the lifetime of the tokens issued from now on.
+func (s *Store) SetTokenTTL(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokenTTL = ttl
+}
+
+func (s *Store) nextID() int {
+	s.lastID++
+	return s.lastID
+}
+
+func apiError(status int, format string, args ...interface{}) *gateway.APIError {
+	return &gateway.APIError{StatusCode: status, Message: fmt.Sprintf(format, args...)}
+}
+
+func badRequest(format string, args ...interface{}) *gateway.APIError {
+	return apiError(http.StatusBadRequest, format, args...)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// Paginate returns one page of items the way the list endpoints do, with
+// links to the neighbouring pages of path.
+func Paginate[T any](items []T, page int, pageSize int, path string) gateway.HelperPaginatedResponse[[]T] {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	pageSize = min(pageSize, MaxPageSize)
+
+	totalPages := (len(items) + pageSize - 1) / pageSize
+	start := min((page-1)*pageSize, len(items))
+	end := min(start+pageSize, len(items))
+
+	link := func(page int) string {
+		return fmt.Sprintf("%s?page=%d&page_size=%d", path, page, pageSize)
+	}
+
+	res := gateway.HelperPaginatedResponse[[]T]{
+		Data: append([]T{}, items[start:end]...),
+		Meta: gateway.HelperMeta{
+			CurrentPage:  page,
+			ItemsPerPage: pageSize,
+			TotalItems:   len(items),
+			TotalPages:   totalPages,
+		},
+	}
+	if totalPages > 0 {
+		res.Links.First = link(1)
+		res.Links.Last = link(totalPages)
+	}
+	if page < totalPages {
+		res.Links.Next = link(page + 1)
+	}
+	if page > 1 && page <= totalPages {
+		res.Links.Previous = link(page - 1)
+	}
+
+	return res
+}
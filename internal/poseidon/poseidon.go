@@ -0,0 +1,209 @@
+// Package poseidon implements the Poseidon hash over the BN254 scalar field
+// with the parameters of circomlib, which Sui zkLogin uses.
+//
+// The round constants and MDS matrices are not embedded: they are derived
+// on first use with the Grain LFSR of the Poseidon reference implementation
+// (generate_parameters_grain.sage), which is how circomlib produced them.
+// The reference script regenerates an MDS matrix that fails its security
+// checks; that step is skipped here because the first matrix passes for
+// every width circomlib supports, which the tests pin against circomlib's
+// embedded constants.
+package poseidon
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+const (
+	// MaxInputs is the most elements Hash accepts.
+	MaxInputs = 16
+
+	fullRounds = 8
+	fieldBits  = 254
+)
+
+// partialRounds are circomlib's partial round counts for widths 2 to 17.
+var partialRounds = [MaxInputs]int{56, 57, 56, 60, 60, 63, 64, 63, 60, 66, 60, 65, 70, 60, 64, 68}
+
+type parameters struct {
+	constants []fr.Element
+	mds       [][]fr.Element
+	partial   int
+}
+
+var (
+	paramsOnce [MaxInputs]sync.Once
+	params     [MaxInputs]*parameters
+)
+
+func parametersFor(width int) *parameters {
+	i := width - 2
+	paramsOnce[i].Do(func() {
+		params[i] = generate(width, partialRounds[i])
+	})
+	return params[i]
+}
+
+// Hash returns the circomlib Poseidon hash of 1 to MaxInputs elements.
+func Hash(inputs []*big.Int) (*big.Int, error) {
+	if len(inputs) == 0 || len(inputs) > MaxInputs {
+		return nil, fmt.Errorf("poseidon: expected 1 to %d inputs, got %d", MaxInputs, len(inputs))
+	}
+
+	modulus := fr.Modulus()
+	width := len(inputs) + 1
+	state := make([]fr.Element, width)
+	for i, input := range inputs {
+		if input.Sign() < 0 || input.Cmp(modulus) >= 0 {
+			return nil, errors.New("poseidon: input is not a field element")
+		}
+		state[i+1].SetBigInt(input)
+	}
+
+	p := parametersFor(width)
+	rounds := fullRounds + p.partial
+	next := make([]fr.Element, width)
+	for r := 0; r < rounds; r++ {
+		for i := range state {
+			state[i].Add(&state[i], &p.constants[r*width+i])
+		}
+
+		if r < fullRounds/2 || r >= fullRounds/2+p.partial {
+			for i := range state {
+				pow5(&state[i])
+			}
+		} else {
+			pow5(&state[0])
+		}
+
+		for i := range next {
+			next[i].SetZero()
+			for j := range state {
+				var term fr.Element
+				term.Mul(&p.mds[i][j], &state[j])
+				next[i].Add(&next[i], &term)
+			}
+		}
+		state, next = next, state
+	}
+
+	return state[0].BigInt(new(big.Int)), nil
+}
+
+func pow5(x *fr.Element) {
+	var x2, x4 fr.Element
+	x2.Square(x)
+	x4.Square(&x2)
+	x.Mul(x, &x4)
+}
+
+// grain is the self-shrinking Grain LFSR the Poseidon reference
+// implementation draws its parameters from.
+type grain struct {
+	state [80]byte
+}
+
+func newGrain(width int, partial int) *grain {
+	g := &grain{}
+	bits := g.state[:0]
+	appendBits := func(value int, n int) {
+		for i := n - 1; i >= 0; i-- {
+			bits = append(bits, byte(value>>i)&1)
+		}
+	}
+	appendBits(1, 2) // prime field
+	appendBits(0, 4) // x^alpha S-box
+	appendBits(fieldBits, 12)
+	appendBits(width, 12)
+	appendBits(fullRounds, 10)
+	appendBits(partial, 10)
+	appendBits(1<<30-1, 30)
+
+	for i := 0; i < 160; i++ {
+		g.step()
+	}
+	return g
+}
+
+func (g *grain) step() byte {
+	s := &g.state
+	bit := s[62] ^ s[51] ^ s[38] ^ s[23] ^ s[13] ^ s[0]
+	copy(s[:], s[1:])
+	s[79] = bit
+	return bit
+}
+
+// bit returns the next output bit: pairs of bits are drawn and the second
+// is kept when the first is 1.
+func (g *grain) bit() byte {
+	for g.step() == 0 {
+		g.step()
+	}
+	return g.step()
+}
+
+func (g *grain) bits(n int) *big.Int {
+	v := new(big.Int)
+	for i := 0; i < n; i++ {
+		v.Lsh(v, 1)
+		if g.bit() == 1 {
+			v.SetBit(v, 0, 1)
+		}
+	}
+	return v
+}
+
+func generate(width int, partial int) *parameters {
+	g := newGrain(width, partial)
+	modulus := fr.Modulus()
+
+	p := &parameters{partial: partial}
+	p.constants = make([]fr.Element, (fullRounds+partial)*width)
+	for i := range p.constants {
+		v := g.bits(fieldBits)
+		for v.Cmp(modulus) >= 0 {
+			v = g.bits(fieldBits)
+		}
+		p.constants[i].SetBigInt(v)
+	}
+
+	// The MDS matrix is the Cauchy matrix 1/(x_i + y_j) of 2*width distinct
+	// draws, reduced modulo the field.
+	for {
+		draws := make([]fr.Element, 2*width)
+		for distinct := false; !distinct; {
+			seen := map[fr.Element]bool{}
+			distinct = true
+			for i := range draws {
+				draws[i].SetBigInt(g.bits(fieldBits))
+				if seen[draws[i]] {
+					distinct = false
+				}
+				seen[draws[i]] = true
+			}
+		}
+
+		mds := make([][]fr.Element, width)
+		ok := true
+		for i := range mds {
+			mds[i] = make([]fr.Element, width)
+			for j := range mds[i] {
+				var sum fr.Element
+				sum.Add(&draws[i], &draws[width+j])
+				if sum.IsZero() {
+					ok = false
+				}
+				mds[i][j].Inverse(&sum)
+			}
+		}
+		if ok {
+			p.mds = mds
+			return p
+		}
+	}
+}
@@ -50,15 +50,11 @@ func GenerateTypes() {
 
 	routeConstants := GenerateRouteConstants(schema.Paths)
 
-	outputTypes := "package client\n\n" + `import "github.com/go-resty/resty/v2"` + "\n\n" + `
+	outputTypes := "package client\n\n" + `
 	type WalletSignMessageType struct {
 	Signature  string
 	SigningKey string
 }` + "\n\n" + `
-		type Config struct {
-		Client *resty.Client
-	}
-	` + "\n \n" + `
 		type Error struct {
 		Error string 
 		}